@@ -1,20 +1,40 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/database"
 	"github.com/haninhammoud01/go-academic-service/internal/config"
 	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/handler"
 	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/middleware"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/eventsink"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/health"
 	"github.com/haninhammoud01/go-academic-service/internal/pkg/jwt"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/seatfeed"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/tracing"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/webhook"
 	postgresRepo "github.com/haninhammoud01/go-academic-service/internal/repository/postgres"
+	sqliteRepo "github.com/haninhammoud01/go-academic-service/internal/repository/sqlite"
 	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
 // @title Go Academic Service API
@@ -26,43 +46,164 @@ import (
 // @in header
 // @name Authorization
 func main() {
-	cfg, err := config.LoadConfig()
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "config" && args[1] == "print" {
+		printConfig(args[2:])
+		return
+	}
+
+	cfg, err := config.Load(args)
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+
+	slog.SetDefault(logger.New(os.Stdout, cfg.Log.Format, cfg.Log.Level))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName: cfg.App.Name,
+		Environment: cfg.App.Env,
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("failed to set up tracing", err)
 	}
 
 	db, err := initDatabase(cfg)
 	if err != nil {
-		log.Fatalf("Failed to connect database: %v", err)
+		fatal("failed to connect database", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("failed to get database handle", err)
+	}
+	if err := metrics.RegisterDBStats(sqlDB, databaseName(cfg)); err != nil {
+		fatal("failed to register database metrics", err)
 	}
 
-	if err := runMigrations(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+	replicas, err := openReplicas(cfg)
+	if err != nil {
+		fatal("failed to open read replicas", err)
+	}
+	if err := postgresRepo.RegisterReplicas(db, replicas); err != nil {
+		fatal("failed to register read replicas", err)
+	}
+	for i, replica := range replicas {
+		name := "replica:" + cfg.Database.ReplicaHosts[i]
+		if err := metrics.RegisterDBStats(replica, name); err != nil {
+			fatal("failed to register replica metrics", err)
+		}
+	}
+
+	migrations, err := database.ForDriver(cfg.Database.Driver)
+	if err != nil {
+		fatal("failed to read embedded migrations", err)
+	}
+	if err := runMigrations(cfg, db, migrations); err != nil {
+		fatal("failed to run migrations", err)
+	}
+
+	schemaVersion, err := database.LatestVersion(migrations)
+	if err != nil {
+		fatal("failed to read embedded migrations", err)
+	}
+
+	checker := health.NewChecker(cfg.App.ReadinessTimeout)
+	checker.Register("database", postgresRepo.PingCheck(sqlDB))
+	checker.Register("migrations", postgresRepo.MigrationCheck(sqlDB, schemaVersion))
+	checker.Register("connection_pool", postgresRepo.PoolCheck(sqlDB))
+	for i, replica := range replicas {
+		checker.Register("replica:"+cfg.Database.ReplicaHosts[i], postgresRepo.PingCheck(replica))
 	}
 
 	if cfg.App.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.Default()
+	router := gin.New()
+	router.Use(
+		middleware.Tracing(cfg.App.Name),
+		middleware.RequestID(),
+		middleware.Actor(),
+		middleware.AccessLog(),
+		middleware.Metrics(),
+		middleware.ErrorHandler(cfg.App.Env == "development"),
+		middleware.Recovery(),
+		middleware.CORS(cfg.CORS),
+	)
 
 	// Initialize JWT Service
 	jwtService := jwt.NewJWTService(cfg.JWT.Secret, cfg.JWT.Expired)
 
 	// Initialize Repositories
-	userRepo := postgresRepo.NewUserRepository(db)
-	studentRepo := postgresRepo.NewStudentRepository(db)
-	lecturerRepo := postgresRepo.NewLecturerRepository(db)
+	timeouts := postgresRepo.QueryTimeouts{
+		Default: cfg.Database.StatementTimeout,
+		List:    cfg.Database.ListStatementTimeout,
+		Report:  cfg.Database.ReportStatementTimeout,
+	}
+	userRepo := postgresRepo.NewUserRepository(db, timeouts)
+	studentRepo := postgresRepo.NewStudentRepository(db, timeouts)
+	lecturerRepo := postgresRepo.NewLecturerRepository(db, timeouts)
+	courseRepo := postgresRepo.NewCourseRepository(db, timeouts)
+	enrollmentRepo := postgresRepo.NewEnrollmentRepository(db, timeouts)
+	searchRepo := postgresRepo.NewSearchRepository(db, timeouts)
+	auditRepo := postgresRepo.NewAuditRepository(db, timeouts)
+	outboxRepo := postgresRepo.NewOutboxRepository(db, timeouts)
+	webhookSubscriptionRepo := postgresRepo.NewWebhookSubscriptionRepository(db, timeouts)
+	webhookDeliveryRepo := postgresRepo.NewWebhookDeliveryRepository(db, timeouts)
+	waitlistRepo := postgresRepo.NewWaitlistRepository(db, timeouts)
+	advisorRepo := postgresRepo.NewAdvisorRepository(db, timeouts)
+	krsRepo := postgresRepo.NewKRSRepository(db, timeouts)
+	seatBus := postgresRepo.NewSeatChangeBus(db)
+	txManager := postgresRepo.NewTxManager(db)
 
 	// Initialize Use Cases
-	authUseCase := usecase.NewAuthUseCase(userRepo, jwtService)
-	studentUseCase := usecase.NewStudentUseCase(studentRepo)
-	lecturerUseCase := usecase.NewLecturerUseCase(lecturerRepo)
+	auditor := usecase.NewAuditor(auditRepo, cfg.Audit.HashChain)
+	outbox := usecase.NewOutbox(outboxRepo)
+	seatHub := seatfeed.NewHub(cfg.SeatFeed.Buffer, cfg.SeatFeed.MaxSubscribers, cfg.SeatFeed.MaxPerClient)
+	if err := metrics.RegisterSeatFeed(seatHub.Subscribers); err != nil {
+		fatal("failed to register seat feed metrics", err)
+	}
+	seatKRS := entity.KRSSeatStatuses(cfg.Enrollment.CountSeatsFrom)
+	seatFeed := usecase.NewSeatFeed(seatBus, courseRepo, enrollmentRepo, seatHub, seatKRS)
+	authUseCase := usecase.NewTracedAuthUseCase(usecase.NewAuthUseCase(userRepo, jwtService, txManager, auditor))
+	studentUseCase := usecase.NewTracedStudentUseCase(usecase.NewStudentUseCase(studentRepo, txManager, auditor, outbox))
+	lecturerUseCase := usecase.NewTracedLecturerUseCase(usecase.NewLecturerUseCase(lecturerRepo, txManager, auditor))
+	courseUseCase := usecase.NewTracedCourseUseCase(usecase.NewCourseUseCase(courseRepo, txManager, auditor))
+	enrollmentUseCase := usecase.NewTracedEnrollmentUseCase(usecase.NewEnrollmentUseCase(enrollmentRepo, studentRepo, courseRepo, waitlistRepo, krsRepo, txManager, auditor, outbox, seatFeed, cfg.Enrollment.MaxCredits, seatKRS))
+	waitlistUseCase := usecase.NewTracedWaitlistUseCase(usecase.NewWaitlistUseCase(waitlistRepo, studentRepo, courseRepo, enrollmentRepo, txManager, auditor, seatKRS))
+	advisorUseCase := usecase.NewTracedAdvisorUseCase(usecase.NewAdvisorUseCase(advisorRepo, studentRepo, lecturerRepo, krsRepo, txManager, auditor))
+	krsUseCase := usecase.NewTracedKRSUseCase(usecase.NewKRSUseCase(krsRepo, studentRepo, enrollmentRepo, courseRepo, lecturerRepo, advisorRepo, enrollmentUseCase, txManager, auditor, outbox, seatFeed, seatKRS))
+	exportUseCase := usecase.NewTracedExportUseCase(usecase.NewExportUseCase(studentRepo, lecturerRepo, enrollmentRepo))
+	searchUseCase := usecase.NewTracedSearchUseCase(usecase.NewSearchUseCase(searchRepo))
+	trashUseCase := usecase.NewTracedTrashUseCase(usecase.NewTrashUseCase(studentRepo, lecturerRepo, courseRepo, enrollmentRepo, txManager, auditor, cfg.Trash.Retention))
+	auditUseCase := usecase.NewTracedAuditUseCase(usecase.NewAuditUseCase(auditRepo, cfg.Audit.HashChain))
+	webhookPolicy := webhook.NewPolicy(cfg.Webhooks.AllowedHosts)
+	webhookUseCase := usecase.NewTracedWebhookUseCase(usecase.NewWebhookUseCase(webhookSubscriptionRepo, webhookDeliveryRepo, txManager, auditor, webhookPolicy))
 
 	// Initialize Handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
-	studentHandler := handler.NewStudentHandler(studentUseCase)
-	lecturerHandler := handler.NewLecturerHandler(lecturerUseCase)
+	pageLimits := pagination.Limits{
+		DefaultPageSize: cfg.Pagination.DefaultPageSize,
+		MaxPageSize:     cfg.Pagination.MaxPageSize,
+	}
+	studentHandler := handler.NewStudentHandler(studentUseCase, pageLimits)
+	lecturerHandler := handler.NewLecturerHandler(lecturerUseCase, pageLimits)
+	courseHandler := handler.NewCourseHandler(courseUseCase, pageLimits)
+	seatHandler := handler.NewSeatHandler(seatFeed, cfg.SeatFeed.Heartbeat)
+	enrollmentHandler := handler.NewEnrollmentHandler(enrollmentUseCase, pageLimits)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistUseCase)
+	advisorHandler := handler.NewAdvisorHandler(advisorUseCase)
+	krsHandler := handler.NewKRSHandler(krsUseCase, pageLimits)
+	exportHandler := handler.NewExportHandler(exportUseCase)
+	searchHandler := handler.NewSearchHandler(searchUseCase)
+	trashHandler := handler.NewTrashHandler(trashUseCase, pageLimits)
+	auditHandler := handler.NewAuditHandler(auditUseCase, pageLimits)
+	webhookHandler := handler.NewWebhookHandler(webhookUseCase, pageLimits)
+	healthHandler := handler.NewHealthHandler(checker)
 
 	// Initialize Middleware
 	authMiddleware := middleware.NewAuthMiddleware(jwtService)
@@ -71,9 +212,17 @@ func main() {
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok", "message": "Service is running"})
 	})
+	router.GET("/livez", healthHandler.Livez)
+	router.GET("/readyz", healthHandler.Readyz)
+
+	// Prometheus scrape endpoint
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	// API v1
 	v1 := router.Group("/api/v1")
+	if cfg.RateLimit.Enabled {
+		v1.Use(middleware.RateLimit(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst))
+	}
 	{
 		v1.GET("/ping", func(c *gin.Context) {
 			c.JSON(200, gin.H{"message": "pong"})
@@ -81,11 +230,20 @@ func main() {
 
 		// Auth routes (public)
 		auth := v1.Group("/auth")
+		if cfg.RateLimit.Enabled {
+			auth.Use(middleware.RateLimit(cfg.RateLimit.AuthPerSecond, cfg.RateLimit.AuthBurst))
+		}
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
 		}
 
+		// Seat routes (public): seat counts are not sensitive, and the
+		// browser EventSource cannot send an Authorization header. Streams
+		// are capped per client address instead.
+		v1.GET("/courses/:id/seats", seatHandler.Get)
+		v1.GET("/courses/:id/seats/stream", seatHandler.Stream)
+
 		// Protected routes
 		protected := v1.Group("")
 		protected.Use(authMiddleware.Authenticate())
@@ -97,7 +255,13 @@ func main() {
 				students.GET("", studentHandler.GetAll)
 				students.GET("/:id", studentHandler.GetByID)
 				students.PUT("/:id", authMiddleware.RequireRole("admin", "staff"), studentHandler.Update)
+				students.PATCH("/:id", authMiddleware.RequireRole("admin", "staff"), studentHandler.Update)
 				students.DELETE("/:id", authMiddleware.RequireRole("admin"), studentHandler.Delete)
+				students.GET("/:id/advisor", advisorHandler.Get)
+				students.GET("/:id/advisor/history", advisorHandler.History)
+				students.PUT("/:id/advisor", authMiddleware.RequireRole("admin", "staff"), advisorHandler.Assign)
+				students.DELETE("/:id/advisor", authMiddleware.RequireRole("admin", "staff"), advisorHandler.Unassign)
+				students.GET("/:id/krs", krsHandler.ListByStudent)
 			}
 
 			// Lecturers routes
@@ -107,63 +271,391 @@ func main() {
 				lecturers.GET("", lecturerHandler.GetAll)
 				lecturers.GET("/:id", lecturerHandler.GetByID)
 				lecturers.PUT("/:id", authMiddleware.RequireRole("admin", "staff"), lecturerHandler.Update)
+				lecturers.PATCH("/:id", authMiddleware.RequireRole("admin", "staff"), lecturerHandler.Update)
 				lecturers.DELETE("/:id", authMiddleware.RequireRole("admin"), lecturerHandler.Delete)
 			}
+
+			// Courses routes
+			courses := protected.Group("/courses")
+			{
+				courses.POST("", authMiddleware.RequireRole("admin", "staff"), courseHandler.Create)
+				courses.GET("", courseHandler.GetAll)
+				courses.GET("/:id", courseHandler.GetByID)
+				courses.PUT("/:id", authMiddleware.RequireRole("admin", "staff"), courseHandler.Update)
+				courses.PATCH("/:id", authMiddleware.RequireRole("admin", "staff"), courseHandler.Update)
+				courses.DELETE("/:id", authMiddleware.RequireRole("admin"), courseHandler.Delete)
+			}
+
+			// Enrollments routes
+			enrollments := protected.Group("/enrollments")
+			{
+				enrollments.POST("", authMiddleware.RequireRole("admin", "staff"), enrollmentHandler.Create)
+				enrollments.GET("", enrollmentHandler.GetAll)
+				enrollments.GET("/:id", enrollmentHandler.GetByID)
+				enrollments.PUT("/:id", authMiddleware.RequireRole("admin", "staff"), enrollmentHandler.Update)
+				enrollments.PATCH("/:id", authMiddleware.RequireRole("admin", "staff"), enrollmentHandler.Update)
+				enrollments.DELETE("/:id", authMiddleware.RequireRole("admin", "staff"), enrollmentHandler.Delete)
+			}
+
+			// Waitlist routes
+			waitlists := protected.Group("/waitlists")
+			{
+				waitlists.POST("", authMiddleware.RequireRole("admin", "staff", "student"), waitlistHandler.Join)
+				waitlists.GET("", authMiddleware.RequireRole("admin"), waitlistHandler.List)
+				waitlists.GET("/:id", authMiddleware.RequireRole("admin", "staff"), waitlistHandler.GetByID)
+				waitlists.PATCH("/:id", authMiddleware.RequireRole("admin"), waitlistHandler.Move)
+				waitlists.DELETE("/:id", authMiddleware.RequireRole("admin", "staff"), waitlistHandler.Remove)
+			}
+
+			// KRS routes
+			krs := protected.Group("/krs")
+			{
+				krs.GET("/inbox", authMiddleware.RequireRole("admin", "staff"), krsHandler.Inbox)
+				krs.GET("/:id", krsHandler.GetByID)
+				krs.POST("/:id/submit", authMiddleware.RequireRole("admin", "student"), krsHandler.Submit)
+				krs.POST("/:id/reviews", authMiddleware.RequireRole("admin", "staff"), krsHandler.Review)
+			}
+
+			// Export routes
+			exports := protected.Group("/exports")
+			exports.Use(authMiddleware.RequireRole("admin", "staff"))
+			{
+				exports.GET("/:resource", exportHandler.Export)
+				exports.GET("/jobs/:id", exportHandler.GetJob)
+				exports.GET("/jobs/:id/download", exportHandler.Download)
+			}
+
+			// Search route
+			protected.GET("/search", searchHandler.Search)
+
+			// Trash routes
+			trash := protected.Group("/admin/trash")
+			trash.Use(authMiddleware.RequireRole("admin"))
+			{
+				trash.GET("/:resource", trashHandler.List)
+				trash.POST("/:resource/:id/restore", trashHandler.Restore)
+				trash.DELETE("/:resource/:id", trashHandler.Purge)
+			}
+
+			// Audit routes
+			audit := protected.Group("/admin/audit")
+			audit.Use(authMiddleware.RequireRole("admin"))
+			{
+				audit.GET("", auditHandler.List)
+				audit.GET("/verify", auditHandler.Verify)
+			}
+
+			// Webhook routes
+			webhooks := protected.Group("/admin/webhooks")
+			webhooks.Use(authMiddleware.RequireRole("admin"))
+			{
+				webhooks.POST("", webhookHandler.Create)
+				webhooks.GET("", webhookHandler.List)
+				webhooks.GET("/deliveries", webhookHandler.ListDeliveries)
+				webhooks.GET("/deliveries/:id", webhookHandler.GetDelivery)
+				webhooks.POST("/deliveries/:id/redeliver", webhookHandler.Redeliver)
+				webhooks.GET("/:id", webhookHandler.GetByID)
+				webhooks.PATCH("/:id", webhookHandler.Update)
+				webhooks.DELETE("/:id", webhookHandler.Delete)
+				webhooks.GET("/:id/deliveries", webhookHandler.ListSubscriptionDeliveries)
+			}
 		}
 	}
 
-	addr := fmt.Sprintf(":%s", cfg.App.Port)
-	log.Printf("Starting %s on %s", cfg.App.Name, addr)
-	log.Println("")
-	log.Println("📚 Available Endpoints:")
-	log.Println("   GET    /health")
-	log.Println("")
-	log.Println("🔐 Authentication (Public):")
-	log.Println("   POST   /api/v1/auth/register")
-	log.Println("   POST   /api/v1/auth/login")
-	log.Println("")
-	log.Println("👥 Students (Protected):")
-	log.Println("   POST   /api/v1/students          [admin, staff]")
-	log.Println("   GET    /api/v1/students          [authenticated]")
-	log.Println("   GET    /api/v1/students/:id      [authenticated]")
-	log.Println("   PUT    /api/v1/students/:id      [admin, staff]")
-	log.Println("   DELETE /api/v1/students/:id      [admin]")
-	log.Println("")
-	log.Println("👨‍🏫 Lecturers (Protected):")
-	log.Println("   POST   /api/v1/lecturers         [admin, staff]")
-	log.Println("   GET    /api/v1/lecturers         [authenticated]")
-	log.Println("   GET    /api/v1/lecturers/:id     [authenticated]")
-	log.Println("   PUT    /api/v1/lecturers/:id     [admin, staff]")
-	log.Println("   DELETE /api/v1/lecturers/:id     [admin]")
-
-	if err := router.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.App.Port),
+		Handler:           router,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
 	}
+	// Seat streams never finish on their own; end them when shutdown starts.
+	srv.RegisterOnShutdown(seatHub.Close)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go seatFeed.Run(ctx)
+
+	if cfg.Trash.PurgeInterval > 0 {
+		go usecase.RunTrashPurger(ctx, trashUseCase, cfg.Trash.PurgeInterval)
+	}
+
+	sink, err := openEventSink(cfg)
+	if err != nil {
+		fatal("failed to open event sink", err)
+	}
+	if cfg.Webhooks.Enabled {
+		fanout := usecase.NewWebhookFanout(webhookSubscriptionRepo, webhookDeliveryRepo)
+		if sink != nil {
+			sink = eventsink.Tee(sink, fanout)
+		} else {
+			sink = fanout
+		}
+	}
+	relayDone := make(chan struct{})
+	if sink != nil {
+		relay := usecase.NewOutboxRelay(outboxRepo, sink, cfg.Outbox.BatchSize, cfg.Outbox.MaxAttempts, cfg.Outbox.Retention)
+		go func() {
+			defer close(relayDone)
+			relay.Run(ctx, cfg.Outbox.RelayInterval)
+		}()
+	} else {
+		close(relayDone)
+	}
+
+	var sender *webhook.Sender
+	dispatcherDone := make(chan struct{})
+	if cfg.Webhooks.Enabled {
+		sender = webhook.NewSender(cfg.Webhooks.Timeout, webhookPolicy)
+		dispatcher := usecase.NewWebhookDispatcher(webhookSubscriptionRepo, webhookDeliveryRepo, sender,
+			cfg.Webhooks.BatchSize, cfg.Webhooks.MaxAttempts, cfg.Webhooks.Retention)
+		go func() {
+			defer close(dispatcherDone)
+			dispatcher.Run(ctx, cfg.Webhooks.DispatchInterval)
+		}()
+	} else {
+		close(dispatcherDone)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("starting server", "app", cfg.App.Name, "env", cfg.App.Env, "addr", srv.Addr, "tls", cfg.Server.TLSEnabled())
+		var err error
+		if cfg.Server.TLSEnabled() {
+			err = srv.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		fatal("failed to start server", err)
+	case <-ctx.Done():
+	}
+	// Restore default signal handling so a second SIGTERM kills the process.
+	stop()
+
+	pools := append([]*sql.DB{sqlDB}, replicas...)
+	if err := shutdown(cfg, srv, checker, exportUseCase, relayDone, sink, dispatcherDone, sender, pools, shutdownTracing); err != nil {
+		fatal("shutdown did not complete cleanly", err)
+	}
+	slog.Info("server stopped")
+}
+
+// shutdown stops the service in dependency order: readiness goes to
+// draining first, then the listener closes and in-flight requests finish,
+// then background jobs, the outbox relay and the webhook dispatcher, the
+// database pools and finally the trace exporter.
+// Everything after the drain delay shares one ShutdownTimeout deadline.
+func shutdown(
+	cfg *config.Config,
+	srv *http.Server,
+	checker *health.Checker,
+	exports usecase.ExportUseCase,
+	relayDone <-chan struct{},
+	sink eventsink.Sink,
+	dispatcherDone <-chan struct{},
+	sender *webhook.Sender,
+	pools []*sql.DB,
+	flushTraces func(context.Context) error,
+) error {
+	slog.Info("shutting down", "drain_delay", cfg.Server.DrainDelay, "timeout", cfg.Server.ShutdownTimeout)
+	checker.SetDraining()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := srv.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("http server: %w", err))
+	}
+	if err := exports.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("export jobs: %w", err))
+	}
+	// The relay stopped with the signal context; wait for its last publish.
+	select {
+	case <-relayDone:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("outbox relay: %w", ctx.Err()))
+	}
+	if sink != nil {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("event sink: %w", err))
+		}
+	}
+	// Likewise for the dispatcher's last delivery.
+	select {
+	case <-dispatcherDone:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("webhook dispatcher: %w", ctx.Err()))
+	}
+	if sender != nil {
+		sender.Close()
+	}
+	for _, pool := range pools {
+		if err := pool.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("database: %w", err))
+		}
+	}
+	if err := flushTraces(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("tracing: %w", err))
+	}
+	return errors.Join(errs...)
 }
 
 func initDatabase(cfg *config.Config) (*gorm.DB, error) {
-	dsn := cfg.Database.DSN()
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
+	gormConfig := &gorm.Config{
+		Logger: logger.NewGormLogger(cfg.Database.SlowQueryThreshold),
+	}
+	var (
+		db       *gorm.DB
+		dbSystem string
+		err      error
+	)
+	if cfg.Database.IsSQLite() {
+		db, err = sqliteRepo.Open(cfg.Database.SQLitePath, gormConfig)
+		dbSystem = "sqlite"
+	} else {
+		db, err = gorm.Open(postgres.Open(cfg.Database.DSN()), gormConfig)
+		dbSystem = "postgresql"
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
-	log.Println("Database connected successfully")
+	// Query parameters stay out of spans for the same reason they stay out
+	// of the logs; pool metrics are already exported to Prometheus.
+	if err := db.Use(gormtracing.NewPlugin(
+		gormtracing.WithDBSystem(dbSystem),
+		gormtracing.WithoutQueryVariables(),
+		gormtracing.WithoutMetrics(),
+	)); err != nil {
+		return nil, fmt.Errorf("failed to enable database tracing: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
+	}
+	configurePool(sqlDB, cfg)
+
+	if cfg.Database.IsSQLite() {
+		slog.Info("database connected", "driver", cfg.Database.Driver, "path", cfg.Database.SQLitePath)
+	} else {
+		slog.Info("database connected", "driver", cfg.Database.Driver, "host", cfg.Database.Host, "name", cfg.Database.Name)
+	}
 	return db, nil
 }
 
-func runMigrations(db *gorm.DB) error {
-	log.Println("Running database migrations...")
+// openEventSink opens the external sink the outbox relay publishes to, or
+// returns nil when there is none. The relay still runs without one when
+// webhooks are enabled, to queue their deliveries.
+func openEventSink(cfg *config.Config) (eventsink.Sink, error) {
+	switch cfg.Outbox.Sink {
+	case "log":
+		return eventsink.NewLogSink(cfg.Outbox.LogPath)
+	case "webhook":
+		return eventsink.NewWebhookSink(cfg.Outbox.WebhookURL, cfg.Outbox.WebhookTimeout), nil
+	}
+	return nil, nil
+}
+
+// databaseName labels the primary pool in metrics.
+func databaseName(cfg *config.Config) string {
+	if cfg.Database.IsSQLite() {
+		return cfg.Database.SQLitePath
+	}
+	return cfg.Database.Name
+}
+
+// openReplicas opens one pool per configured read replica. Connections
+// are established lazily, so an unreachable replica fails readiness
+// rather than startup.
+func openReplicas(cfg *config.Config) ([]*sql.DB, error) {
+	replicas := make([]*sql.DB, 0, len(cfg.Database.ReplicaHosts))
+	for _, host := range cfg.Database.ReplicaHosts {
+		replica, err := sql.Open("pgx", cfg.Database.ReplicaDSN(host))
+		if err != nil {
+			return nil, fmt.Errorf("replica %s: %w", host, err)
+		}
+		configurePool(replica, cfg)
+		replicas = append(replicas, replica)
+		slog.Info("read replica configured", "host", host)
+	}
+	return replicas, nil
+}
+
+func configurePool(pool *sql.DB, cfg *config.Config) {
+	pool.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	pool.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	pool.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	pool.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+}
+
+// runMigrations applies the embedded SQLite migrations, which also record
+// the schema version MigrationCheck expects. Postgres schemas are owned by
+// the migrate CLI; AutoMigrate only fills in what is missing.
+func runMigrations(cfg *config.Config, db *gorm.DB, migrations fs.FS) error {
+	slog.Info("running database migrations", "driver", cfg.Database.Driver)
+	if cfg.Database.IsSQLite() {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("failed to get database handle: %w", err)
+		}
+		if err := sqliteRepo.Migrate(context.Background(), sqlDB, migrations); err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+		slog.Info("migrations completed")
+		return nil
+	}
 	if err := db.AutoMigrate(
 		&entity.User{},
 		&entity.Student{},
 		&entity.Lecturer{},
 		&entity.Course{},
 		&entity.Enrollment{},
+		&entity.AuditLog{},
+		&entity.OutboxEvent{},
+		&entity.WebhookSubscription{},
+		&entity.WebhookDelivery{},
+		&entity.WaitlistEntry{},
+		&entity.AdvisorAssignment{},
+		&entity.KRSSubmission{},
+		&entity.KRSReview{},
 	); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
-	log.Println("Migrations completed")
+	slog.Info("migrations completed")
 	return nil
 }
+
+// printConfig implements "config print": it shows the effective
+// configuration after all layers, with secrets redacted. Validation errors
+// are reported after the output so a broken config can still be inspected.
+func printConfig(args []string) {
+	cfg, err := config.Read(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := cfg.WriteRedacted(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "\ninvalid configuration:\n"+err.Error())
+		os.Exit(1)
+	}
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haninhammoud01/go-academic-service/internal/config"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/eventsink"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/health"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+// steps records the order in which shutdown reaches each dependency.
+type steps struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (s *steps) add(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.names = append(s.names, name)
+}
+
+func (s *steps) list() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.names)
+}
+
+type exportJobs struct {
+	usecase.ExportUseCase
+	steps *steps
+	err   error
+}
+
+func (e *exportJobs) Shutdown(context.Context) error {
+	e.steps.add("export jobs")
+	return e.err
+}
+
+type sink struct {
+	eventsink.Sink
+	steps *steps
+}
+
+func (k *sink) Close() error {
+	k.steps.add("event sink")
+	return nil
+}
+
+// stopped is a relay that has already finished.
+func stopped() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// pool is a database/sql driver whose connections record when they are
+// closed.
+type pool struct {
+	name  string
+	steps *steps
+}
+
+func (p *pool) Connect(context.Context) (driver.Conn, error) { return &poolConn{p}, nil }
+func (p *pool) Driver() driver.Driver                        { return p }
+func (p *pool) Open(string) (driver.Conn, error)             { return &poolConn{p}, nil }
+
+type poolConn struct {
+	*pool
+}
+
+func (c *poolConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *poolConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *poolConn) Close() error {
+	c.steps.add(c.name)
+	return nil
+}
+
+// serving starts srv on a free port and returns its base URL.
+func serving(t *testing.T, srv *http.Server) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Serve(ln)
+	return "http://" + ln.Addr().String()
+}
+
+// openPools opens a pool per name; each records its name when closed.
+func openPools(t *testing.T, s *steps, names ...string) []*sql.DB {
+	t.Helper()
+	var pools []*sql.DB
+	for _, name := range names {
+		db := sql.OpenDB(&pool{name: name, steps: s})
+		// Ping leaves an idle connection for Close to close.
+		if err := db.Ping(); err != nil {
+			t.Fatal(err)
+		}
+		pools = append(pools, db)
+	}
+	return pools
+}
+
+func TestShutdownOrder(t *testing.T) {
+	s := &steps{}
+	checker := health.NewChecker(time.Second)
+	started, release := make(chan struct{}), make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		s.add("in-flight request")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if checker.Run(r.Context()).Status != health.StatusUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	srv := &http.Server{Handler: mux}
+	base := serving(t, srv)
+
+	inFlight := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			inFlight <- 0
+			return
+		}
+		resp.Body.Close()
+		inFlight <- resp.StatusCode
+	}()
+	<-started
+
+	cfg := &config.Config{Server: config.ServerConfig{DrainDelay: 500 * time.Millisecond, ShutdownTimeout: 5 * time.Second}}
+	flush := func(context.Context) error {
+		s.add("traces")
+		return nil
+	}
+	pools := openPools(t, s, "primary", "replica")
+	done := make(chan error, 1)
+	go func() {
+		done <- shutdown(cfg, srv, checker, &exportJobs{steps: s}, stopped(), &sink{steps: s}, stopped(), nil, pools, flush)
+	}()
+
+	// While draining, readiness fails but requests are still served.
+	for !checker.Draining() {
+		time.Sleep(time.Millisecond)
+	}
+	resp, err := http.Get(base + "/readyz")
+	if err != nil {
+		t.Fatalf("GET /readyz while draining: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GET /readyz while draining = %d, want 503", resp.StatusCode)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("shutdown() = %v", err)
+	}
+	if code := <-inFlight; code != http.StatusOK {
+		t.Errorf("in-flight request = %d, want it to complete with 200", code)
+	}
+	want := []string{"in-flight request", "export jobs", "event sink", "primary", "replica", "traces"}
+	if got := s.list(); !slices.Equal(got, want) {
+		t.Errorf("shutdown order = %v, want %v", got, want)
+	}
+}
+
+func TestShutdownGoesOnAfterAFailure(t *testing.T) {
+	s := &steps{}
+	srv := &http.Server{Handler: http.NewServeMux()}
+	serving(t, srv)
+
+	cfg := &config.Config{Server: config.ServerConfig{ShutdownTimeout: time.Second}}
+	jobs := &exportJobs{steps: s, err: errors.New("job still writing")}
+	flush := func(context.Context) error {
+		s.add("traces")
+		return errors.New("collector unreachable")
+	}
+
+	err := shutdown(cfg, srv, health.NewChecker(time.Second), jobs, stopped(), nil, stopped(), nil, openPools(t, s, "primary"), flush)
+	if err == nil || err.Error() != "export jobs: job still writing\ntracing: collector unreachable" {
+		t.Errorf("shutdown() = %v, want the export and tracing failures", err)
+	}
+	if want := []string{"export jobs", "primary", "traces"}; !slices.Equal(s.list(), want) {
+		t.Errorf("shutdown order = %v, want %v", s.list(), want)
+	}
+}
@@ -0,0 +1,72 @@
+// File: database/migrations.go
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// Migrations holds the golang-migrate files for Postgres so the binary
+// knows which schema version it was built against.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// SQLiteMigrations holds the same schema for SQLite, version for version.
+// The service applies them itself on startup.
+//
+//go:embed migrations/sqlite/*.sql
+var SQLiteMigrations embed.FS
+
+// ForDriver returns the migrations of a database driver ("postgres" or
+// "sqlite") with the .sql files at the root.
+func ForDriver(driver string) (fs.FS, error) {
+	switch driver {
+	case "postgres":
+		return fs.Sub(Migrations, "migrations")
+	case "sqlite":
+		return fs.Sub(SQLiteMigrations, "migrations/sqlite")
+	default:
+		return nil, fmt.Errorf("no migrations for database driver %q", driver)
+	}
+}
+
+// Version parses the version prefix of a migration file name such as
+// 000006_add_version_columns.up.sql.
+func Version(name string) (uint, error) {
+	prefix, _, ok := strings.Cut(name, "_")
+	if !ok {
+		return 0, fmt.Errorf("malformed migration file name %q", name)
+	}
+	version, err := strconv.ParseUint(prefix, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed migration file name %q: %w", name, err)
+	}
+	return uint(version), nil
+}
+
+// LatestVersion returns the highest version among the up migrations at
+// the root of migrations.
+func LatestVersion(migrations fs.FS) (uint, error) {
+	entries, err := fs.ReadDir(migrations, ".")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		version, err := Version(name)
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
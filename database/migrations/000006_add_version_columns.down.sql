@@ -0,0 +1,4 @@
+ALTER TABLE enrollments DROP COLUMN IF EXISTS version;
+ALTER TABLE courses DROP COLUMN IF EXISTS version;
+ALTER TABLE lecturers DROP COLUMN IF EXISTS version;
+ALTER TABLE students DROP COLUMN IF EXISTS version;
@@ -0,0 +1,9 @@
+-- ============================================
+-- Migration 6: Optimistic Concurrency Versions
+-- File: database/migrations/000006_add_version_columns.up.sql
+-- ============================================
+
+ALTER TABLE students ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE lecturers ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE enrollments ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+-- ============================================
+-- Migration 7: Keyset Pagination Indexes
+-- File: database/migrations/000007_add_keyset_indexes.up.sql
+-- ============================================
+
+-- Lists page newest first by (created_at, id). These indexes serve both
+-- directions and stop each page from sorting the whole table.
+CREATE INDEX IF NOT EXISTS idx_students_created_at_id ON students(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_lecturers_created_at_id ON lecturers(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_enrollments_created_at_id ON enrollments(created_at DESC, id DESC);
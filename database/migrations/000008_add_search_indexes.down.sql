@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_courses_name_trgm;
+DROP INDEX IF EXISTS idx_lecturers_name_trgm;
+DROP INDEX IF EXISTS idx_students_name_trgm;
+DROP INDEX IF EXISTS idx_courses_search;
+DROP INDEX IF EXISTS idx_lecturers_search;
+DROP INDEX IF EXISTS idx_students_search;
+DROP FUNCTION IF EXISTS search_fold(TEXT);
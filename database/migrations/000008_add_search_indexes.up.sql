@@ -0,0 +1,32 @@
+-- ============================================
+-- Migration 8: Search Indexes
+-- File: database/migrations/000008_add_search_indexes.up.sql
+-- ============================================
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- search_fold lowercases, rewrites old Indonesian spellings (oe, dj, tj,
+-- sj, nj) and ch to their current form and collapses repeated letters, so
+-- Soekarno finds Sukarno and Muhamad finds Muhammad. It must stay in step
+-- with textsearch.Fold, which folds the search terms.
+CREATE OR REPLACE FUNCTION search_fold(value TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE AS $$
+    SELECT regexp_replace(
+        replace(replace(replace(replace(replace(replace(lower(value),
+            'oe', 'u'), 'dj', 'j'), 'tj', 'c'), 'sj', 'sy'), 'nj', 'ny'), 'ch', 'kh'),
+        '([[:alpha:]])\1+', '\1', 'g')
+$$;
+
+-- Word-prefix matching and ranking on name and number or code. The
+-- queries repeat these expressions exactly, or the indexes go unused.
+CREATE INDEX IF NOT EXISTS idx_students_search ON students
+    USING GIN (to_tsvector('simple', search_fold(name) || ' ' || search_fold(nim)));
+CREATE INDEX IF NOT EXISTS idx_lecturers_search ON lecturers
+    USING GIN (to_tsvector('simple', search_fold(name) || ' ' || search_fold(nip)));
+CREATE INDEX IF NOT EXISTS idx_courses_search ON courses
+    USING GIN (to_tsvector('simple', search_fold(name) || ' ' || search_fold(code)));
+
+-- Misspelt names, through the <% operator.
+CREATE INDEX IF NOT EXISTS idx_students_name_trgm ON students USING GIN (search_fold(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_lecturers_name_trgm ON lecturers USING GIN (search_fold(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_courses_name_trgm ON courses USING GIN (search_fold(name) gin_trgm_ops);
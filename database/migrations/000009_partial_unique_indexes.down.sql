@@ -0,0 +1,28 @@
+-- Restoring the full constraints fails while a deleted row shares a value
+-- with another row; purge or rename such rows first.
+DROP INDEX IF EXISTS idx_enrollments_deleted_at;
+DROP INDEX IF EXISTS idx_courses_deleted_at;
+DROP INDEX IF EXISTS idx_lecturers_deleted_at;
+DROP INDEX IF EXISTS idx_students_deleted_at;
+
+DROP INDEX IF EXISTS idx_enrollments_student_id_course_id_academic_year_semester;
+ALTER TABLE enrollments ADD CONSTRAINT enrollments_student_id_course_id_academic_year_semester_key
+    UNIQUE (student_id, course_id, academic_year, semester);
+
+DROP INDEX IF EXISTS idx_courses_code;
+ALTER TABLE courses ADD CONSTRAINT courses_code_key UNIQUE (code);
+CREATE INDEX idx_courses_code ON courses(code);
+
+DROP INDEX IF EXISTS idx_lecturers_email;
+DROP INDEX IF EXISTS idx_lecturers_nip;
+ALTER TABLE lecturers ADD CONSTRAINT lecturers_nip_key UNIQUE (nip);
+ALTER TABLE lecturers ADD CONSTRAINT lecturers_email_key UNIQUE (email);
+CREATE INDEX idx_lecturers_nip ON lecturers(nip);
+CREATE INDEX idx_lecturers_email ON lecturers(email);
+
+DROP INDEX IF EXISTS idx_students_email;
+DROP INDEX IF EXISTS idx_students_nim;
+ALTER TABLE students ADD CONSTRAINT students_nim_key UNIQUE (nim);
+ALTER TABLE students ADD CONSTRAINT students_email_key UNIQUE (email);
+CREATE INDEX idx_students_nim ON students(nim);
+CREATE INDEX idx_students_email ON students(email);
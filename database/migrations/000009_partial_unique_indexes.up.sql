@@ -0,0 +1,37 @@
+-- ============================================
+-- Migration 9: Partial Unique Indexes
+-- File: database/migrations/000009_partial_unique_indexes.up.sql
+-- ============================================
+
+-- Soft-deleted rows no longer hold on to their NIM, NIP, email, course
+-- code or enrollment slot: uniqueness only applies to live rows, so a
+-- deleted record can be re-created. Restoring it fails while a live row
+-- holds the same value. The partial indexes take over the names of the
+-- plain lookup indexes they replace.
+ALTER TABLE students DROP CONSTRAINT IF EXISTS students_nim_key;
+ALTER TABLE students DROP CONSTRAINT IF EXISTS students_email_key;
+DROP INDEX IF EXISTS idx_students_nim;
+DROP INDEX IF EXISTS idx_students_email;
+CREATE UNIQUE INDEX idx_students_nim ON students(nim) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_students_email ON students(email) WHERE deleted_at IS NULL;
+
+ALTER TABLE lecturers DROP CONSTRAINT IF EXISTS lecturers_nip_key;
+ALTER TABLE lecturers DROP CONSTRAINT IF EXISTS lecturers_email_key;
+DROP INDEX IF EXISTS idx_lecturers_nip;
+DROP INDEX IF EXISTS idx_lecturers_email;
+CREATE UNIQUE INDEX idx_lecturers_nip ON lecturers(nip) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_lecturers_email ON lecturers(email) WHERE deleted_at IS NULL;
+
+ALTER TABLE courses DROP CONSTRAINT IF EXISTS courses_code_key;
+DROP INDEX IF EXISTS idx_courses_code;
+CREATE UNIQUE INDEX idx_courses_code ON courses(code) WHERE deleted_at IS NULL;
+
+ALTER TABLE enrollments DROP CONSTRAINT IF EXISTS enrollments_student_id_course_id_academic_year_semester_key;
+CREATE UNIQUE INDEX idx_enrollments_student_id_course_id_academic_year_semester
+    ON enrollments(student_id, course_id, academic_year, semester) WHERE deleted_at IS NULL;
+
+-- The trash lists and the purge look rows up by deletion time.
+CREATE INDEX IF NOT EXISTS idx_students_deleted_at ON students(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_lecturers_deleted_at ON lecturers(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_courses_deleted_at ON courses(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_enrollments_deleted_at ON enrollments(deleted_at) WHERE deleted_at IS NOT NULL;
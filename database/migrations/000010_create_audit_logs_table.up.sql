@@ -0,0 +1,37 @@
+-- ============================================
+-- Migration 10: Audit Log
+-- File: database/migrations/000010_create_audit_logs_table.up.sql
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge')),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    actor_id UUID,
+    actor_role VARCHAR(20),
+    ip VARCHAR(45),
+    request_id VARCHAR(128),
+    changes JSONB NOT NULL DEFAULT '{}',
+    prev_hash VARCHAR(64),
+    hash VARCHAR(64),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- entity_id and actor_id are not foreign keys: entries outlive the
+-- records and users they mention.
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity_type, entity_id, id);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id, id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- The log is append-only.
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$;
+
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS krs_reviews;
+DROP TABLE IF EXISTS krs_submissions;
+DROP TABLE IF EXISTS advisor_assignments;
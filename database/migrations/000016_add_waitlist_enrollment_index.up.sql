@@ -0,0 +1,7 @@
+-- ============================================
+-- Migration 16: Waitlist Enrollment Index
+-- File: database/migrations/000016_add_waitlist_enrollment_index.up.sql
+-- ============================================
+
+-- Seat counts look for the waitlist entry an enrollment was promoted from.
+CREATE INDEX idx_waitlist_entries_enrollment_id ON waitlist_entries(enrollment_id);
@@ -0,0 +1,4 @@
+ALTER TABLE enrollments DROP COLUMN version;
+ALTER TABLE courses DROP COLUMN version;
+ALTER TABLE lecturers DROP COLUMN version;
+ALTER TABLE students DROP COLUMN version;
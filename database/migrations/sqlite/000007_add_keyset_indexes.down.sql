@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_enrollments_created_at_id;
+DROP INDEX IF EXISTS idx_lecturers_created_at_id;
+DROP INDEX IF EXISTS idx_students_created_at_id;
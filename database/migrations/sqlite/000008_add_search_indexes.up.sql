@@ -0,0 +1,10 @@
+-- ============================================
+-- Migration 8: Search Indexes
+-- File: database/migrations/sqlite/000008_add_search_indexes.up.sql
+-- ============================================
+
+-- SQLite has no full-text or trigram index the search could share with
+-- Postgres, and search_fold is registered by the service rather than
+-- stored in the file. Search scans the tables; this version only keeps
+-- the numbering in step.
+SELECT 1;
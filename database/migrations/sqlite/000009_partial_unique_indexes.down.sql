@@ -0,0 +1,109 @@
+-- Restoring the UNIQUE constraints fails while a deleted row shares a
+-- value with another row; purge or rename such rows first.
+
+CREATE TABLE students_new (
+    id TEXT PRIMARY KEY,
+    nim VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) UNIQUE NOT NULL,
+    phone VARCHAR(20),
+    address TEXT,
+    date_of_birth DATE,
+    gender VARCHAR(10) CHECK (gender IN ('male', 'female')),
+    major VARCHAR(100) NOT NULL,
+    enrollment_year INTEGER NOT NULL,
+    status VARCHAR(20) DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'graduated', 'dropped')),
+    gpa DECIMAL(3,2) DEFAULT 0.00,
+    user_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+INSERT INTO students_new (id, nim, name, email, phone, address, date_of_birth, gender, major, enrollment_year, status, gpa, user_id, created_at, updated_at, deleted_at, version)
+SELECT id, nim, name, email, phone, address, date_of_birth, gender, major, enrollment_year, status, gpa, user_id, created_at, updated_at, deleted_at, version FROM students;
+DROP TABLE students;
+ALTER TABLE students_new RENAME TO students;
+CREATE INDEX idx_students_major ON students(major);
+CREATE INDEX idx_students_status ON students(status);
+CREATE INDEX idx_students_created_at_id ON students(created_at DESC, id DESC);
+
+CREATE TABLE lecturers_new (
+    id TEXT PRIMARY KEY,
+    nip VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) UNIQUE NOT NULL,
+    phone VARCHAR(20),
+    address TEXT,
+    date_of_birth DATE,
+    gender VARCHAR(10) CHECK (gender IN ('male', 'female')),
+    department VARCHAR(100) NOT NULL,
+    position VARCHAR(50),
+    specialization VARCHAR(100),
+    education_level VARCHAR(50),
+    status VARCHAR(20) DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'retired')),
+    user_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+INSERT INTO lecturers_new (id, nip, name, email, phone, address, date_of_birth, gender, department, position, specialization, education_level, status, user_id, created_at, updated_at, deleted_at, version)
+SELECT id, nip, name, email, phone, address, date_of_birth, gender, department, position, specialization, education_level, status, user_id, created_at, updated_at, deleted_at, version FROM lecturers;
+DROP TABLE lecturers;
+ALTER TABLE lecturers_new RENAME TO lecturers;
+CREATE INDEX idx_lecturers_department ON lecturers(department);
+CREATE INDEX idx_lecturers_created_at_id ON lecturers(created_at DESC, id DESC);
+
+CREATE TABLE courses_new (
+    id TEXT PRIMARY KEY,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    credits INTEGER NOT NULL CHECK (credits > 0),
+    semester INTEGER NOT NULL CHECK (semester > 0),
+    department VARCHAR(100) NOT NULL,
+    course_type VARCHAR(50) CHECK (course_type IN ('mandatory', 'elective')),
+    max_students INTEGER DEFAULT 40,
+    lecturer_id TEXT REFERENCES lecturers(id) ON DELETE SET NULL,
+    status VARCHAR(20) DEFAULT 'active' CHECK (status IN ('active', 'inactive')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+INSERT INTO courses_new (id, code, name, description, credits, semester, department, course_type, max_students, lecturer_id, status, created_at, updated_at, deleted_at, version)
+SELECT id, code, name, description, credits, semester, department, course_type, max_students, lecturer_id, status, created_at, updated_at, deleted_at, version FROM courses;
+DROP TABLE courses;
+ALTER TABLE courses_new RENAME TO courses;
+CREATE INDEX idx_courses_semester ON courses(semester);
+CREATE INDEX idx_courses_department ON courses(department);
+CREATE INDEX idx_courses_lecturer_id ON courses(lecturer_id);
+
+CREATE TABLE enrollments_new (
+    id TEXT PRIMARY KEY,
+    student_id TEXT NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    course_id TEXT NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    academic_year VARCHAR(10) NOT NULL,
+    semester INTEGER NOT NULL CHECK (semester > 0),
+    enrollment_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    status VARCHAR(20) DEFAULT 'enrolled' CHECK (status IN ('enrolled', 'completed', 'dropped', 'failed')),
+    grade VARCHAR(2) CHECK (grade IN ('A', 'AB', 'B', 'BC', 'C', 'D', 'E')),
+    score DECIMAL(5,2),
+    attendance_percentage DECIMAL(5,2),
+    remarks TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    UNIQUE(student_id, course_id, academic_year, semester)
+);
+INSERT INTO enrollments_new (id, student_id, course_id, academic_year, semester, enrollment_date, status, grade, score, attendance_percentage, remarks, created_at, updated_at, deleted_at, version)
+SELECT id, student_id, course_id, academic_year, semester, enrollment_date, status, grade, score, attendance_percentage, remarks, created_at, updated_at, deleted_at, version FROM enrollments;
+DROP TABLE enrollments;
+ALTER TABLE enrollments_new RENAME TO enrollments;
+CREATE INDEX idx_enrollments_student_id ON enrollments(student_id);
+CREATE INDEX idx_enrollments_course_id ON enrollments(course_id);
+CREATE INDEX idx_enrollments_semester ON enrollments(semester);
+CREATE INDEX idx_enrollments_status ON enrollments(status);
+CREATE INDEX idx_enrollments_created_at_id ON enrollments(created_at DESC, id DESC);
@@ -0,0 +1,34 @@
+-- ============================================
+-- Migration 10: Audit Log
+-- File: database/migrations/sqlite/000010_create_audit_logs_table.up.sql
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge')),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id TEXT NOT NULL,
+    actor_id TEXT,
+    actor_role VARCHAR(20),
+    ip VARCHAR(45),
+    request_id VARCHAR(128),
+    changes TEXT NOT NULL DEFAULT '{}',
+    prev_hash VARCHAR(64),
+    hash VARCHAR(64),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity_type, entity_id, id);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id, id);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- The log is append-only.
+CREATE TRIGGER audit_logs_no_update BEFORE UPDATE ON audit_logs
+BEGIN
+    SELECT RAISE(ABORT, 'audit_logs is append-only');
+END;
+
+CREATE TRIGGER audit_logs_no_delete BEFORE DELETE ON audit_logs
+BEGIN
+    SELECT RAISE(ABORT, 'audit_logs is append-only');
+END;
@@ -0,0 +1,25 @@
+-- ============================================
+-- Migration 11: Transactional Outbox
+-- File: database/migrations/sqlite/000011_create_outbox_events_table.up.sql
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    aggregate_type VARCHAR(20) NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    ordering_key VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    request_id VARCHAR(128),
+    occurred_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP,
+    published_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_outbox_events_event_id ON outbox_events(event_id);
+-- The relay scans the unpublished events in ID order.
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events(published_at);
@@ -0,0 +1,27 @@
+-- ============================================
+-- Migration 13: Waitlist Entries Table
+-- File: database/migrations/sqlite/000013_create_waitlist_entries_table.up.sql
+-- ============================================
+
+-- Promoted and removed entries stay as the history of the waitlist.
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id TEXT PRIMARY KEY,
+    student_id TEXT NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    course_id TEXT NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    academic_year VARCHAR(10) NOT NULL,
+    semester INTEGER NOT NULL CHECK (semester > 0),
+    position INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'promoted', 'removed')),
+    enrollment_id TEXT,
+    promoted_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A student waits at most once for a course term.
+CREATE UNIQUE INDEX idx_waitlist_entries_student_id_course_id_academic_year_semester
+    ON waitlist_entries(student_id, course_id, academic_year, semester) WHERE status = 'waiting';
+-- Promotion walks the waiting entries of a course term in order.
+CREATE INDEX idx_waitlist_entries_waiting
+    ON waitlist_entries(course_id, academic_year, semester, position, created_at, id) WHERE status = 'waiting';
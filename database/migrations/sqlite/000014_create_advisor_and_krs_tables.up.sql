@@ -0,0 +1,50 @@
+-- ============================================
+-- Migration 14: Advisor Assignments and KRS Submissions
+-- File: database/migrations/sqlite/000014_create_advisor_and_krs_tables.up.sql
+-- ============================================
+
+-- Ended assignments stay as the advising history of a student.
+CREATE TABLE IF NOT EXISTS advisor_assignments (
+    id TEXT PRIMARY KEY,
+    student_id TEXT NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    lecturer_id TEXT NOT NULL REFERENCES lecturers(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A student has at most one current advisor.
+CREATE UNIQUE INDEX idx_advisor_assignments_student_id
+    ON advisor_assignments(student_id) WHERE ended_at IS NULL;
+CREATE INDEX idx_advisor_assignments_lecturer_id ON advisor_assignments(lecturer_id);
+
+CREATE TABLE IF NOT EXISTS krs_submissions (
+    id TEXT PRIMARY KEY,
+    student_id TEXT NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    academic_year VARCHAR(10) NOT NULL,
+    semester INTEGER NOT NULL CHECK (semester > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'submitted', 'approved', 'revision_requested')),
+    advisor_id TEXT REFERENCES lecturers(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMP,
+    decided_at TIMESTAMP,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One KRS per student and term; seat counts look it up by the same key.
+CREATE UNIQUE INDEX idx_krs_submissions_student_id_academic_year_semester
+    ON krs_submissions(student_id, academic_year, semester);
+CREATE INDEX idx_krs_submissions_advisor_id ON krs_submissions(advisor_id);
+
+CREATE TABLE IF NOT EXISTS krs_reviews (
+    id TEXT PRIMARY KEY,
+    submission_id TEXT NOT NULL REFERENCES krs_submissions(id) ON DELETE CASCADE,
+    decision VARCHAR(20) NOT NULL CHECK (decision IN ('approved', 'revision_requested')),
+    comment TEXT,
+    lecturer_id TEXT,
+    reviewed_by TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_krs_reviews_submission_id ON krs_reviews(submission_id);
@@ -0,0 +1,12 @@
+-- ============================================
+-- Migration 15: Outbox Dead Letters
+-- File: database/migrations/sqlite/000015_add_outbox_dead_letters.up.sql
+-- ============================================
+
+ALTER TABLE outbox_events ADD COLUMN dead_at TIMESTAMP;
+
+-- The relay scans the events neither published nor given up on, and
+-- looks for an older one on the same key before publishing each.
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX idx_outbox_events_pending_key ON outbox_events(ordering_key, id) WHERE published_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_waitlist_entries_enrollment_id;
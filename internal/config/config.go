@@ -2,90 +2,352 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"strconv"
+	"net"
 	"time"
-
-	"github.com/joho/godotenv"
 )
 
+// Config is assembled in layers, each overriding the previous one:
+// built-in defaults, an optional YAML file (--config or CONFIG_FILE),
+// environment variables (a .env file is loaded when present) and finally
+// command-line flags named after the YAML path, e.g. --server.write_timeout.
+//
+// Every leaf field carries a yaml and an env tag; fields tagged
+// secret:"true" are redacted by WriteRedacted.
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
+	App        AppConfig        `yaml:"app"`
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	Log        LogConfig        `yaml:"log"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Pagination PaginationConfig `yaml:"pagination"`
+	CORS       CORSConfig       `yaml:"cors"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Trash      TrashConfig      `yaml:"trash"`
+	Audit      AuditConfig      `yaml:"audit"`
+	Outbox     OutboxConfig     `yaml:"outbox"`
+	Webhooks   WebhooksConfig   `yaml:"webhooks"`
+	SeatFeed   SeatFeedConfig   `yaml:"seat_feed"`
+	Enrollment EnrollmentConfig `yaml:"enrollment"`
 }
 
 type AppConfig struct {
-	Name string
-	Env  string
-	Port string
+	Name string `yaml:"name" env:"APP_NAME"`
+	Env  string `yaml:"env" env:"APP_ENV"`
+	Port string `yaml:"port" env:"APP_PORT"`
+
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout" env:"READINESS_TIMEOUT"`
+}
+
+func (c *AppConfig) IsProduction() bool {
+	return c.Env == "production"
 }
 
+// ServerConfig holds the HTTP server timeouts and the shutdown sequence.
+// On SIGTERM the service reports not-ready for DrainDelay so load balancers
+// stop sending traffic, then waits up to ShutdownTimeout for in-flight
+// requests and background jobs. TLS is enabled when both files are set.
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	DrainDelay        time.Duration `yaml:"drain_delay" env:"SERVER_DRAIN_DELAY"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+
+	TLSCertFile string `yaml:"tls_cert_file" env:"TLS_CERT_FILE"`
+	TLSKeyFile  string `yaml:"tls_key_file" env:"TLS_KEY_FILE"`
+}
+
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// DatabaseConfig selects the storage backend with Driver: "postgres", or
+// "sqlite" for an embedded database file at SQLitePath. The connection
+// settings, replicas and statement timeouts below apply to Postgres only.
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
+	Driver     string `yaml:"driver" env:"DB_DRIVER"`
+	SQLitePath string `yaml:"sqlite_path" env:"DB_SQLITE_PATH"`
+
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+
+	// ReplicaHosts are read replicas as host or host:port; they share the
+	// primary's credentials, database name and pool settings.
+	ReplicaHosts []string `yaml:"replica_hosts" env:"DB_REPLICA_HOSTS"`
+
+	// Statement timeouts by query class: point lookups and writes, list
+	// pages, and streaming exports.
+	StatementTimeout       time.Duration `yaml:"statement_timeout" env:"DB_STATEMENT_TIMEOUT"`
+	ListStatementTimeout   time.Duration `yaml:"list_statement_timeout" env:"DB_LIST_STATEMENT_TIMEOUT"`
+	ReportStatementTimeout time.Duration `yaml:"report_statement_timeout" env:"DB_REPORT_STATEMENT_TIMEOUT"`
+
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" env:"DB_SLOW_QUERY_THRESHOLD"`
 }
 
 type JWTConfig struct {
-	Secret  string
-	Expired time.Duration
+	Secret  string        `yaml:"secret" env:"JWT_SECRET" secret:"true"`
+	Expired time.Duration `yaml:"expired" env:"JWT_EXPIRED"`
 }
 
-func LoadConfig() (*Config, error) {
-	// Load .env file
-	if err := godotenv.Load(); err != nil {
-		return nil, fmt.Errorf("error loading .env file: %w", err)
-	}
+type LogConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL"`
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
 
-	// Parse JWT expiration
-	jwtExpired, err := time.ParseDuration(getEnv("JWT_EXPIRED", "24h"))
-	if err != nil {
-		return nil, fmt.Errorf("invalid JWT_EXPIRED format: %w", err)
-	}
+// TracingConfig selects where OpenTelemetry spans go: "none", "otlp"
+// (OTLP over HTTP to Endpoint) or "stdout".
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+	Endpoint    string  `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	Insecure    bool    `yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+type PaginationConfig struct {
+	DefaultPageSize int `yaml:"default_page_size" env:"DEFAULT_PAGE_SIZE"`
+	MaxPageSize     int `yaml:"max_page_size" env:"MAX_PAGE_SIZE"`
+}
+
+// CORSConfig is disabled while AllowedOrigins is empty. "*" allows any
+// origin but cannot be combined with AllowCredentials.
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"`
+}
 
+// RateLimitConfig limits requests per client IP with a token bucket. The
+// auth endpoints get their own, stricter bucket.
+type RateLimitConfig struct {
+	Enabled           bool    `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	RequestsPerSecond float64 `yaml:"requests_per_second" env:"RATE_LIMIT_RPS"`
+	Burst             int     `yaml:"burst" env:"RATE_LIMIT_BURST"`
+	AuthPerSecond     float64 `yaml:"auth_requests_per_second" env:"RATE_LIMIT_AUTH_RPS"`
+	AuthBurst         int     `yaml:"auth_burst" env:"RATE_LIMIT_AUTH_BURST"`
+}
+
+// TrashConfig governs soft-deleted records. They can be purged for good
+// once Retention has passed since their deletion; every PurgeInterval the
+// service purges them itself, unless the interval is 0.
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention" env:"TRASH_RETENTION"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL"`
+}
+
+// AuditConfig governs the audit log. HashChain seals every entry with the
+// hash of the one before it, so tampering can be detected; it also makes
+// concurrent writes wait for each other.
+type AuditConfig struct {
+	HashChain bool `yaml:"hash_chain" env:"AUDIT_HASH_CHAIN"`
+}
+
+// OutboxConfig governs the relay publishing domain events to Sink: "log"
+// appends them to the file at LogPath ("-" for stdout), "webhook" POSTs
+// them to WebhookURL and "none" publishes them nowhere; with webhooks
+// enabled the relay still runs to queue their deliveries. An event the
+// sink rejects MaxAttempts times is given up on. Published events are
+// deleted after Retention, unless it is 0.
+type OutboxConfig struct {
+	Sink           string        `yaml:"sink" env:"OUTBOX_SINK"`
+	LogPath        string        `yaml:"log_path" env:"OUTBOX_LOG_PATH"`
+	WebhookURL     string        `yaml:"webhook_url" env:"OUTBOX_WEBHOOK_URL"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout" env:"OUTBOX_WEBHOOK_TIMEOUT"`
+	RelayInterval  time.Duration `yaml:"relay_interval" env:"OUTBOX_RELAY_INTERVAL"`
+	BatchSize      int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE"`
+	MaxAttempts    int           `yaml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS"`
+	Retention      time.Duration `yaml:"retention" env:"OUTBOX_RETENTION"`
+}
+
+// WebhooksConfig governs the webhook subscriptions. When Enabled, the
+// outbox relay also queues every event for the subscriptions wanting it,
+// and a dispatcher sends the queued deliveries every DispatchInterval, up
+// to BatchSize per query. A delivery is tried MaxAttempts times before it
+// is dead-lettered; finished deliveries are deleted after Retention,
+// unless it is 0. Subscription URLs may not point at loopback, private or
+// link-local addresses, unless their host is one of AllowedHosts.
+type WebhooksConfig struct {
+	Enabled          bool          `yaml:"enabled" env:"WEBHOOKS_ENABLED"`
+	DispatchInterval time.Duration `yaml:"dispatch_interval" env:"WEBHOOKS_DISPATCH_INTERVAL"`
+	BatchSize        int           `yaml:"batch_size" env:"WEBHOOKS_BATCH_SIZE"`
+	Timeout          time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT"`
+	MaxAttempts      int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS"`
+	Retention        time.Duration `yaml:"retention" env:"WEBHOOKS_RETENTION"`
+	AllowedHosts     []string      `yaml:"allowed_hosts" env:"WEBHOOKS_ALLOWED_HOSTS"`
+}
+
+// SeatFeedConfig governs the seat streams. Each client may fall Buffer
+// updates behind before it is disconnected; at most MaxSubscribers streams
+// are open per instance, MaxPerClient of them from one client IP unless it
+// is 0, and idle ones get a comment every Heartbeat.
+type SeatFeedConfig struct {
+	Buffer         int           `yaml:"buffer" env:"SEAT_FEED_BUFFER"`
+	MaxSubscribers int           `yaml:"max_subscribers" env:"SEAT_FEED_MAX_SUBSCRIBERS"`
+	MaxPerClient   int           `yaml:"max_per_client" env:"SEAT_FEED_MAX_PER_CLIENT"`
+	Heartbeat      time.Duration `yaml:"heartbeat" env:"SEAT_FEED_HEARTBEAT"`
+}
+
+// EnrollmentConfig governs who may enroll. A student takes at most
+// MaxCredits credits per term, unless it is 0; the limit also applies when
+// a student is promoted from a waitlist. Enrollments hold a seat of their
+// course once the student's KRS reaches CountSeatsFrom, "approved" or
+// "submitted".
+//
+// With "approved", drafts and submitted KRS hold no seat, so any number of
+// students can add a full course and submit it; the capacity is enforced
+// when the advisor approves, and the approvals past it fail with a
+// conflict naming the full course. The advisor then has to send those KRS
+// back for revision. "submitted" enforces it when the student submits
+// instead, at the cost of seats held by KRS that are later sent back.
+type EnrollmentConfig struct {
+	MaxCredits     int    `yaml:"max_credits" env:"ENROLLMENT_MAX_CREDITS"`
+	CountSeatsFrom string `yaml:"count_seats_from" env:"ENROLLMENT_COUNT_SEATS_FROM"`
+}
+
+// Default returns the configuration used when no layer overrides a value.
+// JWT.Secret is deliberately empty so it must always be provided.
+func Default() *Config {
 	return &Config{
 		App: AppConfig{
-			Name: getEnv("APP_NAME", "go-academic-service"),
-			Env:  getEnv("APP_ENV", "development"),
-			Port: getEnv("APP_PORT", "8080"),
+			Name:             "go-academic-service",
+			Env:              "development",
+			Port:             "8080",
+			ReadinessTimeout: 2 * time.Second,
+		},
+		Server: ServerConfig{
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			DrainDelay:        5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			Name:     getEnv("DB_NAME", "academic_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Driver:          "postgres",
+			SQLitePath:      "academic.db",
+			Host:            "localhost",
+			Port:            "5432",
+			User:            "postgres",
+			Password:        "postgres",
+			Name:            "academic_db",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+
+			StatementTimeout:       5 * time.Second,
+			ListStatementTimeout:   15 * time.Second,
+			ReportStatementTimeout: 10 * time.Minute,
+
+			SlowQueryThreshold: 200 * time.Millisecond,
 		},
 		JWT: JWTConfig{
-			Secret:  getEnv("JWT_SECRET", "secret"),
-			Expired: jwtExpired,
+			Expired: 24 * time.Hour,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
+		Pagination: PaginationConfig{
+			DefaultPageSize: 10,
+			MaxPageSize:     100,
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowedHeaders: []string{"Authorization", "Content-Type", "If-Match", "X-Request-ID", "traceparent"},
+			ExposedHeaders: []string{"ETag", "Location", "X-Request-ID"},
+			MaxAge:         12 * time.Hour,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:           true,
+			RequestsPerSecond: 20,
+			Burst:             40,
+			AuthPerSecond:     0.2,
+			AuthBurst:         5,
 		},
-	}, nil
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Audit: AuditConfig{
+			HashChain: true,
+		},
+		Outbox: OutboxConfig{
+			Sink:           "log",
+			LogPath:        "events.jsonl",
+			WebhookTimeout: 10 * time.Second,
+			RelayInterval:  time.Second,
+			BatchSize:      100,
+			MaxAttempts:    20,
+			Retention:      7 * 24 * time.Hour,
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:          true,
+			DispatchInterval: time.Second,
+			BatchSize:        50,
+			Timeout:          10 * time.Second,
+			MaxAttempts:      8,
+			Retention:        30 * 24 * time.Hour,
+		},
+		SeatFeed: SeatFeedConfig{
+			Buffer:         16,
+			MaxSubscribers: 10000,
+			MaxPerClient:   10,
+			Heartbeat:      15 * time.Second,
+		},
+		Enrollment: EnrollmentConfig{
+			MaxCredits:     24,
+			CountSeatsFrom: "approved",
+		},
+	}
+}
+
+func (c *DatabaseConfig) IsSQLite() bool {
+	return c.Driver == "sqlite"
 }
 
 func (c *DatabaseConfig) DSN() string {
-	return fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		c.Host, c.Port, c.User, c.Password, c.Name, c.SSLMode,
-	)
+	return c.dsn(c.Host, c.Port)
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// ReplicaDSN builds the DSN of a replica given as host or host:port.
+func (c *DatabaseConfig) ReplicaDSN(replica string) string {
+	host, port, err := net.SplitHostPort(replica)
+	if err != nil {
+		host, port = replica, c.Port
 	}
-	return defaultValue
+	return c.dsn(host, port)
 }
 
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := getEnv(key, "")
-	if value, err := strconv.Atoi(valueStr); err == nil {
-		return value
+// dsn also sets the server-side statement_timeout to the longest query
+// class, as a backstop for statements whose context carries no deadline.
+func (c *DatabaseConfig) dsn(host, port string) string {
+	dsn := fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		host, port, c.User, c.Password, c.Name, c.SSLMode,
+	)
+	if backstop := max(c.StatementTimeout, c.ListStatementTimeout, c.ReportStatementTimeout); backstop > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", backstop.Milliseconds())
 	}
-	return defaultValue
+	return dsn
 }
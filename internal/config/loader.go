@@ -0,0 +1,240 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[REDACTED]"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// field is one leaf of Config, addressed by its dotted YAML path.
+type field struct {
+	path  string
+	env   string
+	value reflect.Value
+}
+
+// Load builds the configuration from all layers and validates it. args
+// are the command-line arguments without the program name.
+func Load(args []string) (*Config, error) {
+	cfg, err := Read(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Read assembles the configuration layers without validating the result.
+func Read(args []string) (*Config, error) {
+	// A missing .env is normal in containers, where the environment is
+	// provided directly. Variables already set are never overwritten.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error loading .env file: %w", err)
+	}
+
+	cfg := Default()
+	fields := fieldsOf(reflect.ValueOf(cfg).Elem(), "")
+
+	type override struct{ field, value string }
+	var overrides []override
+
+	flags := flag.NewFlagSet("academic-service", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file (env CONFIG_FILE)")
+	for _, f := range fields {
+		usage := "overrides " + f.path
+		if f.env != "" {
+			usage += " (env " + f.env + ")"
+		}
+		flags.Func(f.path, usage, func(value string) error {
+			overrides = append(overrides, override{f.path, value})
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	if *configFile != "" {
+		if err := loadYAML(cfg, *configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+		if value := os.Getenv(f.env); value != "" {
+			if err := setString(f.value, value); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", f.env, err)
+			}
+		}
+	}
+
+	byPath := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byPath[f.path] = f
+	}
+	for _, o := range overrides {
+		if err := setString(byPath[o.field].value, o.value); err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", o.field, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+func loadYAML(cfg *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func fieldsOf(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		path := prefix + name
+		if sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, fieldsOf(v.Field(i), path+".")...)
+			continue
+		}
+		fields = append(fields, field{
+			path:  path,
+			env:   sf.Tag.Get("env"),
+			value: v.Field(i),
+		})
+	}
+	return fields
+}
+
+// setString parses s into v according to v's type. Lists are
+// comma-separated.
+func setString(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
+
+// WriteRedacted writes the effective configuration as YAML, in the same
+// layout a config file uses, with secrets replaced by a placeholder.
+func (c *Config) WriteRedacted(w io.Writer) error {
+	node, err := redactedNode(reflect.ValueOf(c).Elem())
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func redactedNode(v reflect.Value) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		var value *yaml.Node
+		fv := v.Field(i)
+		switch {
+		case sf.Type.Kind() == reflect.Struct:
+			var err error
+			if value, err = redactedNode(fv); err != nil {
+				return nil, err
+			}
+		default:
+			var out interface{} = fv.Interface()
+			if sf.Type == durationType {
+				out = fv.Interface().(time.Duration).String()
+			}
+			if sf.Tag.Get("secret") == "true" && !fv.IsZero() {
+				out = redacted
+			}
+			value = &yaml.Node{}
+			if err := value.Encode(out); err != nil {
+				return nil, err
+			}
+		}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+	}
+	return node, nil
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const minProductionSecretLength = 32
+
+// weakSecrets are defaults and placeholders that must never reach
+// production, compared case-insensitively.
+var weakSecrets = []string{
+	"secret",
+	"changeme",
+	"change-me",
+	"password",
+	"postgres",
+	"your-super-secret-jwt-key",
+	"your-super-secret-jwt-key-change-this",
+	"your-super-secret-jwt-key-change-this-in-production",
+}
+
+// Validate reports every invalid setting at once so a broken deploy can be
+// fixed in one pass.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{"development", "staging", "production"}, c.App.Env),
+		"app.env must be development, staging or production, got %q", c.App.Env)
+	check(c.App.Port != "", "app.port is required")
+	check(c.App.ReadinessTimeout > 0, "app.readiness_timeout must be positive")
+
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.DrainDelay >= 0, "server.drain_delay must not be negative")
+	check((c.Server.TLSCertFile == "") == (c.Server.TLSKeyFile == ""),
+		"server.tls_cert_file and server.tls_key_file must be set together")
+
+	check(slices.Contains([]string{"postgres", "sqlite"}, c.Database.Driver),
+		"database.driver must be postgres or sqlite, got %q", c.Database.Driver)
+	if c.Database.IsSQLite() {
+		check(c.Database.SQLitePath != "", "database.sqlite_path is required with the sqlite driver")
+		check(len(c.Database.ReplicaHosts) == 0, "database.replica_hosts is not supported with the sqlite driver")
+	}
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must not exceed database.max_open_conns")
+
+	check(c.Database.StatementTimeout >= 0 && c.Database.ListStatementTimeout >= 0 && c.Database.ReportStatementTimeout >= 0,
+		"database statement timeouts must not be negative")
+
+	check(c.JWT.Secret != "", "jwt.secret is required")
+	check(c.JWT.Secret != redacted && c.Database.Password != redacted,
+		"secrets still hold the %q placeholder from config print; provide them through the environment", redacted)
+	check(c.JWT.Expired > 0, "jwt.expired must be positive")
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, strings.ToLower(c.Log.Level)),
+		"log.level must be debug, info, warn or error, got %q", c.Log.Level)
+	check(slices.Contains([]string{"json", "text"}, strings.ToLower(c.Log.Format)),
+		"log.format must be json or text, got %q", c.Log.Format)
+
+	check(slices.Contains([]string{"none", "otlp", "stdout"}, strings.ToLower(c.Tracing.Exporter)),
+		"tracing.exporter must be none, otlp or stdout, got %q", c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(c.Pagination.DefaultPageSize >= 1, "pagination.default_page_size must be at least 1")
+	check(c.Pagination.MaxPageSize >= c.Pagination.DefaultPageSize,
+		"pagination.max_page_size must not be smaller than pagination.default_page_size")
+
+	check(!(c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowedOrigins, "*")),
+		"cors.allowed_origins cannot contain \"*\" when cors.allow_credentials is enabled")
+
+	if c.RateLimit.Enabled {
+		check(c.RateLimit.RequestsPerSecond > 0 && c.RateLimit.Burst >= 1,
+			"rate_limit.requests_per_second must be positive and rate_limit.burst at least 1")
+		check(c.RateLimit.AuthPerSecond > 0 && c.RateLimit.AuthBurst >= 1,
+			"rate_limit.auth_requests_per_second must be positive and rate_limit.auth_burst at least 1")
+	}
+
+	check(c.Trash.Retention >= 0 && c.Trash.PurgeInterval >= 0,
+		"trash.retention and trash.purge_interval must not be negative")
+
+	check(slices.Contains([]string{"none", "log", "webhook"}, c.Outbox.Sink),
+		"outbox.sink must be none, log or webhook, got %q", c.Outbox.Sink)
+	switch c.Outbox.Sink {
+	case "log":
+		check(c.Outbox.LogPath != "", "outbox.log_path is required with the log sink")
+	case "webhook":
+		check(c.Outbox.WebhookURL != "", "outbox.webhook_url is required with the webhook sink")
+		check(c.Outbox.WebhookTimeout > 0, "outbox.webhook_timeout must be positive")
+	}
+	// The relay runs for the sink, and for the webhook fanout even without
+	// one.
+	if c.Outbox.Sink != "none" || c.Webhooks.Enabled {
+		check(c.Outbox.RelayInterval > 0 && c.Outbox.BatchSize >= 1,
+			"outbox.relay_interval must be positive and outbox.batch_size at least 1")
+		check(c.Outbox.MaxAttempts >= 1, "outbox.max_attempts must be at least 1")
+	}
+	check(c.Outbox.Retention >= 0, "outbox.retention must not be negative")
+
+	if c.Webhooks.Enabled {
+		check(c.Webhooks.DispatchInterval > 0 && c.Webhooks.BatchSize >= 1,
+			"webhooks.dispatch_interval must be positive and webhooks.batch_size at least 1")
+		check(c.Webhooks.Timeout > 0, "webhooks.timeout must be positive")
+		check(c.Webhooks.MaxAttempts >= 1, "webhooks.max_attempts must be at least 1")
+	}
+	check(c.Webhooks.Retention >= 0, "webhooks.retention must not be negative")
+
+	check(c.SeatFeed.Buffer >= 1 && c.SeatFeed.MaxSubscribers >= 1,
+		"seat_feed.buffer and seat_feed.max_subscribers must be at least 1")
+	check(c.SeatFeed.Heartbeat > 0, "seat_feed.heartbeat must be positive")
+
+	check(c.Enrollment.MaxCredits >= 0, "enrollment.max_credits must not be negative")
+	check(slices.Contains([]string{"approved", "submitted"}, c.Enrollment.CountSeatsFrom),
+		"enrollment.count_seats_from must be approved or submitted, got %q", c.Enrollment.CountSeatsFrom)
+
+	if c.App.IsProduction() {
+		check(len(c.JWT.Secret) >= minProductionSecretLength && !isWeakSecret(c.JWT.Secret),
+			"jwt.secret is too weak for production: use at least %d random characters", minProductionSecretLength)
+		check(c.Database.IsSQLite() || !isWeakSecret(c.Database.Password), "database.password is a default or placeholder value")
+	}
+
+	return errors.Join(errs...)
+}
+
+func isWeakSecret(secret string) bool {
+	for _, weak := range weakSecrets {
+		if strings.EqualFold(secret, weak) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateOutboxRelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "no sink without webhooks needs no relay",
+			modify: func(c *Config) {
+				c.Outbox.Sink = "none"
+				c.Webhooks.Enabled = false
+				c.Outbox.RelayInterval, c.Outbox.MaxAttempts = 0, 0
+			},
+		},
+		{
+			name: "no sink with webhooks still relays",
+			modify: func(c *Config) {
+				c.Outbox.Sink = "none"
+				c.Outbox.RelayInterval = 0
+			},
+			wantErr: "outbox.relay_interval must be positive",
+		},
+		{
+			name: "max attempts",
+			modify: func(c *Config) {
+				c.Outbox.MaxAttempts = 0
+			},
+			wantErr: "outbox.max_attempts must be at least 1",
+		},
+		{
+			name: "webhook sink needs a URL",
+			modify: func(c *Config) {
+				c.Outbox.Sink = "webhook"
+			},
+			wantErr: "outbox.webhook_url is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			c.JWT.Secret = "test-secret"
+			tt.modify(c)
+			err := c.Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
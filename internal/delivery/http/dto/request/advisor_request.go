@@ -0,0 +1,8 @@
+// File: internal/delivery/http/dto/request/advisor_request.go
+package request
+
+import "github.com/google/uuid"
+
+type AssignAdvisorRequest struct {
+	LecturerID uuid.UUID `json:"lecturer_id" binding:"required"`
+}
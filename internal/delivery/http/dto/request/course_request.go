@@ -0,0 +1,60 @@
+// File: internal/delivery/http/dto/request/course_request.go
+package request
+
+import "github.com/google/uuid"
+
+type CreateCourseRequest struct {
+	Code        string     `json:"code" binding:"required,max=20"`
+	Name        string     `json:"name" binding:"required,max=200"`
+	Description string     `json:"description"`
+	Credits     int        `json:"credits" binding:"required,min=1"`
+	Semester    int        `json:"semester" binding:"required,min=1"`
+	Department  string     `json:"department" binding:"required,max=100"`
+	CourseType  string     `json:"course_type" binding:"omitempty,oneof=mandatory elective"`
+	MaxStudents int        `json:"max_students" binding:"omitempty,min=1"`
+	LecturerID  *uuid.UUID `json:"lecturer_id"`
+}
+
+type UpdateCourseRequest struct {
+	Name        *string    `json:"name" binding:"omitnil,min=1,max=200"`
+	Description *string    `json:"description"`
+	Credits     *int       `json:"credits" binding:"omitnil,min=1"`
+	Semester    *int       `json:"semester" binding:"omitnil,min=1"`
+	Department  *string    `json:"department" binding:"omitnil,min=1,max=100"`
+	CourseType  *string    `json:"course_type" binding:"omitnil,oneof=mandatory elective"`
+	MaxStudents *int       `json:"max_students" binding:"omitnil,min=1"`
+	LecturerID  *uuid.UUID `json:"lecturer_id"`
+	Status      *string    `json:"status" binding:"omitnil,oneof=active inactive"`
+}
+
+func (r *UpdateCourseRequest) Changes() map[string]interface{} {
+	changes := make(map[string]interface{})
+	if r.Name != nil {
+		changes["name"] = *r.Name
+	}
+	if r.Description != nil {
+		changes["description"] = *r.Description
+	}
+	if r.Credits != nil {
+		changes["credits"] = *r.Credits
+	}
+	if r.Semester != nil {
+		changes["semester"] = *r.Semester
+	}
+	if r.Department != nil {
+		changes["department"] = *r.Department
+	}
+	if r.CourseType != nil {
+		changes["course_type"] = *r.CourseType
+	}
+	if r.MaxStudents != nil {
+		changes["max_students"] = *r.MaxStudents
+	}
+	if r.LecturerID != nil {
+		changes["lecturer_id"] = *r.LecturerID
+	}
+	if r.Status != nil {
+		changes["status"] = *r.Status
+	}
+	return changes
+}
@@ -0,0 +1,41 @@
+// File: internal/delivery/http/dto/request/enrollment_request.go
+package request
+
+import "github.com/google/uuid"
+
+type CreateEnrollmentRequest struct {
+	StudentID    uuid.UUID `json:"student_id" binding:"required"`
+	CourseID     uuid.UUID `json:"course_id" binding:"required"`
+	AcademicYear string    `json:"academic_year" binding:"required,max=10"`
+	Semester     int       `json:"semester" binding:"required,min=1"`
+}
+
+// UpdateEnrollmentRequest is used by staff to record grades and change the
+// enrollment status; omitted fields keep their stored values.
+type UpdateEnrollmentRequest struct {
+	Status               *string  `json:"status" binding:"omitnil,oneof=enrolled completed dropped failed"`
+	Grade                *string  `json:"grade" binding:"omitnil,oneof=A AB B BC C D E"`
+	Score                *float64 `json:"score" binding:"omitnil,min=0,max=100"`
+	AttendancePercentage *float64 `json:"attendance_percentage" binding:"omitnil,min=0,max=100"`
+	Remarks              *string  `json:"remarks"`
+}
+
+func (r *UpdateEnrollmentRequest) Changes() map[string]interface{} {
+	changes := make(map[string]interface{})
+	if r.Status != nil {
+		changes["status"] = *r.Status
+	}
+	if r.Grade != nil {
+		changes["grade"] = *r.Grade
+	}
+	if r.Score != nil {
+		changes["score"] = *r.Score
+	}
+	if r.AttendancePercentage != nil {
+		changes["attendance_percentage"] = *r.AttendancePercentage
+	}
+	if r.Remarks != nil {
+		changes["remarks"] = *r.Remarks
+	}
+	return changes
+}
@@ -0,0 +1,9 @@
+// File: internal/delivery/http/dto/request/krs_request.go
+package request
+
+// ReviewKRSRequest is an advisor's decision on a submitted KRS. A comment
+// is required when a revision is requested.
+type ReviewKRSRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approved revision_requested"`
+	Comment  string `json:"comment" binding:"max=2000"`
+}
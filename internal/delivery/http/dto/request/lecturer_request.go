@@ -16,13 +16,53 @@ type CreateLecturerRequest struct {
 }
 
 type UpdateLecturerRequest struct {
-	Name           string     `json:"name"`
-	Email          string     `json:"email" binding:"omitempty,email"`
-	Phone          string     `json:"phone"`
-	Department     string     `json:"department"`
-	Position       string     `json:"position"`
-	Specialization string     `json:"specialization"`
+	Name           *string    `json:"name" binding:"omitnil,min=1,max=100"`
+	Email          *string    `json:"email" binding:"omitnil,email"`
+	Phone          *string    `json:"phone" binding:"omitnil,max=20"`
+	Address        *string    `json:"address"`
+	Department     *string    `json:"department" binding:"omitnil,min=1,max=100"`
+	Position       *string    `json:"position" binding:"omitnil,max=50"`
+	Specialization *string    `json:"specialization" binding:"omitnil,max=100"`
+	EducationLevel *string    `json:"education_level" binding:"omitnil,max=50"`
 	DateOfBirth    *time.Time `json:"date_of_birth"`
-	Gender         string     `json:"gender" binding:"omitempty,oneof=male female"`
-	Status         string     `json:"status" binding:"omitempty,oneof=active inactive retired"`
+	Gender         *string    `json:"gender" binding:"omitnil,oneof=male female"`
+	Status         *string    `json:"status" binding:"omitnil,oneof=active inactive retired"`
+}
+
+func (r *UpdateLecturerRequest) Changes() map[string]interface{} {
+	changes := make(map[string]interface{})
+	if r.Name != nil {
+		changes["name"] = *r.Name
+	}
+	if r.Email != nil {
+		changes["email"] = *r.Email
+	}
+	if r.Phone != nil {
+		changes["phone"] = *r.Phone
+	}
+	if r.Address != nil {
+		changes["address"] = *r.Address
+	}
+	if r.Department != nil {
+		changes["department"] = *r.Department
+	}
+	if r.Position != nil {
+		changes["position"] = *r.Position
+	}
+	if r.Specialization != nil {
+		changes["specialization"] = *r.Specialization
+	}
+	if r.EducationLevel != nil {
+		changes["education_level"] = *r.EducationLevel
+	}
+	if r.DateOfBirth != nil {
+		changes["date_of_birth"] = *r.DateOfBirth
+	}
+	if r.Gender != nil {
+		changes["gender"] = *r.Gender
+	}
+	if r.Status != nil {
+		changes["status"] = *r.Status
+	}
+	return changes
 }
@@ -0,0 +1,62 @@
+package request
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+)
+
+// changer is an update request that maps the fields sent to columns.
+type changer interface {
+	Changes() map[string]interface{}
+}
+
+func TestUpdateRequestChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		req  changer
+		body string
+		want map[string]interface{}
+	}{
+		{
+			name: "student fields left out are not changed",
+			req:  &UpdateStudentRequest{},
+			body: `{"phone":"0812","gpa":3.5}`,
+			want: map[string]interface{}{"phone": "0812", "gpa": 3.5},
+		},
+		{
+			name: "student fields sent empty are cleared",
+			req:  &UpdateStudentRequest{},
+			body: `{"address":""}`,
+			want: map[string]interface{}{"address": ""},
+		},
+		{
+			name: "student fields sent as null are not changed",
+			req:  &UpdateStudentRequest{},
+			body: `{"address":null}`,
+			want: map[string]interface{}{},
+		},
+		{
+			name: "lecturer fields left out are not changed",
+			req:  &UpdateLecturerRequest{},
+			body: `{"position":"Professor","education_level":"S3"}`,
+			want: map[string]interface{}{"position": "Professor", "education_level": "S3"},
+		},
+		{
+			name: "lecturer fields sent empty are cleared",
+			req:  &UpdateLecturerRequest{},
+			body: `{"specialization":""}`,
+			want: map[string]interface{}{"specialization": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.body), tt.req); err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.req.Changes(); !maps.Equal(got, tt.want) {
+				t.Errorf("Changes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
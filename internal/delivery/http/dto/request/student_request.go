@@ -18,15 +18,53 @@ type CreateStudentRequest struct {
 	Status         string     `json:"status" binding:"oneof=active inactive graduated dropped"`
 }
 
+// UpdateStudentRequest carries a partial update: nil fields are left
+// untouched so PATCH only changes what the client actually sent.
 type UpdateStudentRequest struct {
-	Name           string     `json:"name"`
-	Email          string     `json:"email" binding:"omitempty,email"`
-	Phone          string     `json:"phone"`
-	Address        string     `json:"address"`
+	Name           *string    `json:"name" binding:"omitnil,min=1,max=100"`
+	Email          *string    `json:"email" binding:"omitnil,email"`
+	Phone          *string    `json:"phone" binding:"omitnil,max=20"`
+	Address        *string    `json:"address"`
 	DateOfBirth    *time.Time `json:"date_of_birth"`
-	Gender         string     `json:"gender" binding:"omitempty,oneof=male female"`
-	Major          string     `json:"major"`
-	EnrollmentYear int        `json:"enrollment_year" binding:"omitempty,min=2000"`
-	Status         string     `json:"status" binding:"omitempty,oneof=active inactive graduated dropped"`
-	GPA            float64    `json:"gpa" binding:"omitempty,min=0,max=4"`
+	Gender         *string    `json:"gender" binding:"omitnil,oneof=male female"`
+	Major          *string    `json:"major" binding:"omitnil,min=1,max=100"`
+	EnrollmentYear *int       `json:"enrollment_year" binding:"omitnil,min=2000"`
+	Status         *string    `json:"status" binding:"omitnil,oneof=active inactive graduated dropped"`
+	GPA            *float64   `json:"gpa" binding:"omitnil,min=0,max=4"`
+}
+
+// Changes maps the provided fields to their column names.
+func (r *UpdateStudentRequest) Changes() map[string]interface{} {
+	changes := make(map[string]interface{})
+	if r.Name != nil {
+		changes["name"] = *r.Name
+	}
+	if r.Email != nil {
+		changes["email"] = *r.Email
+	}
+	if r.Phone != nil {
+		changes["phone"] = *r.Phone
+	}
+	if r.Address != nil {
+		changes["address"] = *r.Address
+	}
+	if r.DateOfBirth != nil {
+		changes["date_of_birth"] = *r.DateOfBirth
+	}
+	if r.Gender != nil {
+		changes["gender"] = *r.Gender
+	}
+	if r.Major != nil {
+		changes["major"] = *r.Major
+	}
+	if r.EnrollmentYear != nil {
+		changes["enrollment_year"] = *r.EnrollmentYear
+	}
+	if r.Status != nil {
+		changes["status"] = *r.Status
+	}
+	if r.GPA != nil {
+		changes["gpa"] = *r.GPA
+	}
+	return changes
 }
@@ -0,0 +1,16 @@
+// File: internal/delivery/http/dto/request/waitlist_request.go
+package request
+
+import "github.com/google/uuid"
+
+type JoinWaitlistRequest struct {
+	StudentID    uuid.UUID `json:"student_id" binding:"required"`
+	CourseID     uuid.UUID `json:"course_id" binding:"required"`
+	AcademicYear string    `json:"academic_year" binding:"required,max=10"`
+	Semester     int       `json:"semester" binding:"required,min=1"`
+}
+
+// MoveWaitlistEntryRequest puts an entry at Position, counted from 1.
+type MoveWaitlistEntryRequest struct {
+	Position int `json:"position" binding:"required,min=1"`
+}
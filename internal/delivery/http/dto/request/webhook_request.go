@@ -0,0 +1,35 @@
+// File: internal/delivery/http/dto/request/webhook_request.go
+package request
+
+import "github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=500"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1"`
+	Description string   `json:"description" binding:"max=255"`
+	Active      *bool    `json:"active"`
+}
+
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url" binding:"omitnil,url,max=500"`
+	EventTypes  []string `json:"event_types" binding:"omitnil,min=1"`
+	Description *string  `json:"description" binding:"omitnil,max=255"`
+	Active      *bool    `json:"active"`
+}
+
+func (r *UpdateWebhookRequest) Changes() map[string]interface{} {
+	changes := make(map[string]interface{})
+	if r.URL != nil {
+		changes["url"] = *r.URL
+	}
+	if r.EventTypes != nil {
+		changes["event_types"] = entity.EventTypeList(r.EventTypes)
+	}
+	if r.Description != nil {
+		changes["description"] = *r.Description
+	}
+	if r.Active != nil {
+		changes["active"] = *r.Active
+	}
+	return changes
+}
@@ -0,0 +1,27 @@
+// File: internal/delivery/http/dto/response/advisor_response.go
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type AdvisorAssignmentResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	StudentID  uuid.UUID  `json:"student_id"`
+	LecturerID uuid.UUID  `json:"lecturer_id"`
+	StartedAt  time.Time  `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+}
+
+func ToAdvisorAssignmentResponse(assignment *entity.AdvisorAssignment) AdvisorAssignmentResponse {
+	return AdvisorAssignmentResponse{
+		ID:         assignment.ID,
+		StudentID:  assignment.StudentID,
+		LecturerID: assignment.LecturerID,
+		StartedAt:  assignment.StartedAt,
+		EndedAt:    assignment.EndedAt,
+	}
+}
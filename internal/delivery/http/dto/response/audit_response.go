@@ -0,0 +1,67 @@
+// File: internal/delivery/http/dto/response/audit_response.go
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type AuditLogResponse struct {
+	ID         int64               `json:"id"`
+	Action     string              `json:"action"`
+	EntityType string              `json:"entity_type"`
+	EntityID   uuid.UUID           `json:"entity_id"`
+	ActorID    *uuid.UUID          `json:"actor_id"`
+	ActorRole  string              `json:"actor_role,omitempty"`
+	IP         string              `json:"ip,omitempty"`
+	RequestID  string              `json:"request_id,omitempty"`
+	Changes    entity.AuditChanges `json:"changes"`
+	PrevHash   string              `json:"prev_hash,omitempty"`
+	Hash       string              `json:"hash,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+type AuditLogListResponse struct {
+	Data       []AuditLogResponse `json:"data"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// AuditVerificationResponse reports a hash chain check. broken_at and
+// reason are only present when valid is false.
+type AuditVerificationResponse struct {
+	Valid    bool   `json:"valid"`
+	Entries  int64  `json:"entries"`
+	BrokenAt int64  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Head     string `json:"head,omitempty"`
+}
+
+func ToAuditLogResponse(entry *entity.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:         entry.ID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		IP:         entry.IP,
+		RequestID:  entry.RequestID,
+		Changes:    entry.Changes,
+		PrevHash:   entry.PrevHash,
+		Hash:       entry.Hash,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+func ToAuditVerificationResponse(result *usecase.AuditVerification) AuditVerificationResponse {
+	return AuditVerificationResponse{
+		Valid:    result.Valid,
+		Entries:  result.Entries,
+		BrokenAt: result.BrokenAt,
+		Reason:   result.Reason,
+		Head:     result.Head,
+	}
+}
@@ -7,7 +7,6 @@ type BaseResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
 }
 
 func SuccessResponse(message string, data interface{}) BaseResponse {
@@ -17,15 +16,3 @@ func SuccessResponse(message string, data interface{}) BaseResponse {
 		Data:    data,
 	}
 }
-
-func ErrorResponse(message string, err error) BaseResponse {
-	errMsg := ""
-	if err != nil {
-		errMsg = err.Error()
-	}
-	return BaseResponse{
-		Success: false,
-		Message: message,
-		Error:   errMsg,
-	}
-}
@@ -0,0 +1,45 @@
+// File: internal/delivery/http/dto/response/course_response.go
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type CourseResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Credits     int        `json:"credits"`
+	Semester    int        `json:"semester"`
+	Department  string     `json:"department"`
+	CourseType  string     `json:"course_type,omitempty"`
+	MaxStudents int        `json:"max_students"`
+	LecturerID  *uuid.UUID `json:"lecturer_id,omitempty"`
+	Status      string     `json:"status"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func ToCourseResponse(course *entity.Course) CourseResponse {
+	return CourseResponse{
+		ID:          course.ID,
+		Code:        course.Code,
+		Name:        course.Name,
+		Description: course.Description,
+		Credits:     course.Credits,
+		Semester:    course.Semester,
+		Department:  course.Department,
+		CourseType:  course.CourseType,
+		MaxStudents: course.MaxStudents,
+		LecturerID:  course.LecturerID,
+		Status:      course.Status,
+		Version:     course.Version,
+		CreatedAt:   course.CreatedAt,
+		UpdatedAt:   course.UpdatedAt,
+	}
+}
@@ -0,0 +1,45 @@
+// File: internal/delivery/http/dto/response/enrollment_response.go
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type EnrollmentResponse struct {
+	ID                   uuid.UUID `json:"id"`
+	StudentID            uuid.UUID `json:"student_id"`
+	CourseID             uuid.UUID `json:"course_id"`
+	AcademicYear         string    `json:"academic_year"`
+	Semester             int       `json:"semester"`
+	EnrollmentDate       time.Time `json:"enrollment_date"`
+	Status               string    `json:"status"`
+	Grade                string    `json:"grade,omitempty"`
+	Score                *float64  `json:"score,omitempty"`
+	AttendancePercentage *float64  `json:"attendance_percentage,omitempty"`
+	Remarks              string    `json:"remarks,omitempty"`
+	Version              int       `json:"version"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+func ToEnrollmentResponse(enrollment *entity.Enrollment) EnrollmentResponse {
+	return EnrollmentResponse{
+		ID:                   enrollment.ID,
+		StudentID:            enrollment.StudentID,
+		CourseID:             enrollment.CourseID,
+		AcademicYear:         enrollment.AcademicYear,
+		Semester:             enrollment.Semester,
+		EnrollmentDate:       enrollment.EnrollmentDate,
+		Status:               enrollment.Status,
+		Grade:                enrollment.Grade,
+		Score:                enrollment.Score,
+		AttendancePercentage: enrollment.AttendancePercentage,
+		Remarks:              enrollment.Remarks,
+		Version:              enrollment.Version,
+		CreatedAt:            enrollment.CreatedAt,
+		UpdatedAt:            enrollment.UpdatedAt,
+	}
+}
@@ -0,0 +1,38 @@
+// File: internal/delivery/http/dto/response/export_response.go
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type ExportJobResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Resource    string     `json:"resource"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Rows        int64      `json:"rows"`
+	Error       string     `json:"error,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func ToExportJobResponse(job *usecase.ExportJob) ExportJobResponse {
+	resp := ExportJobResponse{
+		ID:          job.ID,
+		Resource:    job.Resource,
+		Format:      string(job.Format),
+		Status:      job.Status,
+		Rows:        job.Rows,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+	if job.Status == usecase.ExportJobCompleted {
+		resp.DownloadURL = "/api/v1/exports/jobs/" + job.ID.String() + "/download"
+	}
+	return resp
+}
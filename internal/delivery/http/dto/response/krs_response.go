@@ -0,0 +1,67 @@
+// File: internal/delivery/http/dto/response/krs_response.go
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type KRSReviewResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Decision   string     `json:"decision"`
+	Comment    string     `json:"comment,omitempty"`
+	LecturerID *uuid.UUID `json:"lecturer_id,omitempty"`
+	ReviewedBy *uuid.UUID `json:"reviewed_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// KRSResponse is a KRS with the decisions on it, oldest first. Reviews is
+// left out of lists.
+type KRSResponse struct {
+	ID           uuid.UUID           `json:"id"`
+	StudentID    uuid.UUID           `json:"student_id"`
+	AcademicYear string              `json:"academic_year"`
+	Semester     int                 `json:"semester"`
+	Status       string              `json:"status"`
+	AdvisorID    *uuid.UUID          `json:"advisor_id,omitempty"`
+	SubmittedAt  *time.Time          `json:"submitted_at,omitempty"`
+	DecidedAt    *time.Time          `json:"decided_at,omitempty"`
+	Reviews      []KRSReviewResponse `json:"reviews,omitempty"`
+	Version      int                 `json:"version"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+type KRSListResponse struct {
+	Data       []KRSResponse  `json:"data"`
+	Pagination PaginationMeta `json:"pagination"`
+}
+
+func ToKRSResponse(krs *entity.KRSSubmission) KRSResponse {
+	resp := KRSResponse{
+		ID:           krs.ID,
+		StudentID:    krs.StudentID,
+		AcademicYear: krs.AcademicYear,
+		Semester:     krs.Semester,
+		Status:       krs.Status,
+		AdvisorID:    krs.AdvisorID,
+		SubmittedAt:  krs.SubmittedAt,
+		DecidedAt:    krs.DecidedAt,
+		Version:      krs.Version,
+		CreatedAt:    krs.CreatedAt,
+		UpdatedAt:    krs.UpdatedAt,
+	}
+	for _, review := range krs.Reviews {
+		resp.Reviews = append(resp.Reviews, KRSReviewResponse{
+			ID:         review.ID,
+			Decision:   review.Decision,
+			Comment:    review.Comment,
+			LecturerID: review.LecturerID,
+			ReviewedBy: review.ReviewedBy,
+			CreatedAt:  review.CreatedAt,
+		})
+	}
+	return resp
+}
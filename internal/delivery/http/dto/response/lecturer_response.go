@@ -18,6 +18,7 @@ type LecturerResponse struct {
 	Position       string    `json:"position,omitempty"`
 	Specialization string    `json:"specialization,omitempty"`
 	Status         string    `json:"status"`
+	Version        int       `json:"version"`
 	CreatedAt      time.Time `json:"created_at"`
 }
 
@@ -32,6 +33,7 @@ func ToLecturerResponse(lecturer *entity.Lecturer) LecturerResponse {
 		Position:       lecturer.Position,
 		Specialization: lecturer.Specialization,
 		Status:         lecturer.Status,
+		Version:        lecturer.Version,
 		CreatedAt:      lecturer.CreatedAt,
 	}
 }
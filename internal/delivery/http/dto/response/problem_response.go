@@ -0,0 +1,65 @@
+// File: internal/delivery/http/dto/response/problem_response.go
+package response
+
+import "net/http"
+
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes the problem type URIs. They identify the class
+// of problem and are not meant to be dereferenced.
+const problemTypeBase = "urn:academic-service:problem:"
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	TraceID  string       `json:"trace_id,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+	// Current carries the latest representation on 412 responses so the
+	// client can merge its change and retry.
+	Current interface{} `json:"current,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// NewProblem builds a problem whose type and title are derived from kind,
+// e.g. "not_found" becomes "urn:academic-service:problem:not-found".
+func NewProblem(status int, kind, detail string) Problem {
+	return Problem{
+		Type:   problemTypeBase + problemSlug(kind),
+		Title:  problemTitle(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func problemSlug(kind string) string {
+	slug := []byte(kind)
+	for i, b := range slug {
+		if b == '_' {
+			slug[i] = '-'
+		}
+	}
+	return string(slug)
+}
+
+func problemTitle(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "Validation failed"
+	case http.StatusConflict:
+		return "Resource conflict"
+	case http.StatusPreconditionFailed:
+		return "Resource was modified"
+	case http.StatusPreconditionRequired:
+		return "Missing precondition"
+	}
+	return http.StatusText(status)
+}
@@ -0,0 +1,40 @@
+// File: internal/delivery/http/dto/response/search_response.go
+package response
+
+import (
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type SearchResultResponse struct {
+	Type     string    `json:"type"`
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Subtitle string    `json:"subtitle"`
+	Detail   string    `json:"detail,omitempty"`
+	Rank     float64   `json:"rank"`
+	// Highlights holds title and subtitle as HTML, escaped, with the
+	// matching words in <mark>.
+	Highlights map[string]string `json:"highlights"`
+}
+
+type SearchResponse struct {
+	Query   string                 `json:"query"`
+	Results []SearchResultResponse `json:"results"`
+}
+
+func ToSearchResponse(q string, results []usecase.SearchResult) SearchResponse {
+	resp := SearchResponse{Query: q, Results: make([]SearchResultResponse, len(results))}
+	for i, result := range results {
+		resp.Results[i] = SearchResultResponse{
+			Type:       result.Type,
+			ID:         result.ID,
+			Title:      result.Title,
+			Subtitle:   result.Subtitle,
+			Detail:     result.Detail,
+			Rank:       result.Rank,
+			Highlights: result.Highlights,
+		}
+	}
+	return resp
+}
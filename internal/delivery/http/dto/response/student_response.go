@@ -23,13 +23,16 @@ type StudentResponse struct {
 	EnrollmentYear int        `json:"enrollment_year"`
 	Status         string     `json:"status"`
 	GPA            float64    `json:"gpa"`
+	Version        int        `json:"version"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
+// StudentListResponse carries []StudentResponse, or with fields= objects
+// holding only the requested fields.
 type StudentListResponse struct {
-	Data       []StudentResponse `json:"data"`
-	Pagination PaginationMeta    `json:"pagination"`
+	Data       interface{}    `json:"data"`
+	Pagination PaginationMeta `json:"pagination"`
 }
 
 type PaginationMeta struct {
@@ -39,6 +42,23 @@ type PaginationMeta struct {
 	TotalPage int   `json:"total_page"`
 }
 
+type StudentCursorListResponse struct {
+	Data       interface{} `json:"data"`
+	Pagination CursorMeta  `json:"pagination"`
+}
+
+// CursorMeta is the pagination block of a keyset page. The cursors and
+// links are absent at either end of the list; Total only appears when the
+// request asked for include_total.
+type CursorMeta struct {
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+}
+
 func ToStudentResponse(student *entity.Student) StudentResponse {
 	return StudentResponse{
 		ID:             student.ID,
@@ -53,6 +73,7 @@ func ToStudentResponse(student *entity.Student) StudentResponse {
 		EnrollmentYear: student.EnrollmentYear,
 		Status:         student.Status,
 		GPA:            student.GPA,
+		Version:        student.Version,
 		CreatedAt:      student.CreatedAt,
 		UpdatedAt:      student.UpdatedAt,
 	}
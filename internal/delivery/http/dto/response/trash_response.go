@@ -0,0 +1,17 @@
+// File: internal/delivery/http/dto/response/trash_response.go
+package response
+
+import "time"
+
+// DeletedRecordResponse carries a StudentResponse, LecturerResponse,
+// CourseResponse or EnrollmentResponse in Record.
+type DeletedRecordResponse struct {
+	Record     interface{} `json:"record"`
+	DeletedAt  time.Time   `json:"deleted_at"`
+	PurgeAfter time.Time   `json:"purge_after"`
+}
+
+type DeletedRecordListResponse struct {
+	Data       []DeletedRecordResponse `json:"data"`
+	Pagination PaginationMeta          `json:"pagination"`
+}
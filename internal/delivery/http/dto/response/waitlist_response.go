@@ -0,0 +1,49 @@
+// File: internal/delivery/http/dto/response/waitlist_response.go
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type WaitlistEntryResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	StudentID    uuid.UUID  `json:"student_id"`
+	CourseID     uuid.UUID  `json:"course_id"`
+	AcademicYear string     `json:"academic_year"`
+	Semester     int        `json:"semester"`
+	Position     int        `json:"position"`
+	Status       string     `json:"status"`
+	EnrollmentID *uuid.UUID `json:"enrollment_id,omitempty"`
+	PromotedAt   *time.Time `json:"promoted_at,omitempty"`
+	Version      int        `json:"version"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func ToWaitlistEntryResponse(entry *entity.WaitlistEntry) WaitlistEntryResponse {
+	return WaitlistEntryResponse{
+		ID:           entry.ID,
+		StudentID:    entry.StudentID,
+		CourseID:     entry.CourseID,
+		AcademicYear: entry.AcademicYear,
+		Semester:     entry.Semester,
+		Position:     entry.Position,
+		Status:       entry.Status,
+		EnrollmentID: entry.EnrollmentID,
+		PromotedAt:   entry.PromotedAt,
+		Version:      entry.Version,
+		CreatedAt:    entry.CreatedAt,
+		UpdatedAt:    entry.UpdatedAt,
+	}
+}
+
+// WaitlistResponse is the waitlist of a course term in promotion order.
+type WaitlistResponse struct {
+	CourseID     uuid.UUID               `json:"course_id"`
+	AcademicYear string                  `json:"academic_year"`
+	Semester     int                     `json:"semester"`
+	Entries      []WaitlistEntryResponse `json:"entries"`
+}
@@ -0,0 +1,126 @@
+// File: internal/delivery/http/handler/advisor_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type AdvisorHandler struct {
+	useCase usecase.AdvisorUseCase
+}
+
+func NewAdvisorHandler(useCase usecase.AdvisorUseCase) *AdvisorHandler {
+	return &AdvisorHandler{useCase: useCase}
+}
+
+// Assign godoc
+// @Summary Assign the academic advisor of a student
+// @Description Makes a lecturer the student's advisor (dosen wali). The previous assignment ends and stays in the history; KRS waiting for review move to the new advisor.
+// @Tags advisors
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID"
+// @Param request body request.AssignAdvisorRequest true "Advisor"
+// @Success 200 {object} response.BaseResponse
+// @Router /students/{id}/advisor [put]
+func (h *AdvisorHandler) Assign(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	var req request.AssignAdvisorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	assignment, err := h.useCase.Assign(c.Request.Context(), studentID, req.LecturerID)
+	if err != nil {
+		respondError(c, "Failed to assign advisor", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Advisor assigned successfully", response.ToAdvisorAssignmentResponse(assignment)))
+}
+
+// Get godoc
+// @Summary Get the current academic advisor of a student
+// @Tags advisors
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /students/{id}/advisor [get]
+func (h *AdvisorHandler) Get(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	assignment, err := h.useCase.Current(c.Request.Context(), studentID)
+	if err != nil {
+		respondError(c, "Advisor not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Advisor retrieved successfully", response.ToAdvisorAssignmentResponse(assignment)))
+}
+
+// History godoc
+// @Summary Get the advising history of a student
+// @Description All advisor assignments of the student, newest first.
+// @Tags advisors
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /students/{id}/advisor/history [get]
+func (h *AdvisorHandler) History(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	assignments, err := h.useCase.History(c.Request.Context(), studentID)
+	if err != nil {
+		respondError(c, "Failed to get advising history", err)
+		return
+	}
+
+	data := make([]response.AdvisorAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		data[i] = response.ToAdvisorAssignmentResponse(assignment)
+	}
+	c.JSON(http.StatusOK, response.SuccessResponse("Advising history retrieved successfully", data))
+}
+
+// Unassign godoc
+// @Summary End the advisor assignment of a student
+// @Description Fails with 409 while the student has a KRS waiting for review.
+// @Tags advisors
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /students/{id}/advisor [delete]
+func (h *AdvisorHandler) Unassign(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	if err := h.useCase.Unassign(c.Request.Context(), studentID); err != nil {
+		respondError(c, "Failed to unassign advisor", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Advisor unassigned successfully", nil))
+}
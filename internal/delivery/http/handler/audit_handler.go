@@ -0,0 +1,119 @@
+// File: internal/delivery/http/handler/audit_handler.go
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type AuditHandler struct {
+	useCase usecase.AuditUseCase
+	limits  pagination.Limits
+}
+
+func NewAuditHandler(useCase usecase.AuditUseCase, limits pagination.Limits) *AuditHandler {
+	return &AuditHandler{useCase: useCase, limits: limits}
+}
+
+// List godoc
+// @Summary List audit log entries
+// @Description Changes to students, lecturers, courses, enrollments, users and webhook subscriptions, newest first, with who made them and the changed fields.
+// @Tags audit
+// @Produce json
+// @Param entity_type query string false "student, lecturer, course, enrollment, user or webhook"
+// @Param entity_id query string false "ID of the changed record"
+// @Param actor_id query string false "ID of the user who made the change"
+// @Param from query string false "RFC 3339 time, inclusive"
+// @Param to query string false "RFC 3339 time, exclusive"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/audit [get]
+func (h *AuditHandler) List(c *gin.Context) {
+	filter := repository.AuditFilter{EntityType: c.Query("entity_type")}
+	var err error
+	if filter.EntityID, err = optionalUUID(c.Query("entity_id")); err != nil {
+		invalidRequest(c, "Invalid entity_id", err)
+		return
+	}
+	if filter.ActorID, err = optionalUUID(c.Query("actor_id")); err != nil {
+		invalidRequest(c, "Invalid actor_id", err)
+		return
+	}
+	if filter.From, err = optionalTime(c.Query("from")); err != nil {
+		invalidRequest(c, "Invalid from", err)
+		return
+	}
+	if filter.To, err = optionalTime(c.Query("to")); err != nil {
+		invalidRequest(c, "Invalid to", err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	entries, total, err := h.useCase.List(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		respondError(c, "Failed to get audit log", err)
+		return
+	}
+
+	data := make([]response.AuditLogResponse, len(entries))
+	for i, entry := range entries {
+		data[i] = response.ToAuditLogResponse(entry)
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Audit log retrieved successfully", response.AuditLogListResponse{
+		Data: data,
+		Pagination: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: pagination.TotalPages(total, pageSize),
+		},
+	}))
+}
+
+// Verify godoc
+// @Summary Verify the audit log hash chain
+// @Description Walks the whole log and reports the first entry that was altered, removed or inserted. Fails with 400 when hash chaining is disabled.
+// @Tags audit
+// @Produce json
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/audit/verify [get]
+func (h *AuditHandler) Verify(c *gin.Context) {
+	result, err := h.useCase.Verify(c.Request.Context())
+	if err != nil {
+		respondError(c, "Failed to verify audit log", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Audit log verified", response.ToAuditVerificationResponse(result)))
+}
+
+func optionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func optionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -32,7 +32,7 @@ func NewAuthHandler(authUseCase usecase.AuthUseCase) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req request.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request", err))
+		invalidRequest(c, "Invalid request", err)
 		return
 	}
 
@@ -43,7 +43,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	if err := h.authUseCase.Register(c.Request.Context(), user, req.Password); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Failed to register", err))
+		respondError(c, "Failed to register", err)
 		return
 	}
 
@@ -61,13 +61,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req request.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request", err))
+		invalidRequest(c, "Invalid request", err)
 		return
 	}
 
 	token, user, err := h.authUseCase.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse("Login failed", err))
+		respondError(c, "Login failed", err)
 		return
 	}
 
@@ -0,0 +1,234 @@
+// File: internal/delivery/http/handler/course_handler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type CourseHandler struct {
+	useCase usecase.CourseUseCase
+	limits  pagination.Limits
+}
+
+func NewCourseHandler(useCase usecase.CourseUseCase, limits pagination.Limits) *CourseHandler {
+	return &CourseHandler{useCase: useCase, limits: limits}
+}
+
+// Create godoc
+// @Summary Create new course
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param course body request.CreateCourseRequest true "Course data"
+// @Success 201 {object} response.BaseResponse
+// @Router /courses [post]
+func (h *CourseHandler) Create(c *gin.Context) {
+	var req request.CreateCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	course := &entity.Course{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Credits:     req.Credits,
+		Semester:    req.Semester,
+		Department:  req.Department,
+		CourseType:  req.CourseType,
+		MaxStudents: req.MaxStudents,
+		LecturerID:  req.LecturerID,
+		Status:      "active",
+	}
+
+	if course.CourseType == "" {
+		course.CourseType = "mandatory"
+	}
+	if course.MaxStudents == 0 {
+		course.MaxStudents = 40
+	}
+
+	if err := h.useCase.Create(c.Request.Context(), course); err != nil {
+		respondError(c, "Failed to create course", err)
+		return
+	}
+
+	setETag(c, course.Version)
+	c.JSON(http.StatusCreated, response.SuccessResponse("Course created successfully", response.ToCourseResponse(course)))
+}
+
+// GetByID godoc
+// @Summary Get course by ID
+// @Tags courses
+// @Produce json
+// @Param id path string true "Course ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /courses/{id} [get]
+func (h *CourseHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid course ID", err)
+		return
+	}
+
+	course, err := h.useCase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Course not found", err)
+		return
+	}
+
+	setETag(c, course.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Course retrieved successfully", response.ToCourseResponse(course)))
+}
+
+// GetAll godoc
+// @Summary Get all courses
+// @Tags courses
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size (capped at MAX_PAGE_SIZE)" default(10)
+// @Param department query string false "Filter by department"
+// @Param semester query int false "Filter by semester"
+// @Param lecturer_id query string false "Filter by lecturer"
+// @Param status query string false "Filter by status"
+// @Param search query string false "Search by name or code"
+// @Param filter query string false "filter[field]=value or filter[field][op]=value, op one of eq, ne, gt, gte, lt, lte, like, in; repeatable"
+// @Param sort query string false "Comma-separated fields, - for descending, e.g. semester,code"
+// @Param fields query string false "Comma-separated fields to return"
+// @Success 200 {object} response.BaseResponse
+// @Router /courses [get]
+func (h *CourseHandler) GetAll(c *gin.Context) {
+	spec, ok := listQuery(c, query.Courses, courseParams)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	courses, total, err := h.useCase.GetAll(c.Request.Context(), page, pageSize, spec)
+	if err != nil {
+		respondError(c, "Failed to get courses", err)
+		return
+	}
+
+	var courseResponses []response.CourseResponse
+	for _, course := range courses {
+		courseResponses = append(courseResponses, response.ToCourseResponse(course))
+	}
+
+	data, err := selectFields(courseResponses, spec.Fields)
+	if err != nil {
+		respondError(c, "Failed to get courses", err)
+		return
+	}
+
+	result := map[string]interface{}{
+		"data": data,
+		"pagination": response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: pagination.TotalPages(total, pageSize),
+		},
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Courses retrieved successfully", result))
+}
+
+// Update godoc
+// @Summary Update course
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Param course body request.UpdateCourseRequest true "Course data"
+// @Success 200 {object} response.BaseResponse
+// @Router /courses/{id} [patch]
+func (h *CourseHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid course ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	course, err := h.useCase.Update(c.Request.Context(), id, version, req.Changes())
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to update course", err)
+		return
+	}
+
+	setETag(c, course.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Course updated successfully", response.ToCourseResponse(course)))
+}
+
+// Delete godoc
+// @Summary Delete course
+// @Tags courses
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param If-Match header string true "ETag of the version being deleted"
+// @Success 200 {object} response.BaseResponse
+// @Router /courses/{id} [delete]
+func (h *CourseHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid course ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to delete course", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Course deleted successfully", nil))
+}
+
+func (h *CourseHandler) preconditionFailed(c *gin.Context, id uuid.UUID, err error) {
+	current, getErr := h.useCase.GetByID(c.Request.Context(), id)
+	if getErr != nil {
+		respondError(c, "Course not found", getErr)
+		return
+	}
+	preconditionFailed(c, err, current.Version, response.ToCourseResponse(current))
+}
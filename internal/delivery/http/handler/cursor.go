@@ -0,0 +1,105 @@
+// File: internal/delivery/http/handler/cursor.go
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+)
+
+// cursor is what an opaque cursor token carries: the keyset of the row at
+// the edge of the page it came from and which way to read from there.
+// Clients must treat tokens as opaque; the encoding may change. Tokens are
+// not signed: a crafted one only moves where the page starts.
+type cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"i"`
+	Before    bool      `json:"b,omitempty"`
+}
+
+var errMalformedCursor = errors.New("cursor is malformed")
+
+func encodeCursor(key repository.Keyset, before bool) string {
+	data, _ := json.Marshal(cursor{CreatedAt: key.CreatedAt, ID: key.ID, Before: before})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// keysetPage reads the cursor and page_size parameters of a list request
+// in keyset mode. An empty cursor starts at the newest row. It reports a
+// 400 and returns false when the token cannot be decoded.
+func keysetPage(c *gin.Context, limits pagination.Limits) (repository.KeysetPage, bool) {
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	_, pageSize = limits.Normalize(1, pageSize)
+	page := repository.KeysetPage{Limit: pageSize}
+
+	token := c.Query("cursor")
+	if token == "" {
+		return page, true
+	}
+
+	var cur cursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &cur)
+	}
+	if err != nil || cur.CreatedAt.IsZero() || cur.ID == uuid.Nil {
+		// The decoding error would be reported as a malformed body.
+		invalidRequest(c, "Invalid cursor", errMalformedCursor)
+		return page, false
+	}
+
+	key := &repository.Keyset{CreatedAt: cur.CreatedAt, ID: cur.ID}
+	if cur.Before {
+		page.Before = key
+	} else {
+		page.After = key
+	}
+	return page, true
+}
+
+// countIfRequested runs count only when the client asked for a total with
+// include_total, since counting a large table costs more than the page.
+func countIfRequested(c *gin.Context, count func() (int64, error)) (*int64, error) {
+	if include, _ := strconv.ParseBool(c.Query("include_total")); !include {
+		return nil, nil
+	}
+	total, err := count()
+	if err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
+
+// cursorMeta describes a keyset page: cursors for its neighbours and links
+// to them. The links repeat every other query parameter of the request,
+// so filters carry over, and drop page in case both were given.
+func cursorMeta[T any](c *gin.Context, window repository.Window[T], key func(*T) repository.Keyset, pageSize int, total *int64) response.CursorMeta {
+	meta := response.CursorMeta{PageSize: pageSize, Total: total}
+	if len(window.Items) == 0 {
+		return meta
+	}
+	if window.HasNext {
+		meta.NextCursor = encodeCursor(key(window.Items[len(window.Items)-1]), false)
+		meta.Next = cursorLink(c, meta.NextCursor)
+	}
+	if window.HasPrev {
+		meta.PrevCursor = encodeCursor(key(window.Items[0]), true)
+		meta.Prev = cursorLink(c, meta.PrevCursor)
+	}
+	return meta
+}
+
+func cursorLink(c *gin.Context, token string) string {
+	query := c.Request.URL.Query()
+	query.Del("page")
+	query.Set("cursor", token)
+	return c.Request.URL.Path + "?" + query.Encode()
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+)
+
+var testLimits = pagination.Limits{DefaultPageSize: 10, MaxPageSize: 100}
+
+// readKeysetPage runs keysetPage on a request for rawQuery.
+func readKeysetPage(rawQuery string) (repository.KeysetPage, bool, *gin.Context) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/students?"+rawQuery, nil)
+	page, ok := keysetPage(c, testLimits)
+	return page, ok, c
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	key := repository.Keyset{CreatedAt: time.Date(2024, 9, 2, 8, 30, 0, 123456789, time.UTC), ID: uuid.New()}
+	tests := []struct {
+		name       string
+		before     bool
+		pageSize   string
+		wantLimit  int
+		wantBefore bool
+	}{
+		{name: "next page", pageSize: "20", wantLimit: 20},
+		{name: "previous page", before: true, pageSize: "20", wantLimit: 20, wantBefore: true},
+		{name: "page size capped", pageSize: "1000", wantLimit: 100},
+		{name: "default page size", wantLimit: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{"cursor": {encodeCursor(key, tt.before)}, "page_size": {tt.pageSize}}
+			page, ok, _ := readKeysetPage(query.Encode())
+			if !ok {
+				t.Fatal("keysetPage rejected a cursor it issued")
+			}
+			if page.Limit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", page.Limit, tt.wantLimit)
+			}
+			got, other := page.After, page.Before
+			if tt.wantBefore {
+				got, other = page.Before, page.After
+			}
+			if got == nil || other != nil {
+				t.Fatalf("after %v, before %v, want only the %v side set", page.After, page.Before, tt.wantBefore)
+			}
+			if !got.CreatedAt.Equal(key.CreatedAt) || got.ID != key.ID {
+				t.Errorf("keyset = %+v, want %+v", *got, key)
+			}
+		})
+	}
+}
+
+func TestCursorRejectsMalformed(t *testing.T) {
+	valid := encodeCursor(repository.Keyset{CreatedAt: time.Now().UTC(), ID: uuid.New()}, false)
+	flipped := []byte(valid)
+	flipped[3] ^= 0x20
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "truncated", token: valid[:len(valid)/2]},
+		{name: "corrupted byte", token: string(flipped)},
+		{name: "not base64", token: "not a cursor!"},
+		{name: "padded base64", token: base64.URLEncoding.EncodeToString([]byte(`{"t":"2024-09-02T08:30:00Z","i":"` + uuid.NewString() + `"}`))},
+		{name: "not JSON", token: base64.RawURLEncoding.EncodeToString([]byte("page=3"))},
+		{name: "missing id", token: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2024-09-02T08:30:00Z"}`))},
+		{name: "missing time", token: base64.RawURLEncoding.EncodeToString([]byte(`{"i":"` + uuid.NewString() + `"}`))},
+		{name: "wrong types", token: base64.RawURLEncoding.EncodeToString([]byte(`{"t":1725265800,"i":42}`))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, ok, c := readKeysetPage(url.Values{"cursor": {tt.token}}.Encode())
+			if ok {
+				t.Fatalf("keysetPage accepted %q as %+v", tt.token, page)
+			}
+			if len(c.Errors) != 1 || apperror.KindOf(c.Errors.Last().Err) != apperror.KindValidation {
+				t.Errorf("errors = %v, want one validation error", c.Errors)
+			}
+		})
+	}
+}
+
+func TestKeysetFirstPage(t *testing.T) {
+	page, ok, _ := readKeysetPage("cursor=&page_size=5")
+	if !ok || page.After != nil || page.Before != nil || page.Limit != 5 {
+		t.Errorf("keysetPage() = %+v, %v, want the first 5 rows", page, ok)
+	}
+}
+
+func TestCursorMetaLinks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/students?cursor=abc&page=2&major=Computer+Science&page_size=2", nil)
+
+	first := &repository.Keyset{CreatedAt: time.Now().UTC(), ID: uuid.New()}
+	last := &repository.Keyset{CreatedAt: first.CreatedAt.Add(-time.Hour), ID: uuid.New()}
+	window := repository.Window[repository.Keyset]{Items: []*repository.Keyset{first, last}, HasPrev: true, HasNext: true}
+	meta := cursorMeta(c, window, func(k *repository.Keyset) repository.Keyset { return *k }, 2, nil)
+
+	for name, link := range map[string]string{"next": meta.Next, "prev": meta.Prev} {
+		u, err := url.Parse(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		query := u.Query()
+		if u.Path != "/api/v1/students" || query.Get("major") != "Computer Science" || query.Get("page_size") != "2" || query.Has("page") {
+			t.Errorf("%s link %q does not keep the filters without page", name, link)
+		}
+	}
+
+	next, ok, _ := readKeysetPage(url.Values{"cursor": {meta.NextCursor}}.Encode())
+	if !ok || next.After == nil || next.After.ID != last.ID {
+		t.Errorf("next cursor reads as %+v, want after the last row", next)
+	}
+	prev, ok, _ := readKeysetPage(url.Values{"cursor": {meta.PrevCursor}}.Encode())
+	if !ok || prev.Before == nil || prev.Before.ID != first.ID {
+		t.Errorf("prev cursor reads as %+v, want before the first row", prev)
+	}
+}
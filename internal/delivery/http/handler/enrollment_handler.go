@@ -0,0 +1,271 @@
+// File: internal/delivery/http/handler/enrollment_handler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type EnrollmentHandler struct {
+	useCase usecase.EnrollmentUseCase
+	limits  pagination.Limits
+}
+
+func NewEnrollmentHandler(useCase usecase.EnrollmentUseCase, limits pagination.Limits) *EnrollmentHandler {
+	return &EnrollmentHandler{useCase: useCase, limits: limits}
+}
+
+// Create godoc
+// @Summary Enroll a student in a course
+// @Tags enrollments
+// @Accept json
+// @Produce json
+// @Param enrollment body request.CreateEnrollmentRequest true "Enrollment data"
+// @Success 201 {object} response.BaseResponse
+// @Router /enrollments [post]
+func (h *EnrollmentHandler) Create(c *gin.Context) {
+	var req request.CreateEnrollmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	enrollment := &entity.Enrollment{
+		StudentID:    req.StudentID,
+		CourseID:     req.CourseID,
+		AcademicYear: req.AcademicYear,
+		Semester:     req.Semester,
+	}
+
+	if err := h.useCase.Enroll(c.Request.Context(), enrollment); err != nil {
+		respondError(c, "Failed to create enrollment", err)
+		return
+	}
+
+	setETag(c, enrollment.Version)
+	c.JSON(http.StatusCreated, response.SuccessResponse("Enrollment created successfully", response.ToEnrollmentResponse(enrollment)))
+}
+
+// GetByID godoc
+// @Summary Get enrollment by ID
+// @Tags enrollments
+// @Produce json
+// @Param id path string true "Enrollment ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /enrollments/{id} [get]
+func (h *EnrollmentHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid enrollment ID", err)
+		return
+	}
+
+	enrollment, err := h.useCase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Enrollment not found", err)
+		return
+	}
+
+	setETag(c, enrollment.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Enrollment retrieved successfully", response.ToEnrollmentResponse(enrollment)))
+}
+
+// GetAll godoc
+// @Summary Get all enrollments
+// @Tags enrollments
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size (capped at MAX_PAGE_SIZE)" default(10)
+// @Param cursor query string false "Keyset cursor; switches to cursor pagination"
+// @Param include_total query bool false "Count all matches in cursor mode"
+// @Param student_id query string false "Filter by student"
+// @Param course_id query string false "Filter by course"
+// @Param academic_year query string false "Filter by academic year"
+// @Param semester query int false "Filter by semester"
+// @Param status query string false "Filter by status"
+// @Param filter query string false "filter[field]=value or filter[field][op]=value, op one of eq, ne, gt, gte, lt, lte, like, in; repeatable"
+// @Param sort query string false "Comma-separated fields, - for descending, e.g. -score,created_at"
+// @Param fields query string false "Comma-separated fields to return"
+// @Success 200 {object} response.BaseResponse
+// @Router /enrollments [get]
+func (h *EnrollmentHandler) GetAll(c *gin.Context) {
+	spec, ok := listQuery(c, query.Enrollments, enrollmentParams)
+	if !ok {
+		return
+	}
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.getPage(c, spec)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	enrollments, total, err := h.useCase.GetAll(c.Request.Context(), page, pageSize, spec)
+	if err != nil {
+		respondError(c, "Failed to get enrollments", err)
+		return
+	}
+
+	var enrollmentResponses []response.EnrollmentResponse
+	for _, enrollment := range enrollments {
+		enrollmentResponses = append(enrollmentResponses, response.ToEnrollmentResponse(enrollment))
+	}
+
+	data, err := selectFields(enrollmentResponses, spec.Fields)
+	if err != nil {
+		respondError(c, "Failed to get enrollments", err)
+		return
+	}
+
+	result := map[string]interface{}{
+		"data": data,
+		"pagination": response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: pagination.TotalPages(total, pageSize),
+		},
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Enrollments retrieved successfully", result))
+}
+
+func (h *EnrollmentHandler) getPage(c *gin.Context, spec query.Spec) {
+	if len(spec.Sort) > 0 {
+		respondError(c, "Invalid list query", errSortWithCursor)
+		return
+	}
+	page, ok := keysetPage(c, h.limits)
+	if !ok {
+		return
+	}
+
+	window, err := h.useCase.GetPage(c.Request.Context(), page, spec)
+	if err != nil {
+		respondError(c, "Failed to get enrollments", err)
+		return
+	}
+	total, err := countIfRequested(c, func() (int64, error) {
+		return h.useCase.Count(c.Request.Context(), spec)
+	})
+	if err != nil {
+		respondError(c, "Failed to get enrollments", err)
+		return
+	}
+
+	var enrollmentResponses []response.EnrollmentResponse
+	for _, enrollment := range window.Items {
+		enrollmentResponses = append(enrollmentResponses, response.ToEnrollmentResponse(enrollment))
+	}
+
+	data, err := selectFields(enrollmentResponses, spec.Fields)
+	if err != nil {
+		respondError(c, "Failed to get enrollments", err)
+		return
+	}
+
+	result := map[string]interface{}{
+		"data": data,
+		"pagination": cursorMeta(c, window, func(e *entity.Enrollment) repository.Keyset {
+			return repository.Keyset{CreatedAt: e.CreatedAt, ID: e.ID}
+		}, page.Limit, total),
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Enrollments retrieved successfully", result))
+}
+
+// Update godoc
+// @Summary Update enrollment status or grade
+// @Tags enrollments
+// @Accept json
+// @Produce json
+// @Param id path string true "Enrollment ID"
+// @Param If-Match header string true "ETag of the version being updated"
+// @Param enrollment body request.UpdateEnrollmentRequest true "Enrollment data"
+// @Success 200 {object} response.BaseResponse
+// @Router /enrollments/{id} [patch]
+func (h *EnrollmentHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid enrollment ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateEnrollmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	enrollment, err := h.useCase.Update(c.Request.Context(), id, version, req.Changes())
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to update enrollment", err)
+		return
+	}
+
+	setETag(c, enrollment.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Enrollment updated successfully", response.ToEnrollmentResponse(enrollment)))
+}
+
+// Delete godoc
+// @Summary Delete enrollment
+// @Tags enrollments
+// @Produce json
+// @Param id path string true "Enrollment ID"
+// @Param If-Match header string true "ETag of the version being deleted"
+// @Success 200 {object} response.BaseResponse
+// @Router /enrollments/{id} [delete]
+func (h *EnrollmentHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid enrollment ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to delete enrollment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Enrollment deleted successfully", nil))
+}
+
+func (h *EnrollmentHandler) preconditionFailed(c *gin.Context, id uuid.UUID, err error) {
+	current, getErr := h.useCase.GetByID(c.Request.Context(), id)
+	if getErr != nil {
+		respondError(c, "Enrollment not found", getErr)
+		return
+	}
+	preconditionFailed(c, err, current.Version, response.ToEnrollmentResponse(current))
+}
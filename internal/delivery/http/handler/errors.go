@@ -0,0 +1,19 @@
+// File: internal/delivery/http/handler/errors.go
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+)
+
+// respondError hands err to middleware.ErrorHandler, which picks the status
+// code from the error kind. message is logged with server errors; the
+// problem detail comes from err.
+func respondError(c *gin.Context, message string, err error) {
+	_ = c.Error(err).SetMeta(message)
+}
+
+// invalidRequest reports malformed input (bad JSON, IDs, query values).
+func invalidRequest(c *gin.Context, message string, err error) {
+	respondError(c, message, apperror.Wrap(apperror.KindValidation, message, err))
+}
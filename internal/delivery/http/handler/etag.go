@@ -0,0 +1,50 @@
+// File: internal/delivery/http/handler/etag.go
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/middleware"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+)
+
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion extracts the version from the If-Match header, reporting a
+// 428 when it is missing and a 400 when it cannot be parsed. "*" yields 0,
+// which the use cases treat as "whatever is current".
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		respondError(c, "If-Match header is required", apperror.New(apperror.KindPreconditionRequired, "If-Match header is required"))
+		return 0, false
+	}
+	if header == "*" {
+		return 0, true
+	}
+
+	tag := strings.TrimSpace(strings.Split(header, ",")[0])
+	tag = strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		invalidRequest(c, "Invalid If-Match header", err)
+		return 0, false
+	}
+	return version, true
+}
+
+// preconditionFailed answers a version conflict with 412, the current ETag
+// and the current representation.
+func preconditionFailed(c *gin.Context, err error, version int, current interface{}) {
+	problem := response.NewProblem(http.StatusPreconditionFailed, apperror.KindOf(err).String(), "Resource was modified by another request")
+	problem.Current = current
+
+	setETag(c, version)
+	middleware.WriteProblem(c, problem)
+}
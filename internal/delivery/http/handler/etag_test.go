@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+)
+
+func TestStudentIfMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		ifMatch     string
+		wantStatus  int
+		wantETag    string
+		wantCurrent bool
+	}{
+		{name: "current version", method: http.MethodPatch, body: `{"name":"Ani S"}`, ifMatch: `"1"`, wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "weak tag", method: http.MethodPatch, body: `{"name":"Ani S"}`, ifMatch: `W/"1"`, wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "any version", method: http.MethodPatch, body: `{"name":"Ani S"}`, ifMatch: "*", wantStatus: http.StatusOK, wantETag: `"2"`},
+		{name: "stale version", method: http.MethodPatch, body: `{"name":"Ani S"}`, ifMatch: `"7"`, wantStatus: http.StatusPreconditionFailed, wantETag: `"1"`, wantCurrent: true},
+		{name: "missing header", method: http.MethodPatch, body: `{"name":"Ani S"}`, wantStatus: http.StatusPreconditionRequired},
+		{name: "malformed header", method: http.MethodPatch, body: `{"name":"Ani S"}`, ifMatch: `"one"`, wantStatus: http.StatusBadRequest},
+		{name: "delete of a stale version", method: http.MethodDelete, ifMatch: `"2"`, wantStatus: http.StatusPreconditionFailed, wantETag: `"1"`, wantCurrent: true},
+		{name: "delete without header", method: http.MethodDelete, wantStatus: http.StatusPreconditionRequired},
+		{name: "delete of the current version", method: http.MethodDelete, ifMatch: `"1"`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newStudentRouter()
+			created := createStudent(t, r)
+			path := "/students/" + created.Data.ID
+
+			var header []string
+			if tt.ifMatch != "" {
+				header = []string{"If-Match", tt.ifMatch}
+			}
+			w := serve(r, tt.method, path, tt.body, header...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("%s = %d %s, want %d", tt.method, w.Code, w.Body, tt.wantStatus)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if w.Code >= http.StatusBadRequest {
+				if got := w.Header().Get("Content-Type"); got != response.ProblemContentType {
+					t.Errorf("Content-Type = %q, want %q", got, response.ProblemContentType)
+				}
+			}
+			if tt.wantCurrent {
+				var problem struct {
+					Current struct {
+						Name    string `json:"name"`
+						Version int    `json:"version"`
+					} `json:"current"`
+				}
+				decode(t, w, &problem)
+				if problem.Current.Name != "Ani" || problem.Current.Version != 1 {
+					t.Errorf("current = %+v, want the stored student at version 1", problem.Current)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSetsETag(t *testing.T) {
+	r := newStudentRouter()
+	created := createStudent(t, r)
+
+	w := serve(r, http.MethodGet, "/students/"+created.Data.ID, "")
+	if got := w.Header().Get("ETag"); w.Code != http.StatusOK || got != `"1"` {
+		t.Errorf("GET = %d with ETag %q, want 200 with \"1\"", w.Code, got)
+	}
+}
@@ -0,0 +1,190 @@
+// File: internal/delivery/http/handler/export_handler.go
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/export"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type ExportHandler struct {
+	useCase usecase.ExportUseCase
+}
+
+func NewExportHandler(useCase usecase.ExportUseCase) *ExportHandler {
+	return &ExportHandler{useCase: useCase}
+}
+
+// Export godoc
+// @Summary Export students, lecturers or enrollments
+// @Description Streams the export directly unless async=true, in which case a background job is scheduled.
+// @Tags exports
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param resource path string true "students, lecturers or enrollments"
+// @Param format query string false "csv, xlsx or jsonl" default(csv)
+// @Param async query bool false "Run as a background job"
+// @Success 200 {file} file
+// @Success 202 {object} response.BaseResponse
+// @Router /exports/{resource} [get]
+func (h *ExportHandler) Export(c *gin.Context) {
+	resource := c.Param("resource")
+	if !usecase.IsExportResource(resource) {
+		respondError(c, "Unknown export resource", apperror.NotFound("unknown export resource"))
+		return
+	}
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		invalidRequest(c, "Invalid export format", err)
+		return
+	}
+
+	spec, ok := exportSpec(c, resource)
+	if !ok {
+		return
+	}
+
+	if async, _ := strconv.ParseBool(c.Query("async")); async {
+		userID, _ := c.Get("user_id")
+		requestedBy, _ := userID.(uuid.UUID)
+
+		job, err := h.useCase.StartJob(c.Request.Context(), resource, format, spec, requestedBy)
+		if err != nil {
+			respondError(c, "Failed to start export", err)
+			return
+		}
+
+		c.Header("Location", "/api/v1/exports/jobs/"+job.ID.String())
+		c.JSON(http.StatusAccepted, response.SuccessResponse("Export scheduled", response.ToExportJobResponse(job)))
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, resource, format.Extension()))
+	c.Status(http.StatusOK)
+	clearWriteDeadline(c)
+
+	// Headers are already on the wire once rows start flowing, so a failure
+	// mid-stream can only be logged and the connection cut short.
+	if _, err := h.useCase.Export(c.Request.Context(), resource, format, spec, c.Writer); err != nil {
+		ctx := c.Request.Context()
+		logger.FromContext(ctx).ErrorContext(ctx, "export failed", "resource", resource, "error", err)
+		c.Abort()
+	}
+}
+
+// GetJob godoc
+// @Summary Get export job status
+// @Tags exports
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /exports/jobs/{id} [get]
+func (h *ExportHandler) GetJob(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid job ID", err)
+		return
+	}
+
+	job, err := h.useCase.GetJob(id)
+	if err != nil {
+		respondError(c, "Export job not found", err)
+		return
+	}
+	if !canAccessJob(c, job) {
+		respondError(c, "Export job not found", apperror.NotFound("export job not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Export job retrieved successfully", response.ToExportJobResponse(job)))
+}
+
+// Download godoc
+// @Summary Download a completed export
+// @Tags exports
+// @Param id path string true "Job ID"
+// @Success 200 {file} file
+// @Router /exports/jobs/{id}/download [get]
+func (h *ExportHandler) Download(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid job ID", err)
+		return
+	}
+
+	job, file, err := h.useCase.OpenJobFile(id)
+	if job == nil {
+		respondError(c, "Export job not found", err)
+		return
+	}
+	if !canAccessJob(c, job) {
+		if file != nil {
+			file.Close()
+		}
+		respondError(c, "Export job not found", apperror.NotFound("export job not found"))
+		return
+	}
+	if err != nil {
+		respondError(c, "Export is not ready", err)
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Type", job.Format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, job.FileName()))
+	c.Status(http.StatusOK)
+	clearWriteDeadline(c)
+	if _, err := io.Copy(c.Writer, file); err != nil {
+		ctx := c.Request.Context()
+		logger.FromContext(ctx).ErrorContext(ctx, "export download failed", "job_id", id, "error", err)
+	}
+}
+
+// clearWriteDeadline lifts the server's write timeout for this response
+// only; large exports legitimately take longer than any API call.
+func clearWriteDeadline(c *gin.Context) {
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		ctx := c.Request.Context()
+		logger.FromContext(ctx).WarnContext(ctx, "failed to clear write deadline", "error", err)
+	}
+}
+
+// canAccessJob limits job visibility to the user who requested it, with
+// admins allowed to see everything.
+func canAccessJob(c *gin.Context, job *usecase.ExportJob) bool {
+	if role, _ := c.Get("user_role"); role == "admin" {
+		return true
+	}
+	userID, _ := c.Get("user_id")
+	return userID == job.RequestedBy
+}
+
+// exportSpec reads the same filters, search and sort as the list endpoint
+// of resource. An export always has the columns of its format, so fields
+// is ignored.
+func exportSpec(c *gin.Context, resource string) (query.Spec, bool) {
+	var spec query.Spec
+	var ok bool
+	switch resource {
+	case usecase.ExportResourceStudents:
+		spec, ok = listQuery(c, query.Students, studentParams)
+	case usecase.ExportResourceLecturers:
+		spec, ok = listQuery(c, query.Lecturers, lecturerParams)
+	default:
+		spec, ok = listQuery(c, query.Enrollments, enrollmentParams)
+	}
+	spec.Fields = nil
+	return spec, ok
+}
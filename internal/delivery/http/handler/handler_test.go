@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/middleware"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/memory"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+// newStudentRouter serves the student endpoints over memory repositories,
+// behind the error middleware as in main.
+func newStudentRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	students, audit, outbox := memory.NewStudentRepository(), memory.NewAuditRepository(), memory.NewOutboxRepository()
+	useCase := usecase.NewStudentUseCase(students, memory.NewTxManager(), usecase.NewAuditor(audit, false), usecase.NewOutbox(outbox))
+	h := NewStudentHandler(useCase, pagination.Limits{DefaultPageSize: 10, MaxPageSize: 100})
+
+	r := gin.New()
+	r.Use(middleware.ErrorHandler(false))
+	r.POST("/students", h.Create)
+	r.GET("/students/:id", h.GetByID)
+	r.PATCH("/students/:id", h.Update)
+	r.DELETE("/students/:id", h.Delete)
+	return r
+}
+
+// serve sends a request with body and header, given as name-value pairs,
+// to r.
+func serve(r http.Handler, method, path, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// decode unmarshals the body of w into v.
+func decode(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+}
+
+// studentBody is the success envelope of a single student.
+type studentBody struct {
+	Data struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Email   string `json:"email"`
+		Phone   string `json:"phone"`
+		Address string `json:"address"`
+		Major   string `json:"major"`
+		Version int    `json:"version"`
+	} `json:"data"`
+}
+
+const newStudentJSON = `{"nim":"2024001","name":"Ani","email":"ani@example.com","phone":"0811","address":"Jl. Merdeka 1","gender":"female","major":"Informatics","enrollment_year":2024,"status":"active"}`
+
+// createStudent posts newStudentJSON and returns the student created.
+func createStudent(t *testing.T, r http.Handler) studentBody {
+	t.Helper()
+	w := serve(r, http.MethodPost, "/students", newStudentJSON)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("POST /students = %d %s", w.Code, w.Body)
+	}
+	var created studentBody
+	decode(t, w, &created)
+	return created
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/health"
+)
+
+func TestHealthProbes(t *testing.T) {
+	tests := []struct {
+		name       string
+		database   error
+		draining   bool
+		path       string
+		wantStatus int
+		wantReport health.Status
+	}{
+		{name: "ready", path: "/readyz", wantStatus: http.StatusOK, wantReport: health.StatusUp},
+		{name: "database down", database: errors.New("connection refused"), path: "/readyz", wantStatus: http.StatusServiceUnavailable, wantReport: health.StatusDown},
+		{name: "draining", draining: true, path: "/readyz", wantStatus: http.StatusServiceUnavailable, wantReport: health.StatusDraining},
+		{name: "live while the database is down", database: errors.New("connection refused"), path: "/livez", wantStatus: http.StatusOK, wantReport: health.StatusUp},
+		{name: "live while draining", draining: true, path: "/livez", wantStatus: http.StatusOK, wantReport: health.StatusUp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker(time.Second)
+			checker.Register("database", func(context.Context) error { return tt.database })
+			if tt.draining {
+				checker.SetDraining()
+			}
+			h := NewHealthHandler(checker)
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/livez", h.Livez)
+			r.GET("/readyz", h.Readyz)
+
+			w := serve(r, http.MethodGet, tt.path, "")
+			if w.Code != tt.wantStatus {
+				t.Errorf("GET %s = %d, want %d", tt.path, w.Code, tt.wantStatus)
+			}
+			var report health.Report
+			decode(t, w, &report)
+			if report.Status != tt.wantReport {
+				t.Errorf("report = %+v, want %s", report, tt.wantReport)
+			}
+		})
+	}
+}
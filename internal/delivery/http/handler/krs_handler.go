@@ -0,0 +1,211 @@
+// File: internal/delivery/http/handler/krs_handler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type KRSHandler struct {
+	useCase usecase.KRSUseCase
+	limits  pagination.Limits
+}
+
+func NewKRSHandler(useCase usecase.KRSUseCase, limits pagination.Limits) *KRSHandler {
+	return &KRSHandler{useCase: useCase, limits: limits}
+}
+
+// ListByStudent godoc
+// @Summary List the KRS of a student
+// @Description One KRS per term, latest term first, without their reviews.
+// @Tags krs
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /students/{id}/krs [get]
+func (h *KRSHandler) ListByStudent(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	submissions, err := h.useCase.ListByStudent(c.Request.Context(), studentID)
+	if err != nil {
+		respondError(c, "Failed to get KRS", err)
+		return
+	}
+
+	data := make([]response.KRSResponse, len(submissions))
+	for i, krs := range submissions {
+		data[i] = response.ToKRSResponse(krs)
+	}
+	c.JSON(http.StatusOK, response.SuccessResponse("KRS retrieved successfully", data))
+}
+
+// GetByID godoc
+// @Summary Get a KRS
+// @Description The KRS with the advisor's decisions and comments, oldest first. Its courses are the enrollments of the student in that term.
+// @Tags krs
+// @Produce json
+// @Param id path string true "KRS ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /krs/{id} [get]
+func (h *KRSHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	krs, err := h.useCase.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "KRS not found", err)
+		return
+	}
+
+	setETag(c, krs.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("KRS retrieved successfully", response.ToKRSResponse(krs)))
+}
+
+// Inbox godoc
+// @Summary List the KRS waiting for an advisor's decision
+// @Description Submitted KRS, the longest waiting first. Without lecturer_id, the inbox of the lecturer linked to the caller's account; only admins may name another advisor.
+// @Tags krs
+// @Produce json
+// @Param lecturer_id query string false "Advisor's lecturer ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} response.BaseResponse
+// @Router /krs/inbox [get]
+func (h *KRSHandler) Inbox(c *gin.Context) {
+	var lecturerID uuid.UUID
+	if raw := c.Query("lecturer_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			invalidRequest(c, "Invalid lecturer_id", err)
+			return
+		}
+		lecturerID = id
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	submissions, total, err := h.useCase.Inbox(c.Request.Context(), lecturerID, page, pageSize)
+	if err != nil {
+		respondError(c, "Failed to get KRS inbox", err)
+		return
+	}
+
+	data := make([]response.KRSResponse, len(submissions))
+	for i, krs := range submissions {
+		data[i] = response.ToKRSResponse(krs)
+	}
+	c.JSON(http.StatusOK, response.SuccessResponse("KRS inbox retrieved successfully", response.KRSListResponse{
+		Data: data,
+		Pagination: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: pagination.TotalPages(total, pageSize),
+		},
+	}))
+}
+
+// Submit godoc
+// @Summary Submit a KRS to the student's advisor
+// @Description Submits a draft, or a KRS sent back for revision, to the student's current advisor. Requires If-Match.
+// @Tags krs
+// @Produce json
+// @Param id path string true "KRS ID"
+// @Param If-Match header string true "ETag of the KRS"
+// @Success 200 {object} response.BaseResponse
+// @Router /krs/{id}/submit [post]
+func (h *KRSHandler) Submit(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	krs, err := h.useCase.Submit(c.Request.Context(), id, version)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to submit KRS", err)
+		return
+	}
+
+	setETag(c, krs.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("KRS submitted successfully", response.ToKRSResponse(krs)))
+}
+
+// Review godoc
+// @Summary Approve a KRS or request its revision
+// @Description The advisor's decision on a submitted KRS, with a comment; the comment is required for a revision request. Only the advisor the KRS was submitted to, or an admin, may decide. Requires If-Match.
+// @Tags krs
+// @Accept json
+// @Produce json
+// @Param id path string true "KRS ID"
+// @Param If-Match header string true "ETag of the KRS"
+// @Param request body request.ReviewKRSRequest true "Decision"
+// @Success 200 {object} response.BaseResponse
+// @Router /krs/{id}/reviews [post]
+func (h *KRSHandler) Review(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req request.ReviewKRSRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	krs, err := h.useCase.Review(c.Request.Context(), id, version, req.Decision, req.Comment)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to review KRS", err)
+		return
+	}
+
+	setETag(c, krs.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("KRS reviewed successfully", response.ToKRSResponse(krs)))
+}
+
+func (h *KRSHandler) preconditionFailed(c *gin.Context, id uuid.UUID, err error) {
+	current, getErr := h.useCase.Get(c.Request.Context(), id)
+	if getErr != nil {
+		respondError(c, "KRS not found", getErr)
+		return
+	}
+	preconditionFailed(c, err, current.Version, response.ToKRSResponse(current))
+}
@@ -2,6 +2,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,21 +11,25 @@ import (
 	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
 	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
 	"github.com/haninhammoud01/go-academic-service/internal/usecase"
 )
 
 type LecturerHandler struct {
 	useCase usecase.LecturerUseCase
+	limits  pagination.Limits
 }
 
-func NewLecturerHandler(useCase usecase.LecturerUseCase) *LecturerHandler {
-	return &LecturerHandler{useCase: useCase}
+func NewLecturerHandler(useCase usecase.LecturerUseCase, limits pagination.Limits) *LecturerHandler {
+	return &LecturerHandler{useCase: useCase, limits: limits}
 }
 
 func (h *LecturerHandler) Create(c *gin.Context) {
 	var req request.CreateLecturerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request", err))
+		invalidRequest(c, "Invalid request", err)
 		return
 	}
 
@@ -42,47 +47,48 @@ func (h *LecturerHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.useCase.Create(c.Request.Context(), lecturer); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Failed to create lecturer", err))
+		respondError(c, "Failed to create lecturer", err)
 		return
 	}
 
+	setETag(c, lecturer.Version)
 	c.JSON(http.StatusCreated, response.SuccessResponse("Lecturer created successfully", response.ToLecturerResponse(lecturer)))
 }
 
 func (h *LecturerHandler) GetByID(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid ID", err))
+		invalidRequest(c, "Invalid ID", err)
 		return
 	}
 
 	lecturer, err := h.useCase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, response.ErrorResponse("Lecturer not found", err))
+		respondError(c, "Lecturer not found", err)
 		return
 	}
 
+	setETag(c, lecturer.Version)
 	c.JSON(http.StatusOK, response.SuccessResponse("Lecturer retrieved successfully", response.ToLecturerResponse(lecturer)))
 }
 
 func (h *LecturerHandler) GetAll(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-
-	filters := make(map[string]interface{})
-	if department := c.Query("department"); department != "" {
-		filters["department"] = department
-	}
-	if status := c.Query("status"); status != "" {
-		filters["status"] = status
+	spec, ok := listQuery(c, query.Lecturers, lecturerParams)
+	if !ok {
+		return
 	}
-	if search := c.Query("search"); search != "" {
-		filters["search"] = search
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.getPage(c, spec)
+		return
 	}
 
-	lecturers, total, err := h.useCase.GetAll(c.Request.Context(), page, pageSize, filters)
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	lecturers, total, err := h.useCase.GetAll(c.Request.Context(), page, pageSize, spec)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse("Failed to get lecturers", err))
+		respondError(c, "Failed to get lecturers", err)
 		return
 	}
 
@@ -91,68 +97,130 @@ func (h *LecturerHandler) GetAll(c *gin.Context) {
 		lecturerResponses = append(lecturerResponses, response.ToLecturerResponse(lecturer))
 	}
 
-	totalPage := int(total) / pageSize
-	if int(total)%pageSize > 0 {
-		totalPage++
+	data, err := selectFields(lecturerResponses, spec.Fields)
+	if err != nil {
+		respondError(c, "Failed to get lecturers", err)
+		return
 	}
 
 	result := map[string]interface{}{
-		"data": lecturerResponses,
+		"data": data,
 		"pagination": response.PaginationMeta{
 			Page:      page,
 			PageSize:  pageSize,
 			Total:     total,
-			TotalPage: totalPage,
+			TotalPage: pagination.TotalPages(total, pageSize),
 		},
 	}
 
 	c.JSON(http.StatusOK, response.SuccessResponse("Lecturers retrieved successfully", result))
 }
 
+func (h *LecturerHandler) getPage(c *gin.Context, spec query.Spec) {
+	if len(spec.Sort) > 0 {
+		respondError(c, "Invalid list query", errSortWithCursor)
+		return
+	}
+	page, ok := keysetPage(c, h.limits)
+	if !ok {
+		return
+	}
+
+	window, err := h.useCase.GetPage(c.Request.Context(), page, spec)
+	if err != nil {
+		respondError(c, "Failed to get lecturers", err)
+		return
+	}
+	total, err := countIfRequested(c, func() (int64, error) {
+		return h.useCase.Count(c.Request.Context(), spec)
+	})
+	if err != nil {
+		respondError(c, "Failed to get lecturers", err)
+		return
+	}
+
+	var lecturerResponses []response.LecturerResponse
+	for _, lecturer := range window.Items {
+		lecturerResponses = append(lecturerResponses, response.ToLecturerResponse(lecturer))
+	}
+
+	data, err := selectFields(lecturerResponses, spec.Fields)
+	if err != nil {
+		respondError(c, "Failed to get lecturers", err)
+		return
+	}
+
+	result := map[string]interface{}{
+		"data": data,
+		"pagination": cursorMeta(c, window, func(l *entity.Lecturer) repository.Keyset {
+			return repository.Keyset{CreatedAt: l.CreatedAt, ID: l.ID}
+		}, page.Limit, total),
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Lecturers retrieved successfully", result))
+}
+
 func (h *LecturerHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid ID", err))
+		invalidRequest(c, "Invalid ID", err)
 		return
 	}
 
-	var req request.UpdateLecturerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request", err))
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	lecturer := &entity.Lecturer{
-		Name:           req.Name,
-		Email:          req.Email,
-		Phone:          req.Phone,
-		Department:     req.Department,
-		Position:       req.Position,
-		Specialization: req.Specialization,
-		DateOfBirth:    req.DateOfBirth,
-		Gender:         req.Gender,
-		Status:         req.Status,
+	var req request.UpdateLecturerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
 	}
 
-	if err := h.useCase.Update(c.Request.Context(), id, lecturer); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Failed to update lecturer", err))
+	lecturer, err := h.useCase.Update(c.Request.Context(), id, version, req.Changes())
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to update lecturer", err)
 		return
 	}
 
+	setETag(c, lecturer.Version)
 	c.JSON(http.StatusOK, response.SuccessResponse("Lecturer updated successfully", response.ToLecturerResponse(lecturer)))
 }
 
 func (h *LecturerHandler) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid ID", err))
+		invalidRequest(c, "Invalid ID", err)
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusNotFound, response.ErrorResponse("Failed to delete lecturer", err))
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to delete lecturer", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response.SuccessResponse("Lecturer deleted successfully", nil))
 }
+
+func (h *LecturerHandler) preconditionFailed(c *gin.Context, id uuid.UUID, err error) {
+	current, getErr := h.useCase.GetByID(c.Request.Context(), id)
+	if getErr != nil {
+		respondError(c, "Lecturer not found", getErr)
+		return
+	}
+	preconditionFailed(c, err, current.Version, response.ToLecturerResponse(current))
+}
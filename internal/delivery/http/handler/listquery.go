@@ -0,0 +1,151 @@
+// File: internal/delivery/http/handler/listquery.go
+package handler
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+)
+
+// filterParam matches filter[field] and filter[field][op].
+var filterParam = regexp.MustCompile(`^filter\[([a-z_]+)\](?:\[([a-z]+)\])?$`)
+
+// Legacy list parameters, kept so that clients written before filter[]
+// existed get the results they always did.
+var (
+	studentParams  = map[string]query.Op{"major": query.Like, "status": query.Eq}
+	lecturerParams = map[string]query.Op{"department": query.Like, "status": query.Eq}
+	courseParams   = map[string]query.Op{
+		"department":  query.Like,
+		"semester":    query.Eq,
+		"lecturer_id": query.Eq,
+		"status":      query.Eq,
+	}
+	enrollmentParams = map[string]query.Op{
+		"student_id":    query.Eq,
+		"course_id":     query.Eq,
+		"academic_year": query.Eq,
+		"semester":      query.Eq,
+		"status":        query.Eq,
+	}
+)
+
+// listQuery parses the filter[...], search, sort and fields parameters of
+// a list request against the allowlist of resource, together with the
+// legacy parameters it still accepts. Every filter applies, so repeating
+// a field narrows the result. It reports a 400 naming the offending
+// parameter and returns false on anything the allowlist does not permit.
+func listQuery(c *gin.Context, resource *query.Resource, legacy map[string]query.Op) (query.Spec, bool) {
+	spec, err := parseListQuery(c, resource, legacy)
+	if err != nil {
+		respondError(c, "Invalid list query", err)
+		return spec, false
+	}
+	return spec, true
+}
+
+func parseListQuery(c *gin.Context, resource *query.Resource, legacy map[string]query.Op) (query.Spec, error) {
+	var spec query.Spec
+	params := c.Request.URL.Query()
+
+	// Sorted, so that of several bad parameters the same one is reported.
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		field, op := key, query.Eq
+		legacyOp, isLegacy := legacy[key]
+		match := filterParam.FindStringSubmatch(key)
+		switch {
+		case isLegacy:
+			op = legacyOp
+		case match != nil:
+			field = match[1]
+			if match[2] != "" {
+				op = query.Op(match[2])
+			}
+		case strings.HasPrefix(key, "filter"):
+			return spec, &apperror.Error{
+				Kind:    apperror.KindValidation,
+				Message: "filters are written filter[field] or filter[field][op]",
+				Field:   key,
+			}
+		default:
+			continue
+		}
+
+		for _, raw := range params[key] {
+			if isLegacy && raw == "" {
+				// An empty legacy parameter always meant no filter.
+				continue
+			}
+			filter, err := resource.NewFilter(key, field, op, raw)
+			if err != nil {
+				return spec, err
+			}
+			spec.Filters = append(spec.Filters, filter)
+		}
+	}
+
+	spec.Search = c.Query("search")
+	if raw := c.Query("sort"); raw != "" {
+		sorts, err := resource.ParseSort("sort", raw)
+		if err != nil {
+			return spec, err
+		}
+		spec.Sort = sorts
+	}
+	if raw := c.Query("fields"); raw != "" {
+		fields, err := resource.ParseFields("fields", raw)
+		if err != nil {
+			return spec, err
+		}
+		spec.Fields = fields
+	}
+	return spec, nil
+}
+
+// errSortWithCursor is reported for sort= in keyset mode, where the order
+// is fixed by the cursor.
+var errSortWithCursor = &apperror.Error{
+	Kind:    apperror.KindValidation,
+	Message: "cursor pagination is always newest first and cannot be sorted",
+	Field:   "sort",
+}
+
+// selectFields returns items as they are when fields is empty, or else
+// each item as an object holding only the requested fields. The fields of
+// a response item share the names of the allowlist, so the JSON encoding
+// is trimmed rather than each DTO growing a projection.
+func selectFields[T any](items []T, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return items, nil
+	}
+	selected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		object := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				object[field] = value
+			}
+		}
+		selected = append(selected, object)
+	}
+	return selected, nil
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+)
+
+func TestParseListQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawQuery  string
+		want      query.Spec
+		wantField string
+	}{
+		{name: "no parameters", rawQuery: "page=2&page_size=10"},
+		{
+			name:     "filters with and without operator",
+			rawQuery: "filter[status]=active&filter[enrollment_year][gte]=2023",
+			want: query.Spec{Filters: []query.Filter{
+				{Field: "enrollment_year", Op: query.Gte, Value: 2023},
+				{Field: "status", Op: query.Eq, Value: "active"},
+			}},
+		},
+		{
+			name:     "repeated filters narrow the result",
+			rawQuery: "filter[gpa][gte]=3&filter[gpa][gte]=3.5",
+			want: query.Spec{Filters: []query.Filter{
+				{Field: "gpa", Op: query.Gte, Value: 3.0},
+				{Field: "gpa", Op: query.Gte, Value: 3.5},
+			}},
+		},
+		{
+			name:     "legacy parameters keep their operator",
+			rawQuery: "major=Computer&status=active",
+			want: query.Spec{Filters: []query.Filter{
+				{Field: "major", Op: query.Like, Value: "Computer"},
+				{Field: "status", Op: query.Eq, Value: "active"},
+			}},
+		},
+		{name: "empty legacy parameter", rawQuery: "major=&status="},
+		{
+			name:     "search, sort and fields",
+			rawQuery: "search=ani&sort=-gpa,name&fields=nim,name",
+			want: query.Spec{
+				Search: "ani",
+				Sort:   []query.Sort{{Field: "gpa", Desc: true}, {Field: "name"}},
+				Fields: []string{"nim", "name"},
+			},
+		},
+		{name: "malformed filter", rawQuery: "filter[status=active", wantField: "filter[status"},
+		{name: "nested too deep", rawQuery: "filter[gpa][gte][x]=3", wantField: "filter[gpa][gte][x]"},
+		{name: "field outside the allowlist", rawQuery: "filter[password]=x", wantField: "filter[password]"},
+		{name: "operator outside the allowlist", rawQuery: "filter[name][gt]=A", wantField: "filter[name][gt]"},
+		{name: "bad operand", rawQuery: "filter[enrollment_year]=recent", wantField: "filter[enrollment_year]"},
+		{name: "first bad parameter in order", rawQuery: "filter[zzz]=1&filter[aaa]=1", wantField: "filter[aaa]"},
+		{name: "unsortable field", rawQuery: "sort=phone", wantField: "sort"},
+		{name: "unknown selected field", rawQuery: "fields=nim,password", wantField: "fields"},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/students?"+tt.rawQuery, nil)
+
+			got, err := parseListQuery(c, query.Students, studentParams)
+			if tt.wantField != "" {
+				if apperror.KindOf(err) != apperror.KindValidation || apperror.FieldOf(err) != tt.wantField {
+					t.Errorf("parseListQuery() = %v, want a validation error on %q", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseListQuery() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseListQuery() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	type item struct {
+		NIM   string  `json:"nim"`
+		Name  string  `json:"name"`
+		Phone string  `json:"phone,omitempty"`
+		GPA   float64 `json:"gpa"`
+	}
+	items := []item{{NIM: "2024001", Name: "Ani", GPA: 3.5}}
+
+	all, err := selectFields(items, nil)
+	if err != nil || !reflect.DeepEqual(all, items) {
+		t.Errorf("selectFields(nil) = %v, %v, want the items as they are", all, err)
+	}
+
+	selected, err := selectFields(items, []string{"gpa", "phone", "nim"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(selected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An empty omitempty field stays left out.
+	if want := `[{"gpa":3.5,"nim":"2024001"}]`; string(data) != want {
+		t.Errorf("selected = %s, want %s", data, want)
+	}
+}
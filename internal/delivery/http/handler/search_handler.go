@@ -0,0 +1,52 @@
+// File: internal/delivery/http/handler/search_handler.go
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type SearchHandler struct {
+	useCase usecase.SearchUseCase
+}
+
+func NewSearchHandler(useCase usecase.SearchUseCase) *SearchHandler {
+	return &SearchHandler{useCase: useCase}
+}
+
+// Search godoc
+// @Summary Search students, lecturers and courses
+// @Description Ranked matches on names, NIM, NIP and course codes, tolerant of Indonesian spelling variants and, on Postgres, of typos. Students can search lecturers and courses only.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search terms"
+// @Param types query string false "Comma-separated kinds: student, lecturer, course (default: all the caller may search)"
+// @Param limit query int false "Maximum results (capped at 50)" default(10)
+// @Success 200 {object} response.BaseResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	q := c.Query("q")
+
+	var kinds []string
+	if raw := c.Query("types"); raw != "" {
+		for _, kind := range strings.Split(raw, ",") {
+			kinds = append(kinds, strings.TrimSpace(kind))
+		}
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	role, _ := c.Get("user_role")
+	roleName, _ := role.(string)
+
+	results, err := h.useCase.Search(c.Request.Context(), q, kinds, roleName, limit)
+	if err != nil {
+		respondError(c, "Search failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Search completed", response.ToSearchResponse(q, results)))
+}
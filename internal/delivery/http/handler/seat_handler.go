@@ -0,0 +1,151 @@
+// File: internal/delivery/http/handler/seat_handler.go
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/seatfeed"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+const (
+	// seatWriteTimeout bounds every write to a seat stream. A client that
+	// stops reading is disconnected once its socket buffer is full.
+	seatWriteTimeout = 10 * time.Second
+
+	// seatRetry asks EventSource clients to wait this long before
+	// reconnecting.
+	seatRetry = 3 * time.Second
+)
+
+type SeatHandler struct {
+	feed      *usecase.SeatFeed
+	heartbeat time.Duration
+}
+
+func NewSeatHandler(feed *usecase.SeatFeed, heartbeat time.Duration) *SeatHandler {
+	return &SeatHandler{feed: feed, heartbeat: heartbeat}
+}
+
+// Get godoc
+// @Summary Get the seats of a course
+// @Description Capacity, taken and remaining seats of a course in one term. remaining is null when the course has no limit.
+// @Tags courses
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param academic_year query string true "Academic year, e.g. 2024/2025"
+// @Param semester query int true "Semester"
+// @Success 200 {object} response.BaseResponse
+// @Router /courses/{id}/seats [get]
+func (h *SeatHandler) Get(c *gin.Context) {
+	key, ok := seatKey(c)
+	if !ok {
+		return
+	}
+
+	seats, err := h.feed.Seats(c.Request.Context(), key)
+	if err != nil {
+		respondError(c, "Failed to get seats", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Seats retrieved successfully", seats))
+}
+
+// Stream godoc
+// @Summary Stream the seats of a course
+// @Description Server-Sent Events: a "seats" event with the current seats, then one whenever an enrollment in the course and term is created, dropped or removed. A client that falls behind gets a "lagged" event and is disconnected; it should reconnect.
+// @Tags courses
+// @Produce text/event-stream
+// @Param id path string true "Course ID"
+// @Param academic_year query string true "Academic year, e.g. 2024/2025"
+// @Param semester query int true "Semester"
+// @Success 200 {string} string "event stream"
+// @Router /courses/{id}/seats/stream [get]
+func (h *SeatHandler) Stream(c *gin.Context) {
+	key, ok := seatKey(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	sub, seats, err := h.feed.Subscribe(ctx, key, c.ClientIP())
+	if err != nil {
+		respondError(c, "Failed to watch seats", err)
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	// Keep reverse proxies such as nginx from buffering the stream.
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// The server's WriteTimeout would end the stream; each write gets a
+	// deadline of its own instead.
+	rc := http.NewResponseController(c.Writer)
+	write := func(event sse.Event) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(seatWriteTimeout))
+		if err := event.Render(c.Writer); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	if !write(sse.Event{Event: "seats", Retry: uint(seatRetry.Milliseconds()), Data: seats}) {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case seats, ok := <-sub.Updates():
+			if !ok {
+				if sub.Lagged() {
+					metrics.RecordSeatFeedLagged()
+					write(sse.Event{Event: "lagged", Data: "too many updates were not read in time, reconnect"})
+				}
+				return
+			}
+			if !write(sse.Event{Event: "seats", Data: seats}) {
+				return
+			}
+		case <-heartbeat.C:
+			// A comment line keeps proxies from closing an idle stream.
+			_ = rc.SetWriteDeadline(time.Now().Add(seatWriteTimeout))
+			if _, err := c.Writer.WriteString(": ping\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+func seatKey(c *gin.Context) (seatfeed.Key, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return seatfeed.Key{}, false
+	}
+	academicYear := c.Query("academic_year")
+	if academicYear == "" {
+		invalidRequest(c, "academic_year is required", nil)
+		return seatfeed.Key{}, false
+	}
+	semester, err := strconv.Atoi(c.Query("semester"))
+	if err != nil || semester < 1 {
+		invalidRequest(c, "semester must be a positive number", err)
+		return seatfeed.Key{}, false
+	}
+	return seatfeed.Key{CourseID: id, AcademicYear: academicYear, Semester: semester}, true
+}
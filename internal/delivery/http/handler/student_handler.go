@@ -4,6 +4,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -12,15 +13,19 @@ import (
 	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
 	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
 	"github.com/haninhammoud01/go-academic-service/internal/usecase"
 )
 
 type StudentHandler struct {
 	useCase usecase.StudentUseCase
+	limits  pagination.Limits
 }
 
-func NewStudentHandler(useCase usecase.StudentUseCase) *StudentHandler {
-	return &StudentHandler{useCase: useCase}
+func NewStudentHandler(useCase usecase.StudentUseCase, limits pagination.Limits) *StudentHandler {
+	return &StudentHandler{useCase: useCase, limits: limits}
 }
 
 // Create godoc
@@ -34,7 +39,7 @@ func NewStudentHandler(useCase usecase.StudentUseCase) *StudentHandler {
 func (h *StudentHandler) Create(c *gin.Context) {
 	var req request.CreateStudentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request", err))
+		invalidRequest(c, "Invalid request", err)
 		return
 	}
 
@@ -56,10 +61,11 @@ func (h *StudentHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.useCase.Create(c.Request.Context(), student); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Failed to create student", err))
+		respondError(c, "Failed to create student", err)
 		return
 	}
 
+	setETag(c, student.Version)
 	c.JSON(http.StatusCreated, response.SuccessResponse("Student created successfully", response.ToStudentResponse(student)))
 }
 
@@ -74,16 +80,17 @@ func (h *StudentHandler) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid student ID", err))
+		invalidRequest(c, "Invalid student ID", err)
 		return
 	}
 
 	student, err := h.useCase.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, response.ErrorResponse("Student not found", err))
+		respondError(c, "Student not found", err)
 		return
 	}
 
+	setETag(c, student.Version)
 	c.JSON(http.StatusOK, response.SuccessResponse("Student retrieved successfully", response.ToStudentResponse(student)))
 }
 
@@ -91,31 +98,36 @@ func (h *StudentHandler) GetByID(c *gin.Context) {
 // @Summary Get all students
 // @Tags students
 // @Produce json
+// @Description Pages by offset with page, or by keyset when cursor is present: pass an empty cursor for the first page, then the next or prev cursor of the previous response.
 // @Param page query int false "Page number" default(1)
-// @Param page_size query int false "Page size" default(10)
+// @Param page_size query int false "Page size (capped at MAX_PAGE_SIZE)" default(10)
+// @Param cursor query string false "Keyset cursor; switches to cursor pagination"
+// @Param include_total query bool false "Count all matches in cursor mode"
 // @Param major query string false "Filter by major"
 // @Param status query string false "Filter by status"
 // @Param search query string false "Search by name or NIM"
+// @Param filter query string false "filter[field]=value or filter[field][op]=value, op one of eq, ne, gt, gte, lt, lte, like, in; repeatable"
+// @Param sort query string false "Comma-separated fields, - for descending, e.g. -gpa,name"
+// @Param fields query string false "Comma-separated fields to return"
 // @Success 200 {object} response.BaseResponse
 // @Router /students [get]
 func (h *StudentHandler) GetAll(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-
-	filters := make(map[string]interface{})
-	if major := c.Query("major"); major != "" {
-		filters["major"] = major
-	}
-	if status := c.Query("status"); status != "" {
-		filters["status"] = status
+	spec, ok := listQuery(c, query.Students, studentParams)
+	if !ok {
+		return
 	}
-	if search := c.Query("search"); search != "" {
-		filters["search"] = search
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.getPage(c, spec)
+		return
 	}
 
-	students, total, err := h.useCase.GetAll(c.Request.Context(), page, pageSize, filters)
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	students, total, err := h.useCase.GetAll(c.Request.Context(), page, pageSize, spec)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse("Failed to get students", err))
+		respondError(c, "Failed to get students", err)
 		return
 	}
 
@@ -124,65 +136,114 @@ func (h *StudentHandler) GetAll(c *gin.Context) {
 		studentResponses = append(studentResponses, response.ToStudentResponse(student))
 	}
 
-	totalPage := int(total) / pageSize
-	if int(total)%pageSize > 0 {
-		totalPage++
+	data, err := selectFields(studentResponses, spec.Fields)
+	if err != nil {
+		respondError(c, "Failed to get students", err)
+		return
 	}
 
 	result := response.StudentListResponse{
-		Data: studentResponses,
+		Data: data,
 		Pagination: response.PaginationMeta{
 			Page:      page,
 			PageSize:  pageSize,
 			Total:     total,
-			TotalPage: totalPage,
+			TotalPage: pagination.TotalPages(total, pageSize),
 		},
 	}
 
 	c.JSON(http.StatusOK, response.SuccessResponse("Students retrieved successfully", result))
 }
 
+// getPage serves GetAll in keyset mode. The cursor, not an offset, marks
+// where the page starts, so rows inserted or deleted meanwhile do not shift
+// it, and no count runs unless include_total asks for one.
+func (h *StudentHandler) getPage(c *gin.Context, spec query.Spec) {
+	if len(spec.Sort) > 0 {
+		respondError(c, "Invalid list query", errSortWithCursor)
+		return
+	}
+	page, ok := keysetPage(c, h.limits)
+	if !ok {
+		return
+	}
+
+	window, err := h.useCase.GetPage(c.Request.Context(), page, spec)
+	if err != nil {
+		respondError(c, "Failed to get students", err)
+		return
+	}
+	total, err := countIfRequested(c, func() (int64, error) {
+		return h.useCase.Count(c.Request.Context(), spec)
+	})
+	if err != nil {
+		respondError(c, "Failed to get students", err)
+		return
+	}
+
+	var studentResponses []response.StudentResponse
+	for _, student := range window.Items {
+		studentResponses = append(studentResponses, response.ToStudentResponse(student))
+	}
+
+	data, err := selectFields(studentResponses, spec.Fields)
+	if err != nil {
+		respondError(c, "Failed to get students", err)
+		return
+	}
+
+	result := response.StudentCursorListResponse{
+		Data: data,
+		Pagination: cursorMeta(c, window, func(s *entity.Student) repository.Keyset {
+			return repository.Keyset{CreatedAt: s.CreatedAt, ID: s.ID}
+		}, page.Limit, total),
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Students retrieved successfully", result))
+}
+
 // Update godoc
 // @Summary Update student
+// @Description Partially updates a student; fields omitted from the body keep their stored values.
 // @Tags students
 // @Accept json
 // @Produce json
 // @Param id path string true "Student ID"
+// @Param If-Match header string true "ETag of the version being updated"
 // @Param student body request.UpdateStudentRequest true "Student data"
 // @Success 200 {object} response.BaseResponse
+// @Router /students/{id} [patch]
 // @Router /students/{id} [put]
 func (h *StudentHandler) Update(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid student ID", err))
+		invalidRequest(c, "Invalid student ID", err)
 		return
 	}
 
-	var req request.UpdateStudentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request", err))
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	student := &entity.Student{
-		Name:           req.Name,
-		Email:          req.Email,
-		Phone:          req.Phone,
-		Address:        req.Address,
-		DateOfBirth:    req.DateOfBirth,
-		Gender:         req.Gender,
-		Major:          req.Major,
-		EnrollmentYear: req.EnrollmentYear,
-		Status:         req.Status,
-		GPA:            req.GPA,
+	var req request.UpdateStudentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
 	}
 
-	if err := h.useCase.Update(c.Request.Context(), id, student); err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Failed to update student", err))
+	student, err := h.useCase.Update(c.Request.Context(), id, version, req.Changes())
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to update student", err)
 		return
 	}
 
+	setETag(c, student.Version)
 	c.JSON(http.StatusOK, response.SuccessResponse("Student updated successfully", response.ToStudentResponse(student)))
 }
 
@@ -191,20 +252,39 @@ func (h *StudentHandler) Update(c *gin.Context) {
 // @Tags students
 // @Produce json
 // @Param id path string true "Student ID"
+// @Param If-Match header string true "ETag of the version being deleted"
 // @Success 200 {object} response.BaseResponse
 // @Router /students/{id} [delete]
 func (h *StudentHandler) Delete(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid student ID", err))
+		invalidRequest(c, "Invalid student ID", err)
 		return
 	}
 
-	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusNotFound, response.ErrorResponse("Failed to delete student", err))
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Delete(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to delete student", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response.SuccessResponse("Student deleted successfully", nil))
 }
+
+func (h *StudentHandler) preconditionFailed(c *gin.Context, id uuid.UUID, err error) {
+	current, getErr := h.useCase.GetByID(c.Request.Context(), id)
+	if getErr != nil {
+		respondError(c, "Student not found", getErr)
+		return
+	}
+	preconditionFailed(c, err, current.Version, response.ToStudentResponse(current))
+}
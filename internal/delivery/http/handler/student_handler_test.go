@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStudentPatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantPhone   string
+		wantAddress string
+		wantName    string
+		wantVersion int
+	}{
+		{
+			name:        "only the fields sent change",
+			body:        `{"phone":"0812"}`,
+			wantStatus:  http.StatusOK,
+			wantPhone:   "0812",
+			wantAddress: "Jl. Merdeka 1",
+			wantName:    "Ani",
+			wantVersion: 2,
+		},
+		{
+			name:        "an empty string clears a field",
+			body:        `{"address":""}`,
+			wantStatus:  http.StatusOK,
+			wantPhone:   "0811",
+			wantName:    "Ani",
+			wantVersion: 2,
+		},
+		{
+			name:        "an empty object changes nothing",
+			body:        `{}`,
+			wantStatus:  http.StatusOK,
+			wantPhone:   "0811",
+			wantAddress: "Jl. Merdeka 1",
+			wantName:    "Ani",
+			wantVersion: 1,
+		},
+		{
+			name:        "sent fields are validated",
+			body:        `{"name":""}`,
+			wantStatus:  http.StatusBadRequest,
+			wantPhone:   "0811",
+			wantAddress: "Jl. Merdeka 1",
+			wantName:    "Ani",
+			wantVersion: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newStudentRouter()
+			created := createStudent(t, r)
+			path := "/students/" + created.Data.ID
+
+			w := serve(r, http.MethodPatch, path, tt.body, "If-Match", `"1"`)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("PATCH = %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+
+			var got studentBody
+			decode(t, serve(r, http.MethodGet, path, ""), &got)
+			if got.Data.Phone != tt.wantPhone || got.Data.Address != tt.wantAddress || got.Data.Name != tt.wantName {
+				t.Errorf("student = %+v, want phone %q, address %q, name %q", got.Data, tt.wantPhone, tt.wantAddress, tt.wantName)
+			}
+			if got.Data.Email != "ani@example.com" || got.Data.Major != "Informatics" {
+				t.Errorf("fields not sent changed: %+v", got.Data)
+			}
+			if got.Data.Version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", got.Data.Version, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// File: internal/delivery/http/handler/trash_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type TrashHandler struct {
+	useCase usecase.TrashUseCase
+	limits  pagination.Limits
+}
+
+func NewTrashHandler(useCase usecase.TrashUseCase, limits pagination.Limits) *TrashHandler {
+	return &TrashHandler{useCase: useCase, limits: limits}
+}
+
+// List godoc
+// @Summary List deleted records
+// @Description Soft-deleted students, lecturers, courses or enrollments, most recently deleted first, with the time from which each may be purged.
+// @Tags trash
+// @Produce json
+// @Param resource path string true "students, lecturers, courses or enrollments"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/trash/{resource} [get]
+func (h *TrashHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	records, total, err := h.useCase.List(c.Request.Context(), c.Param("resource"), page, pageSize)
+	if err != nil {
+		respondError(c, "Failed to get deleted records", err)
+		return
+	}
+
+	data := make([]response.DeletedRecordResponse, len(records))
+	for i, record := range records {
+		rendered, _ := recordResponse(record.Record)
+		data[i] = response.DeletedRecordResponse{Record: rendered, DeletedAt: record.DeletedAt, PurgeAfter: record.PurgeAfter}
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Deleted records retrieved successfully", response.DeletedRecordListResponse{
+		Data: data,
+		Pagination: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: pagination.TotalPages(total, pageSize),
+		},
+	}))
+}
+
+// Restore godoc
+// @Summary Restore a deleted record
+// @Description Fails with 409 while a live record holds one of its unique values, or for an enrollment whose student or course is deleted.
+// @Tags trash
+// @Produce json
+// @Param resource path string true "students, lecturers, courses or enrollments"
+// @Param id path string true "Record ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/trash/{resource}/{id}/restore [post]
+func (h *TrashHandler) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	record, err := h.useCase.Restore(c.Request.Context(), c.Param("resource"), id)
+	if err != nil {
+		respondError(c, "Failed to restore record", err)
+		return
+	}
+
+	rendered, version := recordResponse(record)
+	setETag(c, version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Record restored successfully", rendered))
+}
+
+// Purge godoc
+// @Summary Permanently delete a deleted record
+// @Description Only allowed once the retention period since the deletion has passed (409 before). Purging a student or course also removes its enrollments.
+// @Tags trash
+// @Produce json
+// @Param resource path string true "students, lecturers, courses or enrollments"
+// @Param id path string true "Record ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/trash/{resource}/{id} [delete]
+func (h *TrashHandler) Purge(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	if err := h.useCase.Purge(c.Request.Context(), c.Param("resource"), id); err != nil {
+		respondError(c, "Failed to purge record", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Record purged successfully", nil))
+}
+
+// recordResponse renders a record of any trash resource and returns its
+// version for the ETag.
+func recordResponse(record interface{}) (interface{}, int) {
+	switch r := record.(type) {
+	case *entity.Student:
+		return response.ToStudentResponse(r), r.Version
+	case *entity.Lecturer:
+		return response.ToLecturerResponse(r), r.Version
+	case *entity.Course:
+		return response.ToCourseResponse(r), r.Version
+	case *entity.Enrollment:
+		return response.ToEnrollmentResponse(r), r.Version
+	}
+	panic(fmt.Sprintf("trash: unexpected record type %T", record))
+}
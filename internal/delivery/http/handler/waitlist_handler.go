@@ -0,0 +1,211 @@
+// File: internal/delivery/http/handler/waitlist_handler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type WaitlistHandler struct {
+	useCase usecase.WaitlistUseCase
+}
+
+func NewWaitlistHandler(useCase usecase.WaitlistUseCase) *WaitlistHandler {
+	return &WaitlistHandler{useCase: useCase}
+}
+
+// Join godoc
+// @Summary Put a student on the waitlist of a full course
+// @Description The student joins the end of the waitlist of the course term. Fails with 409 while the course has free seats.
+// @Tags waitlists
+// @Accept json
+// @Produce json
+// @Param request body request.JoinWaitlistRequest true "Waitlist entry"
+// @Success 201 {object} response.BaseResponse
+// @Router /waitlists [post]
+func (h *WaitlistHandler) Join(c *gin.Context) {
+	var req request.JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	entry := &entity.WaitlistEntry{
+		StudentID:    req.StudentID,
+		CourseID:     req.CourseID,
+		AcademicYear: req.AcademicYear,
+		Semester:     req.Semester,
+	}
+
+	if err := h.useCase.Join(c.Request.Context(), entry); err != nil {
+		respondError(c, "Failed to join waitlist", err)
+		return
+	}
+
+	setETag(c, entry.Version)
+	c.JSON(http.StatusCreated, response.SuccessResponse("Joined waitlist successfully", response.ToWaitlistEntryResponse(entry)))
+}
+
+// List godoc
+// @Summary Get the waitlist of a course
+// @Description The waiting entries of a course term in the order they are promoted.
+// @Tags waitlists
+// @Produce json
+// @Param course_id query string true "Course ID"
+// @Param academic_year query string true "Academic year, e.g. 2024/2025"
+// @Param semester query int true "Semester"
+// @Success 200 {object} response.BaseResponse
+// @Router /waitlists [get]
+func (h *WaitlistHandler) List(c *gin.Context) {
+	courseID, err := uuid.Parse(c.Query("course_id"))
+	if err != nil {
+		invalidRequest(c, "course_id must be a valid ID", err)
+		return
+	}
+	academicYear := c.Query("academic_year")
+	if academicYear == "" {
+		invalidRequest(c, "academic_year is required", nil)
+		return
+	}
+	semester, err := strconv.Atoi(c.Query("semester"))
+	if err != nil || semester < 1 {
+		invalidRequest(c, "semester must be a positive number", err)
+		return
+	}
+
+	entries, err := h.useCase.List(c.Request.Context(), courseID, academicYear, semester)
+	if err != nil {
+		respondError(c, "Failed to get waitlist", err)
+		return
+	}
+
+	data := make([]response.WaitlistEntryResponse, len(entries))
+	for i, entry := range entries {
+		data[i] = response.ToWaitlistEntryResponse(entry)
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Waitlist retrieved successfully", response.WaitlistResponse{
+		CourseID:     courseID,
+		AcademicYear: academicYear,
+		Semester:     semester,
+		Entries:      data,
+	}))
+}
+
+// GetByID godoc
+// @Summary Get a waitlist entry
+// @Tags waitlists
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /waitlists/{id} [get]
+func (h *WaitlistHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	entry, err := h.useCase.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Waitlist entry not found", err)
+		return
+	}
+
+	setETag(c, entry.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Waitlist entry retrieved successfully", response.ToWaitlistEntryResponse(entry)))
+}
+
+// Move godoc
+// @Summary Move a waitlist entry
+// @Description Puts a waiting entry at a new position, counted from 1, and renumbers the waitlist. Requires If-Match.
+// @Tags waitlists
+// @Accept json
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Param If-Match header string true "ETag of the entry"
+// @Param request body request.MoveWaitlistEntryRequest true "New position"
+// @Success 200 {object} response.BaseResponse
+// @Router /waitlists/{id} [patch]
+func (h *WaitlistHandler) Move(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req request.MoveWaitlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	entry, err := h.useCase.Move(c.Request.Context(), id, version, req.Position)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to move waitlist entry", err)
+		return
+	}
+
+	setETag(c, entry.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Waitlist entry moved successfully", response.ToWaitlistEntryResponse(entry)))
+}
+
+// Remove godoc
+// @Summary Take an entry off its waitlist
+// @Description The entry is kept with status removed. Requires If-Match.
+// @Tags waitlists
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Param If-Match header string true "ETag of the entry"
+// @Success 200 {object} response.BaseResponse
+// @Router /waitlists/{id} [delete]
+func (h *WaitlistHandler) Remove(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.Remove(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to remove waitlist entry", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Waitlist entry removed successfully", nil))
+}
+
+func (h *WaitlistHandler) preconditionFailed(c *gin.Context, id uuid.UUID, err error) {
+	current, getErr := h.useCase.Get(c.Request.Context(), id)
+	if getErr != nil {
+		respondError(c, "Waitlist entry not found", getErr)
+		return
+	}
+	preconditionFailed(c, err, current.Version, response.ToWaitlistEntryResponse(current))
+}
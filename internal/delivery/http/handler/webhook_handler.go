@@ -0,0 +1,321 @@
+// File: internal/delivery/http/handler/webhook_handler.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/request"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/pagination"
+	"github.com/haninhammoud01/go-academic-service/internal/usecase"
+)
+
+type WebhookHandler struct {
+	useCase usecase.WebhookUseCase
+	limits  pagination.Limits
+}
+
+func NewWebhookHandler(useCase usecase.WebhookUseCase, limits pagination.Limits) *WebhookHandler {
+	return &WebhookHandler{useCase: useCase, limits: limits}
+}
+
+// Create godoc
+// @Summary Register a webhook subscription
+// @Description Subscribes a URL to domain events. The response carries the signing secret, which is not shown again.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body request.CreateWebhookRequest true "Subscription"
+// @Success 201 {object} response.BaseResponse
+// @Router /admin/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req request.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	subscription := &entity.WebhookSubscription{
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		Active:      req.Active == nil || *req.Active,
+	}
+
+	if err := h.useCase.CreateSubscription(c.Request.Context(), subscription); err != nil {
+		respondError(c, "Failed to create webhook subscription", err)
+		return
+	}
+
+	resp := response.ToWebhookSubscriptionResponse(subscription)
+	resp.Secret = subscription.Secret
+	setETag(c, subscription.Version)
+	c.JSON(http.StatusCreated, response.SuccessResponse("Webhook subscription created successfully", resp))
+}
+
+// List godoc
+// @Summary List webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	subscriptions, total, err := h.useCase.ListSubscriptions(c.Request.Context(), page, pageSize)
+	if err != nil {
+		respondError(c, "Failed to get webhook subscriptions", err)
+		return
+	}
+
+	data := make([]response.WebhookSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		data[i] = response.ToWebhookSubscriptionResponse(subscription)
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Webhook subscriptions retrieved successfully", response.WebhookSubscriptionListResponse{
+		Data: data,
+		Pagination: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: pagination.TotalPages(total, pageSize),
+		},
+	}))
+}
+
+// GetByID godoc
+// @Summary Get a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	subscription, err := h.useCase.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Webhook subscription not found", err)
+		return
+	}
+
+	setETag(c, subscription.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Webhook subscription retrieved successfully", response.ToWebhookSubscriptionResponse(subscription)))
+}
+
+// Update godoc
+// @Summary Update a webhook subscription
+// @Description Changes the URL, event types, description or active flag. Requires If-Match.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param If-Match header string true "ETag of the subscription"
+// @Param request body request.UpdateWebhookRequest true "Fields to change"
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/webhooks/{id} [patch]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, "Invalid request", err)
+		return
+	}
+
+	subscription, err := h.useCase.UpdateSubscription(c.Request.Context(), id, version, req.Changes())
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to update webhook subscription", err)
+		return
+	}
+
+	setETag(c, subscription.Version)
+	c.JSON(http.StatusOK, response.SuccessResponse("Webhook subscription updated successfully", response.ToWebhookSubscriptionResponse(subscription)))
+}
+
+// Delete godoc
+// @Summary Delete a webhook subscription
+// @Description Stops deliveries to the subscription. Its delivery log is kept. Requires If-Match.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param If-Match header string true "ETag of the subscription"
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.DeleteSubscription(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			h.preconditionFailed(c, id, err)
+			return
+		}
+		respondError(c, "Failed to delete webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Webhook subscription deleted successfully", nil))
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description The delivery log, newest first. status=dead lists the dead-lettered deliveries.
+// @Tags webhooks
+// @Produce json
+// @Param subscription_id query string false "Subscription ID"
+// @Param status query string false "pending, succeeded or dead"
+// @Param event_type query string false "Event type, e.g. GradePosted"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/webhooks/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	filter := repository.DeliveryFilter{Status: c.Query("status"), EventType: c.Query("event_type")}
+	var err error
+	if filter.SubscriptionID, err = optionalUUID(c.Query("subscription_id")); err != nil {
+		invalidRequest(c, "Invalid subscription_id", err)
+		return
+	}
+	h.listDeliveries(c, filter)
+}
+
+// ListSubscriptionDeliveries godoc
+// @Summary List the deliveries of a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param status query string false "pending, succeeded or dead"
+// @Param event_type query string false "Event type, e.g. GradePosted"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListSubscriptionDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+	h.listDeliveries(c, repository.DeliveryFilter{SubscriptionID: &id, Status: c.Query("status"), EventType: c.Query("event_type")})
+}
+
+func (h *WebhookHandler) listDeliveries(c *gin.Context, filter repository.DeliveryFilter) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = h.limits.Normalize(page, pageSize)
+
+	deliveries, total, err := h.useCase.ListDeliveries(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		respondError(c, "Failed to get webhook deliveries", err)
+		return
+	}
+
+	data := make([]response.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		data[i] = response.ToWebhookDeliveryResponse(delivery, false)
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Webhook deliveries retrieved successfully", response.WebhookDeliveryListResponse{
+		Data: data,
+		Pagination: response.PaginationMeta{
+			Page:      page,
+			PageSize:  pageSize,
+			Total:     total,
+			TotalPage: pagination.TotalPages(total, pageSize),
+		},
+	}))
+}
+
+// GetDelivery godoc
+// @Summary Get a webhook delivery
+// @Description One entry of the delivery log, with the payload sent.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 200 {object} response.BaseResponse
+// @Router /admin/webhooks/deliveries/{id} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	delivery, err := h.useCase.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Webhook delivery not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse("Webhook delivery retrieved successfully", response.ToWebhookDeliveryResponse(delivery, true)))
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queues a succeeded or dead-lettered delivery to be sent again with a fresh set of attempts. Fails with 409 while it is still pending.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 202 {object} response.BaseResponse
+// @Router /admin/webhooks/deliveries/{id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidRequest(c, "Invalid ID", err)
+		return
+	}
+
+	delivery, err := h.useCase.Redeliver(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, "Failed to redeliver webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.SuccessResponse("Webhook delivery queued", response.ToWebhookDeliveryResponse(delivery, false)))
+}
+
+func (h *WebhookHandler) preconditionFailed(c *gin.Context, id uuid.UUID, err error) {
+	current, getErr := h.useCase.GetSubscription(c.Request.Context(), id)
+	if getErr != nil {
+		respondError(c, "Webhook subscription not found", getErr)
+		return
+	}
+	preconditionFailed(c, err, current.Version, response.ToWebhookSubscriptionResponse(current))
+}
@@ -4,11 +4,11 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/actor"
 	"github.com/haninhammoud01/go-academic-service/internal/pkg/jwt"
 )
 
@@ -24,7 +24,7 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, response.ErrorResponse("Missing authorization header", nil))
+			_ = c.Error(apperror.Unauthorized("missing authorization header"))
 			c.Abort()
 			return
 		}
@@ -32,7 +32,7 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		// Extract token from "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, response.ErrorResponse("Invalid authorization format", nil))
+			_ = c.Error(apperror.Unauthorized("invalid authorization format"))
 			c.Abort()
 			return
 		}
@@ -40,7 +40,7 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		token := parts[1]
 		claims, err := m.jwtService.ValidateToken(token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, response.ErrorResponse("Invalid or expired token", err))
+			_ = c.Error(apperror.Wrap(apperror.KindUnauthorized, "invalid or expired token", err))
 			c.Abort()
 			return
 		}
@@ -50,6 +50,10 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		c.Set("user_email", claims.Email)
 		c.Set("user_role", claims.Role)
 
+		caller := actor.From(c.Request.Context())
+		caller.UserID, caller.Role = claims.UserID, claims.Role
+		c.Request = c.Request.WithContext(actor.With(c.Request.Context(), caller))
+
 		c.Next()
 	}
 }
@@ -58,7 +62,7 @@ func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("user_role")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, response.ErrorResponse("User role not found", nil))
+			_ = c.Error(apperror.Unauthorized("user role not found"))
 			c.Abort()
 			return
 		}
@@ -73,7 +77,7 @@ func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 		}
 
 		if !allowed {
-			c.JSON(http.StatusForbidden, response.ErrorResponse("Insufficient permissions", nil))
+			_ = c.Error(apperror.Forbidden("insufficient permissions"))
 			c.Abort()
 			return
 		}
@@ -0,0 +1,218 @@
+// File: internal/delivery/http/middleware/error_middleware.go
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/tracing"
+)
+
+var registerTagNames sync.Once
+
+// ErrorHandler renders the last error a handler attached with c.Error as an
+// RFC 7807 problem. The status comes from the domain error kind. Unless
+// debug is set, details of internal errors are replaced by a generic text
+// so driver messages and SQL never reach the client. Other errors always
+// carry only their domain message.
+func ErrorHandler(debug bool) gin.HandlerFunc {
+	registerTagNames.Do(useJSONFieldNames)
+
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		last := c.Errors.Last()
+		message, _ := last.Meta.(string)
+		problem := problemFromError(last.Err, message, debug)
+		if problem.Status >= http.StatusInternalServerError {
+			ctx := c.Request.Context()
+			logger.FromContext(ctx).ErrorContext(ctx, message, slog.String("error", last.Err.Error()))
+		}
+		WriteProblem(c, problem)
+	}
+}
+
+// WriteProblem writes problem as application/problem+json and aborts the
+// chain. Instance and trace id are filled in from the request.
+func WriteProblem(c *gin.Context, problem response.Problem) {
+	if problem.Instance == "" {
+		problem.Instance = c.Request.URL.Path
+	}
+	problem.TraceID = TraceID(c)
+
+	c.Header("Content-Type", response.ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// TraceID returns the identifier used to correlate a response with logs:
+// the OpenTelemetry trace ID when the request is traced, otherwise the
+// request ID.
+func TraceID(c *gin.Context) string {
+	if id := tracing.TraceID(c.Request.Context()); id != "" {
+		return id
+	}
+	return c.GetString(requestIDKey)
+}
+
+func StatusFromError(err error) int {
+	switch apperror.KindOf(err) {
+	case apperror.KindNotFound:
+		return http.StatusNotFound
+	case apperror.KindConflict:
+		return http.StatusConflict
+	case apperror.KindValidation:
+		return http.StatusBadRequest
+	case apperror.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.KindForbidden:
+		return http.StatusForbidden
+	case apperror.KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	case apperror.KindPreconditionRequired:
+		return http.StatusPreconditionRequired
+	case apperror.KindUnavailable:
+		return http.StatusServiceUnavailable
+	case apperror.KindRateLimited:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+func problemFromError(err error, message string, debug bool) response.Problem {
+	kind := apperror.KindOf(err)
+	status := StatusFromError(err)
+
+	var detail string
+	var appErr *apperror.Error
+	switch {
+	case kind == apperror.KindInternal && debug:
+		detail = strings.TrimPrefix(message+": "+err.Error(), ": ")
+	case kind == apperror.KindInternal:
+		detail = "An unexpected error occurred"
+	case errors.As(err, &appErr):
+		// Only the domain message, in debug mode too: wrapped driver and
+		// validator errors stay server-side, and fields go into Errors.
+		detail = appErr.Message
+	}
+
+	problem := response.NewProblem(status, kind.String(), detail)
+	problem.Errors = fieldErrors(err)
+	return problem
+}
+
+// fieldErrors extracts per-field details from validator failures, JSON
+// decoding errors and domain errors that name a field.
+func fieldErrors(err error) []response.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]response.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			field := fieldPath(fe)
+			fields = append(fields, response.FieldError{
+				Field:   field,
+				Rule:    fe.Tag(),
+				Message: validationMessage(field, fe),
+			})
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []response.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type),
+		}}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return []response.FieldError{{
+			Field:   "body",
+			Rule:    "json",
+			Message: fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset),
+		}}
+	}
+
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) && appErr.Field != "" {
+		rule := appErr.Kind.String()
+		if appErr.Kind == apperror.KindConflict {
+			rule = "unique"
+		}
+		return []response.FieldError{{Field: appErr.Field, Rule: rule, Message: appErr.Message}}
+	}
+
+	return nil
+}
+
+// fieldPath drops the struct name from the namespace so nested fields read
+// like the JSON document ("address.city" rather than "Request.address.city").
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+func validationMessage(field string, fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email address"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min":
+		if isString {
+			return fmt.Sprintf("%s must be at least %s characters", field, fe.Param())
+		}
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "max":
+		if isString {
+			return fmt.Sprintf("%s must be at most %s characters", field, fe.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
+	case "uuid", "uuid4":
+		return field + " must be a valid UUID"
+	}
+	return fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+}
+
+// useJSONFieldNames makes validator report JSON names instead of Go field
+// names, so errors refer to what the client actually sent.
+func useJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+}
@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/delivery/http/dto/response"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+)
+
+func TestStatusFromError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: apperror.NotFound("student not found"), want: http.StatusNotFound},
+		{err: apperror.Conflict("nim", "nim already exists"), want: http.StatusConflict},
+		{err: apperror.Validation("bad input"), want: http.StatusBadRequest},
+		{err: apperror.Unauthorized("no token"), want: http.StatusUnauthorized},
+		{err: apperror.Forbidden("not yours"), want: http.StatusForbidden},
+		{err: apperror.New(apperror.KindPreconditionFailed, "stale"), want: http.StatusPreconditionFailed},
+		{err: apperror.New(apperror.KindPreconditionRequired, "If-Match"), want: http.StatusPreconditionRequired},
+		{err: apperror.Unavailable("database unavailable", errors.New("dial")), want: http.StatusServiceUnavailable},
+		{err: apperror.New(apperror.KindRateLimited, "slow down"), want: http.StatusTooManyRequests},
+		{err: fmt.Errorf("find: %w", apperror.NotFound("course not found")), want: http.StatusNotFound},
+		{err: errors.New("driver exploded"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := StatusFromError(tt.err); got != tt.want {
+			t.Errorf("StatusFromError(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+// signupRequest exercises the validator and JSON decoding errors.
+type signupRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Name  string `json:"full_name" binding:"required,min=3"`
+	Age   int    `json:"age"`
+}
+
+func TestErrorHandlerProblem(t *testing.T) {
+	tests := []struct {
+		name       string
+		debug      bool
+		body       string
+		err        error
+		wantStatus int
+		wantType   string
+		wantTitle  string
+		wantDetail string
+		wantFields []response.FieldError
+	}{
+		{
+			name:       "not found",
+			err:        apperror.NotFound("student not found"),
+			wantStatus: http.StatusNotFound,
+			wantType:   "urn:academic-service:problem:not-found",
+			wantTitle:  "Not Found",
+			wantDetail: "student not found",
+		},
+		{
+			name:       "conflict names the field",
+			err:        apperror.Conflict("nim", "nim already exists"),
+			wantStatus: http.StatusConflict,
+			wantType:   "urn:academic-service:problem:conflict",
+			wantTitle:  "Resource conflict",
+			wantDetail: "nim already exists",
+			wantFields: []response.FieldError{{Field: "nim", Rule: "unique", Message: "nim already exists"}},
+		},
+		{
+			name:       "wrapped driver error stays server-side",
+			err:        apperror.Wrap(apperror.KindValidation, "referenced record does not exist", errors.New("pq: violates fk_enrollments_student")),
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:academic-service:problem:validation",
+			wantTitle:  "Validation failed",
+			wantDetail: "referenced record does not exist",
+		},
+		{
+			name:       "internal error is hidden",
+			err:        errors.New("pq: relation students does not exist"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "urn:academic-service:problem:internal",
+			wantTitle:  "Internal Server Error",
+			wantDetail: "An unexpected error occurred",
+		},
+		{
+			name:       "internal error in debug mode",
+			debug:      true,
+			err:        errors.New("pq: relation students does not exist"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "urn:academic-service:problem:internal",
+			wantTitle:  "Internal Server Error",
+			wantDetail: "Request failed: pq: relation students does not exist",
+		},
+		{
+			name:       "validator errors use JSON names",
+			body:       `{"email":"nope","full_name":"Al"}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:academic-service:problem:validation",
+			wantTitle:  "Validation failed",
+			wantDetail: "Request failed",
+			wantFields: []response.FieldError{
+				{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+				{Field: "full_name", Rule: "min", Message: "full_name must be at least 3 characters"},
+			},
+		},
+		{
+			name:       "validator errors in debug mode",
+			debug:      true,
+			body:       `{"email":"nope","full_name":"Ani"}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:academic-service:problem:validation",
+			wantTitle:  "Validation failed",
+			wantDetail: "Request failed",
+			wantFields: []response.FieldError{{Field: "email", Rule: "email", Message: "email must be a valid email address"}},
+		},
+		{
+			name:       "wrapped driver error in debug mode",
+			debug:      true,
+			err:        apperror.Wrap(apperror.KindValidation, "referenced record does not exist", errors.New("pq: violates fk_enrollments_student")),
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:academic-service:problem:validation",
+			wantTitle:  "Validation failed",
+			wantDetail: "referenced record does not exist",
+		},
+		{
+			name:       "type mismatch",
+			body:       `{"email":"a@example.com","full_name":"Ani","age":"old"}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:academic-service:problem:validation",
+			wantTitle:  "Validation failed",
+			wantDetail: "Request failed",
+			wantFields: []response.FieldError{{Field: "age", Rule: "type", Message: "age must be of type int"}},
+		},
+		{
+			name:       "malformed JSON",
+			body:       `{"email":}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:academic-service:problem:validation",
+			wantTitle:  "Validation failed",
+			wantDetail: "Request failed",
+			wantFields: []response.FieldError{{Field: "body", Rule: "json", Message: "malformed JSON at offset 10"}},
+		},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(RequestID(), ErrorHandler(tt.debug))
+			r.POST("/students", func(c *gin.Context) {
+				err := tt.err
+				if tt.body != "" {
+					var req signupRequest
+					if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+						err = apperror.Wrap(apperror.KindValidation, "Request failed", bindErr)
+					}
+				}
+				_ = c.Error(err).SetMeta("Request failed")
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/students", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); got != response.ProblemContentType {
+				t.Errorf("Content-Type = %q, want %q", got, response.ProblemContentType)
+			}
+			var problem response.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("decode %q: %v", w.Body, err)
+			}
+			if problem.Type != tt.wantType || problem.Title != tt.wantTitle || problem.Status != tt.wantStatus {
+				t.Errorf("problem = %q %q %d, want %q %q %d", problem.Type, problem.Title, problem.Status, tt.wantType, tt.wantTitle, tt.wantStatus)
+			}
+			if problem.Detail != tt.wantDetail {
+				t.Errorf("detail = %q, want %q", problem.Detail, tt.wantDetail)
+			}
+			if problem.Instance != "/students" || problem.TraceID == "" {
+				t.Errorf("instance %q and trace id %q, want the path and the request id", problem.Instance, problem.TraceID)
+			}
+			if !slices.Equal(problem.Errors, tt.wantFields) {
+				t.Errorf("errors = %+v, want %+v", problem.Errors, tt.wantFields)
+			}
+		})
+	}
+}
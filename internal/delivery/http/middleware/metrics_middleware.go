@@ -0,0 +1,42 @@
+// File: internal/delivery/http/middleware/metrics_middleware.go
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
+)
+
+// unmatchedRoute labels requests that hit no route, so scans of random
+// paths cannot blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+// otherMethod labels requests with a nonstandard method, for the same
+// reason.
+const otherMethod = "other"
+
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.ObserveHTTPRequest(methodLabel(c.Request.Method), route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// methodLabel returns method if it is one of the standard HTTP methods,
+// and otherMethod otherwise.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return otherMethod
+}
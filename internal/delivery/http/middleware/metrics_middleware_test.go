@@ -0,0 +1,23 @@
+package middleware
+
+import "testing"
+
+func TestMethodLabel(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{method: "GET", want: "GET"},
+		{method: "PATCH", want: "PATCH"},
+		{method: "OPTIONS", want: "OPTIONS"},
+		{method: "get", want: "other"},
+		{method: "PROPFIND", want: "other"},
+		{method: "X-RANDOM-1234", want: "other"},
+		{method: "", want: "other"},
+	}
+	for _, tt := range tests {
+		if got := methodLabel(tt.method); got != tt.want {
+			t.Errorf("methodLabel(%q) = %q, want %q", tt.method, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// File: internal/delivery/http/middleware/ratelimit_middleware.go
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"golang.org/x/time/rate"
+)
+
+// limiterIdleTTL is how long a client's bucket is kept after its last
+// request. A bucket idle this long has refilled anyway.
+const limiterIdleTTL = 10 * time.Minute
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipLimiter keeps one token bucket per client IP. Idle buckets are swept
+// lazily on access, so no background goroutine is needed.
+type ipLimiter struct {
+	rps   rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// reserve takes a token for ip and reports how long the caller would have
+// to wait for it; zero means the request may proceed.
+func (l *ipLimiter) reserve(ip string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > limiterIdleTTL {
+		for key, client := range l.clients {
+			if now.Sub(client.lastSeen) > limiterIdleTTL {
+				delete(l.clients, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(l.rps, l.burst)}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+
+	reservation := client.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		reservation.CancelAt(now)
+	}
+	return delay
+}
+
+// RateLimit allows each client IP rps requests per second with bursts of
+// up to burst. Rejected requests get 429 and a Retry-After header.
+func RateLimit(rps float64, burst int) gin.HandlerFunc {
+	limiter := &ipLimiter{
+		rps:     rate.Limit(rps),
+		burst:   burst,
+		clients: make(map[string]*clientLimiter),
+	}
+
+	return func(c *gin.Context) {
+		if delay := limiter.reserve(c.ClientIP(), time.Now()); delay > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			_ = c.Error(apperror.New(apperror.KindRateLimited, "too many requests, retry later"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+// File: internal/delivery/http/middleware/tracing_middleware.go
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// untracedPaths are polled by infrastructure and would only add noise.
+var untracedPaths = map[string]bool{
+	"/health":  true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Tracing starts a server span per request, continuing the caller's trace
+// when a W3C traceparent header is present. It must run before RequestID
+// and AccessLog so their context and log lines carry the span.
+func Tracing(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service, otelgin.WithFilter(func(r *http.Request) bool {
+		return !untracedPaths[r.URL.Path]
+	}))
+}
@@ -0,0 +1,32 @@
+// File: internal/domain/entity/advisor.go
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdvisorAssignment makes a lecturer the academic advisor (dosen wali) of
+// a student from StartedAt until EndedAt. The assignment without EndedAt
+// is the current one; the ended ones are kept as history.
+type AdvisorAssignment struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	StudentID  uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_advisor_assignments_student_id,where:ended_at IS NULL" json:"student_id"`
+	Student    *Student   `gorm:"foreignKey:StudentID;constraint:OnDelete:CASCADE" json:"student,omitempty"`
+	LecturerID uuid.UUID  `gorm:"type:uuid;not null;index:idx_advisor_assignments_lecturer_id" json:"lecturer_id"`
+	Lecturer   *Lecturer  `gorm:"foreignKey:LecturerID;constraint:OnDelete:CASCADE" json:"lecturer,omitempty"`
+	StartedAt  time.Time  `gorm:"not null" json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (AdvisorAssignment) TableName() string {
+	return "advisor_assignments"
+}
+
+func (a *AdvisorAssignment) BeforeCreate(*gorm.DB) error {
+	assignID(&a.ID)
+	return nil
+}
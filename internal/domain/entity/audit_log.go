@@ -0,0 +1,92 @@
+// File: internal/domain/entity/audit_log.go
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// Entity types recorded in the audit log.
+const (
+	AuditStudent    = "student"
+	AuditLecturer   = "lecturer"
+	AuditCourse     = "course"
+	AuditEnrollment = "enrollment"
+	AuditUser       = "user"
+	AuditWebhook    = "webhook"
+	AuditWaitlist   = "waitlist"
+	AuditAdvisor    = "advisor"
+	AuditKRS        = "krs"
+)
+
+var AuditEntityTypes = []string{AuditStudent, AuditLecturer, AuditCourse, AuditEnrollment, AuditUser, AuditWebhook, AuditWaitlist, AuditAdvisor, AuditKRS}
+
+// AuditLog is one entry of the append-only audit log: a change to one
+// record, who made it and which fields it changed. With hash chaining on,
+// Hash seals the entry together with the Hash of the entry before it.
+type AuditLog struct {
+	ID         int64        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Action     string       `gorm:"not null;size:20" json:"action"`
+	EntityType string       `gorm:"not null;size:20;index:idx_audit_logs_entity" json:"entity_type"`
+	EntityID   uuid.UUID    `gorm:"type:uuid;not null;index:idx_audit_logs_entity" json:"entity_id"`
+	ActorID    *uuid.UUID   `gorm:"type:uuid;index:idx_audit_logs_actor_id" json:"actor_id,omitempty"`
+	ActorRole  string       `gorm:"size:20" json:"actor_role,omitempty"`
+	IP         string       `gorm:"size:45" json:"ip,omitempty"`
+	RequestID  string       `gorm:"size:128" json:"request_id,omitempty"`
+	Changes    AuditChanges `gorm:"type:jsonb;not null" json:"changes"`
+	PrevHash   string       `gorm:"size:64" json:"prev_hash,omitempty"`
+	Hash       string       `gorm:"size:64" json:"hash,omitempty"`
+	CreatedAt  time.Time    `gorm:"not null;index:idx_audit_logs_created_at" json:"created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// FieldChange is one field before and after a change. Old is nil for a
+// create and New is nil for a delete or purge.
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditChanges maps field names, as in the API, to their change. It is
+// stored as JSON.
+type AuditChanges map[string]FieldChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *AuditChanges) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case nil:
+		*c = nil
+		return nil
+	}
+	return fmt.Errorf("audit changes: cannot scan %T", src)
+}
@@ -9,8 +9,8 @@ import (
 )
 
 type Course struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Code        string         `gorm:"uniqueIndex;not null;size:20" json:"code"`
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	Code        string         `gorm:"uniqueIndex:idx_courses_code,where:deleted_at IS NULL;not null;size:20" json:"code"`
 	Name        string         `gorm:"not null;size:200" json:"name"`
 	Description string         `gorm:"type:text" json:"description"`
 	Credits     int            `gorm:"not null;check:credits > 0" json:"credits"`
@@ -21,6 +21,7 @@ type Course struct {
 	LecturerID  *uuid.UUID     `gorm:"type:uuid" json:"lecturer_id,omitempty"`
 	Lecturer    *Lecturer      `gorm:"foreignKey:LecturerID;constraint:OnDelete:SET NULL" json:"lecturer,omitempty"`
 	Status      string         `gorm:"size:20;default:'active';check:status IN ('active', 'inactive')" json:"status"`
+	Version     int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -29,3 +30,8 @@ type Course struct {
 func (Course) TableName() string {
 	return "courses"
 }
+
+func (c *Course) BeforeCreate(*gorm.DB) error {
+	assignID(&c.ID)
+	return nil
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type Enrollment struct {
-	ID                   uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ID                   uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
 	StudentID            uuid.UUID      `gorm:"type:uuid;not null" json:"student_id"`
 	Student              *Student       `gorm:"foreignKey:StudentID;constraint:OnDelete:CASCADE" json:"student,omitempty"`
 	CourseID             uuid.UUID      `gorm:"type:uuid;not null" json:"course_id"`
@@ -22,6 +22,7 @@ type Enrollment struct {
 	Score                *float64       `gorm:"type:decimal(5,2)" json:"score,omitempty"`
 	AttendancePercentage *float64       `gorm:"type:decimal(5,2)" json:"attendance_percentage,omitempty"`
 	Remarks              string         `gorm:"type:text" json:"remarks,omitempty"`
+	Version              int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt            time.Time      `json:"created_at"`
 	UpdatedAt            time.Time      `json:"updated_at"`
 	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -30,3 +31,8 @@ type Enrollment struct {
 func (Enrollment) TableName() string {
 	return "enrollments"
 }
+
+func (e *Enrollment) BeforeCreate(*gorm.DB) error {
+	assignID(&e.ID)
+	return nil
+}
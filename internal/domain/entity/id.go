@@ -0,0 +1,13 @@
+// File: internal/domain/entity/id.go
+package entity
+
+import "github.com/google/uuid"
+
+// assignID gives a new row a random UUID unless the caller chose one.
+// IDs are generated here rather than by a database default so that every
+// storage backend, including SQLite, hands out the same kind of ID.
+func assignID(id *uuid.UUID) {
+	if *id == uuid.Nil {
+		*id = uuid.New()
+	}
+}
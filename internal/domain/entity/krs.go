@@ -0,0 +1,81 @@
+// File: internal/domain/entity/krs.go
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KRS submission states. A draft is submitted to the student's advisor,
+// who approves it or sends it back for revision; a revised KRS is
+// submitted again. An approved KRS is final.
+const (
+	KRSDraft             = "draft"
+	KRSSubmitted         = "submitted"
+	KRSApproved          = "approved"
+	KRSRevisionRequested = "revision_requested"
+)
+
+// KRSSeatStatuses returns the KRS states in which enrollments hold a seat
+// of their course, counting from approval or, with from set to
+// KRSSubmitted, from submission.
+func KRSSeatStatuses(from string) []string {
+	if from == KRSSubmitted {
+		return []string{KRSSubmitted, KRSApproved}
+	}
+	return []string{KRSApproved}
+}
+
+// KRSSubmission is the study plan (Kartu Rencana Studi) of a student for
+// one term: the student's enrollments in that term, as a whole, going
+// through the advisor's approval. AdvisorID is the lecturer who reviews
+// it, taken from the advisor assignment when it is submitted.
+type KRSSubmission struct {
+	ID           uuid.UUID   `gorm:"type:uuid;primary_key" json:"id"`
+	StudentID    uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_krs_submissions_student_id_academic_year_semester" json:"student_id"`
+	Student      *Student    `gorm:"foreignKey:StudentID;constraint:OnDelete:CASCADE" json:"student,omitempty"`
+	AcademicYear string      `gorm:"not null;size:10;uniqueIndex:idx_krs_submissions_student_id_academic_year_semester" json:"academic_year"`
+	Semester     int         `gorm:"not null;check:semester > 0;uniqueIndex:idx_krs_submissions_student_id_academic_year_semester" json:"semester"`
+	Status       string      `gorm:"size:20;not null;default:'draft';check:status IN ('draft', 'submitted', 'approved', 'revision_requested')" json:"status"`
+	AdvisorID    *uuid.UUID  `gorm:"type:uuid;index:idx_krs_submissions_advisor_id" json:"advisor_id,omitempty"`
+	Advisor      *Lecturer   `gorm:"foreignKey:AdvisorID;constraint:OnDelete:SET NULL" json:"advisor,omitempty"`
+	SubmittedAt  *time.Time  `json:"submitted_at,omitempty"`
+	DecidedAt    *time.Time  `json:"decided_at,omitempty"`
+	Reviews      []KRSReview `gorm:"foreignKey:SubmissionID;constraint:OnDelete:CASCADE" json:"reviews,omitempty"`
+	Version      int         `gorm:"not null;default:1" json:"version"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+func (KRSSubmission) TableName() string {
+	return "krs_submissions"
+}
+
+func (k *KRSSubmission) BeforeCreate(*gorm.DB) error {
+	assignID(&k.ID)
+	return nil
+}
+
+// KRSReview is one decision on a submitted KRS with the advisor's comment.
+// ReviewedBy is the user who made it: the advisor, or an admin deciding on
+// their behalf.
+type KRSReview struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	SubmissionID uuid.UUID  `gorm:"type:uuid;not null;index:idx_krs_reviews_submission_id" json:"submission_id"`
+	Decision     string     `gorm:"size:20;not null;check:decision IN ('approved', 'revision_requested')" json:"decision"`
+	Comment      string     `gorm:"type:text" json:"comment,omitempty"`
+	LecturerID   *uuid.UUID `gorm:"type:uuid" json:"lecturer_id,omitempty"`
+	ReviewedBy   *uuid.UUID `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (KRSReview) TableName() string {
+	return "krs_reviews"
+}
+
+func (r *KRSReview) BeforeCreate(*gorm.DB) error {
+	assignID(&r.ID)
+	return nil
+}
@@ -9,10 +9,10 @@ import (
 )
 
 type Lecturer struct {
-	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	NIP            string         `gorm:"uniqueIndex;not null;size:20" json:"nip"`
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	NIP            string         `gorm:"column:nip;uniqueIndex:idx_lecturers_nip,where:deleted_at IS NULL;not null;size:20" json:"nip"`
 	Name           string         `gorm:"not null;size:100" json:"name"`
-	Email          string         `gorm:"uniqueIndex;not null;size:100" json:"email"`
+	Email          string         `gorm:"uniqueIndex:idx_lecturers_email,where:deleted_at IS NULL;not null;size:100" json:"email"`
 	Phone          string         `gorm:"size:20" json:"phone"`
 	Address        string         `gorm:"type:text" json:"address"`
 	DateOfBirth    *time.Time     `json:"date_of_birth,omitempty"`
@@ -24,6 +24,7 @@ type Lecturer struct {
 	Status         string         `gorm:"size:20;default:'active';check:status IN ('active', 'inactive', 'retired')" json:"status"`
 	UserID         *uuid.UUID     `gorm:"type:uuid" json:"user_id,omitempty"`
 	User           *User          `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL" json:"user,omitempty"`
+	Version        int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -32,3 +33,8 @@ type Lecturer struct {
 func (Lecturer) TableName() string {
 	return "lecturers"
 }
+
+func (l *Lecturer) BeforeCreate(*gorm.DB) error {
+	assignID(&l.ID)
+	return nil
+}
@@ -10,5 +10,13 @@ func AutoMigrate(db *gorm.DB) error {
 		&Lecturer{},
 		&Course{},
 		&Enrollment{},
+		&AuditLog{},
+		&OutboxEvent{},
+		&WebhookSubscription{},
+		&WebhookDelivery{},
+		&WaitlistEntry{},
+		&AdvisorAssignment{},
+		&KRSSubmission{},
+		&KRSReview{},
 	)
 }
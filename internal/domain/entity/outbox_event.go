@@ -0,0 +1,48 @@
+// File: internal/domain/entity/outbox_event.go
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain events published to other systems through the outbox.
+const (
+	EventStudentCreated       = "StudentCreated"
+	EventStudentStatusChanged = "StudentStatusChanged"
+	EventEnrollmentCreated    = "EnrollmentCreated"
+	EventGradePosted          = "GradePosted"
+	EventWaitlistPromoted     = "WaitlistPromoted"
+	EventKRSSubmitted         = "KRSSubmitted"
+	EventKRSReviewed          = "KRSReviewed"
+)
+
+var EventTypes = []string{EventStudentCreated, EventStudentStatusChanged, EventEnrollmentCreated, EventGradePosted, EventWaitlistPromoted, EventKRSSubmitted, EventKRSReviewed}
+
+// OutboxEvent is a domain event waiting to be published, written in the
+// same transaction as the change it describes. Events sharing an
+// OrderingKey are published in ID order; EventID stays the same across
+// redeliveries so consumers can drop duplicates. An event the sink keeps
+// rejecting is given up on after the relay's maximum attempts: DeadAt is
+// set and the events behind it on its key go ahead.
+type OutboxEvent struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_outbox_events_event_id" json:"event_id"`
+	Type          string     `gorm:"not null;size:50" json:"type"`
+	AggregateType string     `gorm:"not null;size:20" json:"aggregate_type"`
+	AggregateID   uuid.UUID  `gorm:"type:uuid;not null" json:"aggregate_id"`
+	OrderingKey   string     `gorm:"not null;size:100" json:"ordering_key"`
+	Payload       string     `gorm:"type:jsonb;not null" json:"payload"`
+	RequestID     string     `gorm:"size:128" json:"request_id,omitempty"`
+	OccurredAt    time.Time  `gorm:"not null" json:"occurred_at"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	PublishedAt   *time.Time `gorm:"index:idx_outbox_events_published_at" json:"published_at,omitempty"`
+	DeadAt        *time.Time `json:"dead_at,omitempty"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -9,10 +9,10 @@ import (
 )
 
 type Student struct {
-	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	NIM            string         `gorm:"uniqueIndex;not null;size:20" json:"nim"`
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	NIM            string         `gorm:"uniqueIndex:idx_students_nim,where:deleted_at IS NULL;not null;size:20" json:"nim"`
 	Name           string         `gorm:"not null;size:100" json:"name"`
-	Email          string         `gorm:"uniqueIndex;not null;size:100" json:"email"`
+	Email          string         `gorm:"uniqueIndex:idx_students_email,where:deleted_at IS NULL;not null;size:100" json:"email"`
 	Phone          string         `gorm:"size:20" json:"phone"`
 	Address        string         `gorm:"type:text" json:"address"`
 	DateOfBirth    *time.Time     `json:"date_of_birth,omitempty"`
@@ -23,6 +23,7 @@ type Student struct {
 	GPA            float64        `gorm:"type:decimal(3,2);default:0.00" json:"gpa"`
 	UserID         *uuid.UUID     `gorm:"type:uuid" json:"user_id,omitempty"`
 	User           *User          `gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL" json:"user,omitempty"`
+	Version        int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -31,3 +32,8 @@ type Student struct {
 func (Student) TableName() string {
 	return "students"
 }
+
+func (s *Student) BeforeCreate(*gorm.DB) error {
+	assignID(&s.ID)
+	return nil
+}
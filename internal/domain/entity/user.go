@@ -9,7 +9,7 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
 	Username  string         `gorm:"uniqueIndex;not null;size:50" json:"username"`
 	Email     string         `gorm:"uniqueIndex;not null;size:100" json:"email"`
 	Password  string         `gorm:"not null;size:255" json:"-"`
@@ -19,3 +19,8 @@ type User struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
+
+func (u *User) BeforeCreate(*gorm.DB) error {
+	assignID(&u.ID)
+	return nil
+}
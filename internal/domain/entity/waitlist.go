@@ -0,0 +1,46 @@
+// File: internal/domain/entity/waitlist.go
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Waitlist entry states. Only waiting entries are on the waitlist; the
+// others are kept as its history.
+const (
+	WaitlistWaiting  = "waiting"
+	WaitlistPromoted = "promoted"
+	WaitlistRemoved  = "removed"
+)
+
+// WaitlistEntry is a student waiting for a seat in a full course in one
+// term. Waiting entries are promoted in Position order; the enrollment of
+// a promoted entry holds its seat whatever the state of its KRS.
+type WaitlistEntry struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	StudentID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_waitlist_entries_student_id_course_id_academic_year_semester,where:status = 'waiting'" json:"student_id"`
+	Student      *Student   `gorm:"foreignKey:StudentID;constraint:OnDelete:CASCADE" json:"student,omitempty"`
+	CourseID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_waitlist_entries_student_id_course_id_academic_year_semester,where:status = 'waiting'" json:"course_id"`
+	Course       *Course    `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"course,omitempty"`
+	AcademicYear string     `gorm:"not null;size:10;uniqueIndex:idx_waitlist_entries_student_id_course_id_academic_year_semester,where:status = 'waiting'" json:"academic_year"`
+	Semester     int        `gorm:"not null;check:semester > 0;uniqueIndex:idx_waitlist_entries_student_id_course_id_academic_year_semester,where:status = 'waiting'" json:"semester"`
+	Position     int        `gorm:"not null" json:"position"`
+	Status       string     `gorm:"size:20;not null;default:'waiting';check:status IN ('waiting', 'promoted', 'removed')" json:"status"`
+	EnrollmentID *uuid.UUID `gorm:"type:uuid;index:idx_waitlist_entries_enrollment_id" json:"enrollment_id,omitempty"`
+	PromotedAt   *time.Time `json:"promoted_at,omitempty"`
+	Version      int        `gorm:"not null;default:1" json:"version"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+func (w *WaitlistEntry) BeforeCreate(*gorm.DB) error {
+	assignID(&w.ID)
+	return nil
+}
@@ -0,0 +1,111 @@
+// File: internal/domain/entity/webhook.go
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Delivery states. A pending delivery is retried until it succeeds or
+// runs out of attempts and is dead-lettered.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead"
+)
+
+var DeliveryStatuses = []string{DeliveryPending, DeliverySucceeded, DeliveryDead}
+
+// WebhookSubscription asks for the domain events of EventTypes to be
+// POSTed to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	URL         string         `gorm:"not null;size:500" json:"url"`
+	EventTypes  EventTypeList  `gorm:"type:jsonb;not null" json:"event_types"`
+	Secret      string         `gorm:"not null;size:100" json:"-"`
+	Description string         `gorm:"size:255" json:"description"`
+	Active      bool           `gorm:"not null" json:"active"`
+	Version     int            `gorm:"not null;default:1" json:"version"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s *WebhookSubscription) BeforeCreate(*gorm.DB) error {
+	assignID(&s.ID)
+	return nil
+}
+
+// Wants reports whether the subscription receives events of eventType.
+func (s *WebhookSubscription) Wants(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypeList is stored as a JSON array.
+type EventTypeList []string
+
+func (l EventTypeList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *EventTypeList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(l))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(l))
+	case nil:
+		*l = nil
+		return nil
+	}
+	return fmt.Errorf("event types: cannot scan %T", src)
+}
+
+// WebhookDelivery is one event on its way to one subscription, and the
+// outcome of the latest attempt. Payload is the event envelope as sent.
+type WebhookDelivery struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	SubscriptionID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"subscription_id"`
+	EventID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_subscription_event" json:"event_id"`
+	EventType      string     `gorm:"not null;size:50" json:"event_type"`
+	Payload        string     `gorm:"type:jsonb;not null" json:"payload"`
+	Status         string     `gorm:"not null;size:20" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (d *WebhookDelivery) BeforeCreate(*gorm.DB) error {
+	assignID(&d.ID)
+	return nil
+}
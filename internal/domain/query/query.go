@@ -0,0 +1,219 @@
+// File: internal/domain/query/query.go
+
+// Package query describes what a list request asks for: filters, a search
+// term, the sort order and the fields to return. A Spec is built once at
+// the edge of the service and checked against the Resource allowlist of
+// the list it targets, so repositories can translate it without
+// re-validating column names or value types.
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+)
+
+// Op is a filter operator.
+type Op string
+
+const (
+	Eq  Op = "eq"
+	Ne  Op = "ne"
+	Gt  Op = "gt"
+	Gte Op = "gte"
+	Lt  Op = "lt"
+	Lte Op = "lte"
+	// Like matches values containing the operand, ignoring case. % and _
+	// in the operand are wildcards.
+	Like Op = "like"
+	// In matches any value of a comma-separated list.
+	In Op = "in"
+)
+
+// Type is the type of a field's values, which decides how filter operands
+// are parsed: Int as int, Float as float64, Date (2006-01-02) and Time
+// (RFC 3339 or a date) as time.Time, UUID as uuid.UUID.
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Float
+	Date
+	Time
+	UUID
+)
+
+// Field is one entry of a resource allowlist. Every field can be selected
+// with fields=; Ops lists the operators it can be filtered with and
+// Sortable whether it can be sorted on. Values restricts the operands of
+// an enumerated string field.
+type Field struct {
+	Type     Type
+	Ops      []Op
+	Values   []string
+	Sortable bool
+	// Nullable fields sort their NULLs last in either direction.
+	Nullable bool
+}
+
+// Resource is the allowlist of a list endpoint. Field names are those of
+// the API and match the column names.
+type Resource struct {
+	Name   string
+	Fields map[string]Field
+	// Search lists the fields matched by a search term.
+	Search      []string
+	DefaultSort []Sort
+}
+
+type Filter struct {
+	Field string
+	Op    Op
+	// Value is the parsed operand, of the Go type for the field's Type;
+	// a []any of them for In.
+	Value any
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Spec is a validated list request. An empty Spec lists everything in the
+// resource's default order.
+type Spec struct {
+	Filters []Filter
+	Search  string
+	Sort    []Sort
+	// Fields limits the returned fields; empty means all.
+	Fields []string
+}
+
+// OrderBy returns the requested sort, or the resource default.
+func (s Spec) OrderBy(r *Resource) []Sort {
+	if len(s.Sort) > 0 {
+		return s.Sort
+	}
+	return r.DefaultSort
+}
+
+// NewFilter checks a filter against the allowlist and parses its operand.
+// param is the query parameter it came from, used to point at the problem.
+func (r *Resource) NewFilter(param, field string, op Op, raw string) (Filter, error) {
+	def, ok := r.Fields[field]
+	switch {
+	case !ok:
+		return Filter{}, invalid(param, "%s has no field %q", r.Name, field)
+	case !slices.Contains(def.Ops, op):
+		return Filter{}, invalid(param, "%s cannot be filtered with %q", field, op)
+	}
+
+	if op == In {
+		var values []any
+		for _, item := range strings.Split(raw, ",") {
+			value, err := def.parse(strings.TrimSpace(item))
+			if err != nil {
+				return Filter{}, invalid(param, "%s: %v", field, err)
+			}
+			values = append(values, value)
+		}
+		return Filter{Field: field, Op: op, Value: values}, nil
+	}
+	if op == Like {
+		// The operand is a pattern, not a value, even on enumerated fields.
+		return Filter{Field: field, Op: op, Value: raw}, nil
+	}
+	value, err := def.parse(raw)
+	if err != nil {
+		return Filter{}, invalid(param, "%s: %v", field, err)
+	}
+	return Filter{Field: field, Op: op, Value: value}, nil
+}
+
+// ParseSort parses a comma-separated sort list such as "-gpa,name", where
+// a leading minus sorts descending.
+func (r *Resource) ParseSort(param, raw string) ([]Sort, error) {
+	var sorts []Sort
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		name, desc := strings.CutPrefix(item, "-")
+		def, ok := r.Fields[name]
+		switch {
+		case !ok:
+			return nil, invalid(param, "%s has no field %q", r.Name, name)
+		case !def.Sortable:
+			return nil, invalid(param, "%s cannot be sorted on", name)
+		case slices.ContainsFunc(sorts, func(s Sort) bool { return s.Field == name }):
+			return nil, invalid(param, "%s is sorted on twice", name)
+		}
+		sorts = append(sorts, Sort{Field: name, Desc: desc})
+	}
+	return sorts, nil
+}
+
+// ParseFields parses a comma-separated field list.
+func (r *Resource) ParseFields(param, raw string) ([]string, error) {
+	var fields []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := r.Fields[name]; !ok {
+			return nil, invalid(param, "%s has no field %q", r.Name, name)
+		}
+		if !slices.Contains(fields, name) {
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+func (f Field) parse(raw string) (any, error) {
+	switch f.Type {
+	case Int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", raw)
+		}
+		return value, nil
+	case Float:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return value, nil
+	case Date:
+		value, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date (YYYY-MM-DD)", raw)
+		}
+		return value, nil
+	case Time:
+		if value, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			return value.UTC(), nil
+		}
+		value, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an RFC 3339 time or a date", raw)
+		}
+		return value, nil
+	case UUID:
+		value, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a UUID", raw)
+		}
+		return value, nil
+	}
+	if len(f.Values) > 0 && !slices.Contains(f.Values, raw) {
+		return nil, fmt.Errorf("%q is not one of %s", raw, strings.Join(f.Values, ", "))
+	}
+	return raw, nil
+}
+
+func invalid(param, format string, args ...any) error {
+	return &apperror.Error{Kind: apperror.KindValidation, Message: fmt.Sprintf(format, args...), Field: param}
+}
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+)
+
+func TestNewFilter(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		field   string
+		op      Op
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{name: "integer", field: "enrollment_year", op: Gte, raw: "2023", want: 2023},
+		{name: "float", field: "gpa", op: Lt, raw: "3.5", want: 3.5},
+		{name: "date", field: "date_of_birth", op: Lt, raw: "2000-01-31", want: time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "RFC 3339 time in UTC", field: "created_at", op: Gt, raw: "2024-09-02T15:30:00+07:00", want: time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC)},
+		{name: "date as time", field: "created_at", op: Gt, raw: "2024-09-02", want: time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "uuid", field: "id", op: Eq, raw: id.String(), want: id},
+		{name: "enumerated value", field: "status", op: Eq, raw: "active", want: "active"},
+		{name: "list", field: "enrollment_year", op: In, raw: "2022, 2023", want: []any{2022, 2023}},
+		{name: "pattern on any text", field: "major", op: Like, raw: "comp%", want: "comp%"},
+		{name: "unknown field", field: "password", op: Eq, raw: "x", wantErr: true},
+		{name: "field not filterable", field: "phone", op: Eq, raw: "0812", wantErr: true},
+		{name: "operator not allowed", field: "status", op: Gt, raw: "active", wantErr: true},
+		{name: "unknown operator", field: "name", op: Op("regex"), raw: ".*", wantErr: true},
+		{name: "not an integer", field: "enrollment_year", op: Eq, raw: "2023.5", wantErr: true},
+		{name: "not a date", field: "date_of_birth", op: Eq, raw: "31/01/2000", wantErr: true},
+		{name: "not a uuid", field: "id", op: Eq, raw: "42", wantErr: true},
+		{name: "value outside the enumeration", field: "status", op: Eq, raw: "expelled", wantErr: true},
+		{name: "bad item in a list", field: "status", op: In, raw: "active,expelled", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Students.NewFilter("filter", tt.field, tt.op, tt.raw)
+			if tt.wantErr {
+				if apperror.KindOf(err) != apperror.KindValidation || apperror.FieldOf(err) != "filter" {
+					t.Errorf("NewFilter() = %+v, %v, want a validation error on the parameter", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFilter() = %v", err)
+			}
+			want := Filter{Field: tt.field, Op: tt.op, Value: tt.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("NewFilter() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    []Sort
+		wantErr bool
+	}{
+		{raw: "name", want: []Sort{{Field: "name"}}},
+		{raw: "-gpa, name", want: []Sort{{Field: "gpa", Desc: true}, {Field: "name"}}},
+		{raw: "phone", wantErr: true},
+		{raw: "password", wantErr: true},
+		{raw: "name,-name", wantErr: true},
+		{raw: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Students.ParseSort("sort", tt.raw)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSort(%q) = %v, %v, want %v, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{raw: "nim,name", want: []string{"nim", "name"}},
+		{raw: "phone, nim, phone", want: []string{"phone", "nim"}},
+		{raw: "nim,password", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Students.ParseFields("fields", tt.raw)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseFields(%q) = %v, %v, want %v, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	if got := (Spec{}).OrderBy(Courses); !reflect.DeepEqual(got, []Sort{{Field: "code"}}) {
+		t.Errorf("default order = %v, want by code", got)
+	}
+	sorts := []Sort{{Field: "credits", Desc: true}}
+	if got := (Spec{Sort: sorts}).OrderBy(Courses); !reflect.DeepEqual(got, sorts) {
+		t.Errorf("order = %v, want %v", got, sorts)
+	}
+}
@@ -0,0 +1,96 @@
+// File: internal/domain/query/resources.go
+package query
+
+// The allowlists of the list endpoints. A field missing here can be
+// neither filtered, sorted nor selected, whatever the table holds.
+
+var (
+	ordered = []Op{Eq, Ne, Gt, Gte, Lt, Lte, In}
+	textual = []Op{Eq, Ne, Like, In}
+	exact   = []Op{Eq, Ne, In}
+)
+
+var Students = &Resource{
+	Name: "students",
+	Fields: map[string]Field{
+		"id":              {Type: UUID, Ops: exact},
+		"nim":             {Type: String, Ops: textual, Sortable: true},
+		"name":            {Type: String, Ops: textual, Sortable: true},
+		"email":           {Type: String, Ops: textual, Sortable: true},
+		"phone":           {Type: String},
+		"address":         {Type: String},
+		"date_of_birth":   {Type: Date, Ops: ordered, Sortable: true, Nullable: true},
+		"gender":          {Type: String, Ops: exact, Values: []string{"male", "female"}},
+		"major":           {Type: String, Ops: textual, Sortable: true},
+		"enrollment_year": {Type: Int, Ops: ordered, Sortable: true},
+		"status":          {Type: String, Ops: exact, Values: []string{"active", "inactive", "graduated", "dropped"}, Sortable: true},
+		"gpa":             {Type: Float, Ops: ordered, Sortable: true},
+		"version":         {Type: Int},
+		"created_at":      {Type: Time, Ops: ordered, Sortable: true},
+		"updated_at":      {Type: Time, Ops: ordered, Sortable: true},
+	},
+	Search:      []string{"name", "nim"},
+	DefaultSort: []Sort{{Field: "created_at", Desc: true}},
+}
+
+var Lecturers = &Resource{
+	Name: "lecturers",
+	Fields: map[string]Field{
+		"id":             {Type: UUID, Ops: exact},
+		"nip":            {Type: String, Ops: textual, Sortable: true},
+		"name":           {Type: String, Ops: textual, Sortable: true},
+		"email":          {Type: String, Ops: textual, Sortable: true},
+		"phone":          {Type: String},
+		"department":     {Type: String, Ops: textual, Sortable: true},
+		"position":       {Type: String, Ops: textual, Sortable: true},
+		"specialization": {Type: String, Ops: textual},
+		"status":         {Type: String, Ops: exact, Values: []string{"active", "inactive", "retired"}, Sortable: true},
+		"version":        {Type: Int},
+		"created_at":     {Type: Time, Ops: ordered, Sortable: true},
+	},
+	Search:      []string{"name", "nip"},
+	DefaultSort: []Sort{{Field: "created_at", Desc: true}},
+}
+
+var Courses = &Resource{
+	Name: "courses",
+	Fields: map[string]Field{
+		"id":           {Type: UUID, Ops: exact},
+		"code":         {Type: String, Ops: textual, Sortable: true},
+		"name":         {Type: String, Ops: textual, Sortable: true},
+		"description":  {Type: String},
+		"credits":      {Type: Int, Ops: ordered, Sortable: true},
+		"semester":     {Type: Int, Ops: ordered, Sortable: true},
+		"department":   {Type: String, Ops: textual, Sortable: true},
+		"course_type":  {Type: String, Ops: exact, Values: []string{"mandatory", "elective"}},
+		"max_students": {Type: Int, Ops: ordered, Sortable: true},
+		"lecturer_id":  {Type: UUID, Ops: exact},
+		"status":       {Type: String, Ops: exact, Values: []string{"active", "inactive"}},
+		"version":      {Type: Int},
+		"created_at":   {Type: Time, Ops: ordered, Sortable: true},
+		"updated_at":   {Type: Time, Ops: ordered, Sortable: true},
+	},
+	Search:      []string{"name", "code"},
+	DefaultSort: []Sort{{Field: "code"}},
+}
+
+var Enrollments = &Resource{
+	Name: "enrollments",
+	Fields: map[string]Field{
+		"id":                    {Type: UUID, Ops: exact},
+		"student_id":            {Type: UUID, Ops: exact},
+		"course_id":             {Type: UUID, Ops: exact},
+		"academic_year":         {Type: String, Ops: exact, Sortable: true},
+		"semester":              {Type: Int, Ops: ordered, Sortable: true},
+		"enrollment_date":       {Type: Time, Ops: ordered, Sortable: true},
+		"status":                {Type: String, Ops: exact, Values: []string{"enrolled", "completed", "dropped", "failed"}, Sortable: true},
+		"grade":                 {Type: String, Ops: exact, Values: []string{"A", "AB", "B", "BC", "C", "D", "E"}, Sortable: true},
+		"score":                 {Type: Float, Ops: ordered, Sortable: true, Nullable: true},
+		"attendance_percentage": {Type: Float, Ops: ordered, Sortable: true, Nullable: true},
+		"remarks":               {Type: String},
+		"version":               {Type: Int},
+		"created_at":            {Type: Time, Ops: ordered, Sortable: true},
+		"updated_at":            {Type: Time, Ops: ordered, Sortable: true},
+	},
+	DefaultSort: []Sort{{Field: "created_at", Desc: true}},
+}
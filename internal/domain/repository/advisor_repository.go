@@ -0,0 +1,21 @@
+// File: internal/domain/repository/advisor_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type AdvisorRepository interface {
+	Create(ctx context.Context, assignment *entity.AdvisorAssignment) error
+	// FindCurrent returns the assignment of a student that has not ended.
+	FindCurrent(ctx context.Context, studentID uuid.UUID) (*entity.AdvisorAssignment, error)
+	// FindByStudent returns all assignments of a student, newest first.
+	FindByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.AdvisorAssignment, error)
+	// End ends the current assignment of a student at endedAt. It returns
+	// ErrNotFound when the student has none.
+	End(ctx context.Context, studentID uuid.UUID, endedAt time.Time) error
+}
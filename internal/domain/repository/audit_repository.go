@@ -0,0 +1,35 @@
+// File: internal/domain/repository/audit_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+// AuditFilter narrows a listing of the audit log. Zero fields match every
+// entry; From is inclusive and To exclusive.
+type AuditFilter struct {
+	EntityType string
+	EntityID   *uuid.UUID
+	ActorID    *uuid.UUID
+	From       time.Time
+	To         time.Time
+}
+
+// AuditRepository stores the audit log. Entries are append-only: there is
+// no update or delete, and the migrations reject both in the database.
+type AuditRepository interface {
+	// Create appends entry and assigns its ID, which grows with every entry.
+	Create(ctx context.Context, entry *entity.AuditLog) error
+	// LatestHash returns the Hash of the newest entry, or "" when the log
+	// is empty.
+	LatestHash(ctx context.Context) (string, error)
+	// FindAll lists the entries matching filter, newest first.
+	FindAll(ctx context.Context, filter AuditFilter, page, pageSize int) ([]*entity.AuditLog, int64, error)
+	// FindAfter returns up to limit entries with an ID above afterID in ID
+	// order, for walking the whole log.
+	FindAfter(ctx context.Context, afterID int64, limit int) ([]*entity.AuditLog, error)
+}
@@ -0,0 +1,20 @@
+// File: internal/domain/repository/course_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+)
+
+type CourseRepository interface {
+	Create(ctx context.Context, course *entity.Course) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Course, error)
+	FindByCode(ctx context.Context, code string) (*entity.Course, error)
+	FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Course, int64, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	Trash[entity.Course]
+}
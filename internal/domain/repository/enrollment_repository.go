@@ -0,0 +1,31 @@
+// File: internal/domain/repository/enrollment_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+)
+
+type EnrollmentRepository interface {
+	Create(ctx context.Context, enrollment *entity.Enrollment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error)
+	FindByStudentCourse(ctx context.Context, studentID, courseID uuid.UUID, academicYear string, semester int) (*entity.Enrollment, error)
+	FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Enrollment, int64, error)
+	FindPage(ctx context.Context, page KeysetPage, spec query.Spec) (Window[entity.Enrollment], error)
+	Count(ctx context.Context, spec query.Spec) (int64, error)
+	// CountActiveByCourse counts the enrollments of a course in one term
+	// that hold a seat: those not dropped whose KRS is in one of the
+	// states krs, or that were promoted from the waitlist. A nil krs
+	// counts them whatever their KRS.
+	CountActiveByCourse(ctx context.Context, courseID uuid.UUID, academicYear string, semester int, krs []string) (int64, error)
+	// FindActiveByStudent returns the enrollments of a student in one term
+	// that were not dropped.
+	FindActiveByStudent(ctx context.Context, studentID uuid.UUID, academicYear string, semester int) ([]*entity.Enrollment, error)
+	Stream(ctx context.Context, spec query.Spec, fn func(*entity.Enrollment) error) error
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	Trash[entity.Enrollment]
+}
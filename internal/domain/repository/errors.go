@@ -0,0 +1,12 @@
+// File: internal/domain/repository/errors.go
+package repository
+
+import "github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+
+// ErrVersionConflict is returned by Update and Delete when the stored row no
+// longer carries the version the caller read, i.e. someone else changed it.
+var ErrVersionConflict = apperror.New(apperror.KindPreconditionFailed, "version conflict")
+
+// ErrNotFound is returned by lookups and writes that match no row. Use cases
+// check for it to report which resource is missing.
+var ErrNotFound = apperror.New(apperror.KindNotFound, "record not found")
@@ -0,0 +1,35 @@
+// File: internal/domain/repository/keyset.go
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Keyset is the position of a row in a list ordered newest first:
+// created_at DESC, with id DESC breaking ties so that the order is total
+// and a page boundary never falls between two equal timestamps.
+type Keyset struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// KeysetPage asks for up to Limit rows following After, or preceding
+// Before when that is set instead. With neither it starts at the newest
+// row. Unlike an offset, a keyset stays put while rows are inserted or
+// deleted ahead of it.
+type KeysetPage struct {
+	After  *Keyset
+	Before *Keyset
+	Limit  int
+}
+
+// Window is one keyset page in list order. HasPrev and HasNext report
+// whether rows exist before the first and after the last item; the side
+// a page was reached from is assumed to continue without checking.
+type Window[T any] struct {
+	Items   []*T
+	HasPrev bool
+	HasNext bool
+}
@@ -0,0 +1,24 @@
+// File: internal/domain/repository/krs_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type KRSRepository interface {
+	Create(ctx context.Context, submission *entity.KRSSubmission) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.KRSSubmission, error)
+	FindByStudentTerm(ctx context.Context, studentID uuid.UUID, academicYear string, semester int) (*entity.KRSSubmission, error)
+	// FindByStudent returns the submissions of a student, latest term first.
+	FindByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.KRSSubmission, error)
+	// FindSubmitted returns the submissions waiting for a decision of an
+	// advisor, the longest waiting first.
+	FindSubmitted(ctx context.Context, advisorID uuid.UUID, page, pageSize int) ([]*entity.KRSSubmission, int64, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error
+	AddReview(ctx context.Context, review *entity.KRSReview) error
+	// FindReviews returns the decisions on a submission, oldest first.
+	FindReviews(ctx context.Context, submissionID uuid.UUID) ([]*entity.KRSReview, error)
+}
@@ -6,12 +6,18 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
 )
 
 type LecturerRepository interface {
 	Create(ctx context.Context, lecturer *entity.Lecturer) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Lecturer, error)
-	FindAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Lecturer, int64, error)
-	Update(ctx context.Context, lecturer *entity.Lecturer) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Lecturer, error)
+	FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Lecturer, int64, error)
+	FindPage(ctx context.Context, page KeysetPage, spec query.Spec) (Window[entity.Lecturer], error)
+	Count(ctx context.Context, spec query.Spec) (int64, error)
+	Stream(ctx context.Context, spec query.Spec, fn func(*entity.Lecturer) error) error
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	Trash[entity.Lecturer]
 }
@@ -0,0 +1,32 @@
+// File: internal/domain/repository/outbox_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+// OutboxRepository stores domain events until the relay has published
+// them.
+type OutboxRepository interface {
+	// Create adds event and assigns its ID, which grows with every event.
+	Create(ctx context.Context, event *entity.OutboxEvent) error
+	// FindDue returns, in ID order, up to limit events that are neither
+	// published nor dead, whose next attempt is due at now, and that are
+	// the oldest such event of their ordering key. A key waiting for a
+	// retry therefore holds back only its own events.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64, at time.Time) error
+	// MarkFailed counts a failed attempt and schedules the next one.
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	// MarkDead counts a failed attempt and gives up on the event.
+	MarkDead(ctx context.Context, id int64, lastError string, at time.Time) error
+	// DeletePublished removes events published before the given time and
+	// returns how many there were.
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+	// TryLock makes the caller the only relay until it calls release. ok
+	// is false when another relay holds the lock.
+	TryLock(ctx context.Context) (release func(), ok bool, err error)
+}
@@ -0,0 +1,35 @@
+// File: internal/domain/repository/search_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// The kinds of record a search covers.
+const (
+	SearchStudent  = "student"
+	SearchLecturer = "lecturer"
+	SearchCourse   = "course"
+)
+
+// SearchHit is one record matching a search: a student (name, NIM, major),
+// lecturer (name, NIP, department) or course (name, code, department).
+type SearchHit struct {
+	Type     string
+	ID       uuid.UUID
+	Title    string
+	Subtitle string
+	Detail   string
+	// Rank orders hits across kinds; higher is better. Its scale depends
+	// on the backend.
+	Rank float64
+}
+
+// SearchRepository finds live records of the given kinds whose name,
+// number or code matches every term, best match first. Terms are folded
+// with textsearch.Terms; a backend may also accept near misses.
+type SearchRepository interface {
+	Search(ctx context.Context, terms []string, kinds []string, limit int) ([]SearchHit, error)
+}
@@ -0,0 +1,27 @@
+// File: internal/domain/repository/seat_change_bus.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SeatChange names a course term whose seats may have changed.
+type SeatChange struct {
+	CourseID     uuid.UUID `json:"course_id"`
+	AcademicYear string    `json:"academic_year"`
+	Semester     int       `json:"semester"`
+}
+
+// SeatChangeBus carries seat changes to every instance sharing the
+// database. Changes may be lost while a listener reconnects, so listeners
+// must catch up by other means.
+type SeatChangeBus interface {
+	// Announce tells the listeners of every instance, this one included,
+	// about change. Call it after the change has committed.
+	Announce(ctx context.Context, change SeatChange) error
+	// Listen calls fn with every change announced until ctx is done or the
+	// connection fails. fn must not block.
+	Listen(ctx context.Context, fn func(SeatChange)) error
+}
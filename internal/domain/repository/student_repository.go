@@ -8,13 +8,18 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
 )
 
 type StudentRepository interface {
 	Create(ctx context.Context, student *entity.Student) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Student, error)
 	FindByNIM(ctx context.Context, nim string) (*entity.Student, error)
-	FindAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Student, int64, error)
-	Update(ctx context.Context, student *entity.Student) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Student, int64, error)
+	FindPage(ctx context.Context, page KeysetPage, spec query.Spec) (Window[entity.Student], error)
+	Count(ctx context.Context, spec query.Spec) (int64, error)
+	Stream(ctx context.Context, spec query.Spec, fn func(*entity.Student) error) error
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	Trash[entity.Student]
 }
@@ -0,0 +1,28 @@
+// File: internal/domain/repository/trash.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trash reaches the soft-deleted rows of an entity, which every other
+// repository method skips. Deleted rows are listed most recently deleted
+// first. Methods given an id report ErrNotFound when no deleted
+// row has it.
+type Trash[T any] interface {
+	FindDeleted(ctx context.Context, page, pageSize int) ([]*T, int64, error)
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*T, error)
+	// Restore clears deleted_at and bumps the version, so ETags taken
+	// before the delete no longer match. It fails with a conflict when a
+	// live row has since taken one of the row's unique values.
+	Restore(ctx context.Context, id uuid.UUID) error
+	// Purge removes the row for good if it was deleted before
+	// deletedBefore.
+	Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error
+	// PurgeDeleted removes every row deleted before deletedBefore and
+	// returns how many there were.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,21 @@
+// File: internal/domain/repository/tx_manager.go
+package repository
+
+import "context"
+
+// TxManager groups repository calls into one atomic unit of work.
+//
+// WithinTx runs fn inside a transaction and hands it a context carrying
+// that transaction: every repository call made with this context joins it,
+// so use cases keep using their usual repositories. The transaction commits
+// when fn returns nil and rolls back when it returns an error or panics.
+// Calling WithinTx with a context that already carries a transaction runs
+// fn in the outer one.
+//
+// Implementations may run fn more than once when the database aborts the
+// transaction for a serialization failure or deadlock, so fn must not have
+// side effects outside the repositories (metrics, messages, ...); do those
+// after WithinTx returns, or record events in the outbox.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,18 @@
+// File: internal/domain/repository/waitlist_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry *entity.WaitlistEntry) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error)
+	// FindWaiting returns the waiting entries of a course in one term in
+	// the order they are promoted.
+	FindWaiting(ctx context.Context, courseID uuid.UUID, academicYear string, semester int) ([]*entity.WaitlistEntry, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error
+}
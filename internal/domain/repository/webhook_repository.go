@@ -0,0 +1,50 @@
+// File: internal/domain/repository/webhook_repository.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+	// FindAll lists the subscriptions, newest first.
+	FindAll(ctx context.Context, page, pageSize int) ([]*entity.WebhookSubscription, int64, error)
+	// FindActive returns every active subscription.
+	FindActive(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error
+	// Delete soft-deletes the subscription; its deliveries are kept.
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+}
+
+// DeliveryFilter narrows a listing of webhook deliveries. Zero fields
+// match every delivery.
+type DeliveryFilter struct {
+	SubscriptionID *uuid.UUID
+	Status         string
+	EventType      string
+}
+
+type WebhookDeliveryRepository interface {
+	// CreateMany adds deliveries, skipping those whose subscription already
+	// has a delivery of the same event, so fanning an event out twice is
+	// harmless.
+	CreateMany(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+	// FindAll lists the deliveries matching filter, newest first.
+	FindAll(ctx context.Context, filter DeliveryFilter, page, pageSize int) ([]*entity.WebhookDelivery, int64, error)
+	// FindDue returns up to limit pending deliveries whose next attempt is
+	// due at now, the longest waiting first.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	Update(ctx context.Context, id uuid.UUID, changes map[string]interface{}) error
+	// DeleteFinished removes the deliveries that succeeded or were
+	// dead-lettered before the given time and returns how many there were.
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+	// TryLock makes the caller the only dispatcher until it calls
+	// release. ok is false when another dispatcher holds the lock.
+	TryLock(ctx context.Context) (release func(), ok bool, err error)
+}
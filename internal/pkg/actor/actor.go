@@ -0,0 +1,32 @@
+// File: internal/pkg/actor/actor.go
+
+// Package actor carries who is behind a request through context.Context,
+// so use cases can attribute their changes in the audit log.
+package actor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// Actor is the caller of a request. UserID and Role are empty until the
+// caller has been authenticated.
+type Actor struct {
+	UserID uuid.UUID
+	Role   string
+	IP     string
+}
+
+func With(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, contextKey{}, a)
+}
+
+// From returns the actor stored in ctx, or the zero Actor for work not
+// started by a request, such as background jobs.
+func From(ctx context.Context) Actor {
+	a, _ := ctx.Value(contextKey{}).(Actor)
+	return a
+}
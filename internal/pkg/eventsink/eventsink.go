@@ -0,0 +1,150 @@
+// File: internal/pkg/eventsink/eventsink.go
+
+// Package eventsink delivers domain events to the systems consuming them.
+// A Sink is where the outbox relay publishes; brokers such as NATS or
+// Kafka plug in by implementing it.
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is the envelope every sink delivers. ID identifies the event
+// across redeliveries; events with the same Key arrive in Sequence order.
+type Message struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Key           string          `json:"key"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	RequestID     string          `json:"request_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Sink publishes messages. Publish returns only once the message is
+// handed over for good; an error means it may or may not have arrived and
+// will be published again.
+type Sink interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+type logSink struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// NewLogSink appends every message as a line of JSON to the file at path,
+// for development. "-" writes to standard output.
+func NewLogSink(path string) (Sink, error) {
+	if path == "-" {
+		return &logSink{w: nopCloser{os.Stdout}}, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	return &logSink{w: f}, nil
+}
+
+func (s *logSink) Publish(ctx context.Context, msg Message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+func (s *logSink) Close() error {
+	return s.w.Close()
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink POSTs every message as JSON to url. Any status other
+// than 2xx counts as a failure.
+func NewWebhookSink(url string, timeout time.Duration) Sink {
+	return &webhookSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *webhookSink) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", msg.ID.String())
+	req.Header.Set("X-Event-Type", msg.Type)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+type teeSink struct {
+	sinks []Sink
+}
+
+// Tee publishes every message to each of sinks in turn. It stops at the
+// first failure, so the message is published again to all of them: the
+// sinks behind a tee must tolerate duplicates, as every sink must.
+func Tee(sinks ...Sink) Sink {
+	return &teeSink{sinks: sinks}
+}
+
+func (s *teeSink) Publish(ctx context.Context, msg Message) error {
+	for _, sink := range s.sinks {
+		if err := sink.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *teeSink) Close() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
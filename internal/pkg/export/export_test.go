@@ -0,0 +1,142 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Format
+		wantErr bool
+	}{
+		{value: "", want: FormatCSV},
+		{value: "csv", want: FormatCSV},
+		{value: "xlsx", want: FormatXLSX},
+		{value: "jsonl", want: FormatJSONL},
+		{value: "CSV", wantErr: true},
+		{value: "pdf", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	at := time.Date(2024, 9, 2, 8, 30, 0, 0, time.UTC)
+	columns := []string{"nim", "name", "gpa", "created_at", "phone"}
+	rows := [][]interface{}{
+		{"2024001", "Ani, \"the first\"", 3.75, at, nil},
+		{"2024002", "Budi\nSantoso", 3, at, "0812"},
+	}
+
+	tests := []struct {
+		format Format
+		read   func(t *testing.T, data []byte) [][]string
+		// time is how the time column reads back: text in CSV, a date
+		// cell in XLSX.
+		time string
+	}{
+		{format: FormatCSV, read: readCSV, time: "2024-09-02T08:30:00Z"},
+		{format: FormatXLSX, read: readXLSX, time: "9/2/24 08:30"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(tt.format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.WriteHeader(columns); err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			want := [][]string{
+				columns,
+				{"2024001", "Ani, \"the first\"", "3.75", tt.time, ""},
+				{"2024002", "Budi\nSantoso", "3", tt.time, "0812"},
+			}
+			got := tt.read(t, buf.Bytes())
+			if len(got) != len(want) {
+				t.Fatalf("read %d rows, want %d: %q", len(got), len(want), got)
+			}
+			for i := range want {
+				if !slices.Equal(got[i], want[i]) {
+					t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatJSONL, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteHeader([]string{"nim", "gpa", "phone"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]interface{}{"2024001", 3.75, nil}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]interface{}{"2024002"}); err == nil {
+		t.Error("WriteRow accepted a row shorter than the header")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"nim":"2024001","gpa":3.75,"phone":null}` + "\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func readCSV(t *testing.T, data []byte) [][]string {
+	t.Helper()
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+// readXLSX pads the rows to the header, as excelize leaves out trailing
+// empty cells.
+func readXLSX(t *testing.T, data []byte) [][]string {
+	t.Helper()
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := file.GetRows(xlsxSheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rows {
+		for len(rows[i]) < len(rows[0]) {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return rows
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func up(context.Context) error { return nil }
+
+func down(context.Context) error { return errors.New("connection refused") }
+
+// hang blocks until its deadline.
+func hang(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCheckerRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]CheckFunc
+		wantStatus Status
+		wantDown   map[string]string
+	}{
+		{
+			name:       "every component up",
+			checks:     map[string]CheckFunc{"database": up, "migrations": up},
+			wantStatus: StatusUp,
+		},
+		{
+			name:       "one component down",
+			checks:     map[string]CheckFunc{"database": up, "migrations": down},
+			wantStatus: StatusDown,
+			wantDown:   map[string]string{"migrations": "connection refused"},
+		},
+		{
+			name:       "a check past its timeout",
+			checks:     map[string]CheckFunc{"database": hang, "connection_pool": up},
+			wantStatus: StatusDown,
+			wantDown:   map[string]string{"database": context.DeadlineExceeded.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(20 * time.Millisecond)
+			for name, fn := range tt.checks {
+				c.Register(name, fn)
+			}
+
+			report := c.Run(context.Background())
+			if report.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", report.Status, tt.wantStatus)
+			}
+			if len(report.Components) != len(tt.checks) {
+				t.Fatalf("components = %v, want one per check", report.Components)
+			}
+			for name, component := range report.Components {
+				wantErr, isDown := tt.wantDown[name]
+				switch {
+				case isDown && (component.Status != StatusDown || component.Error != wantErr):
+					t.Errorf("%s = %+v, want down with %q", name, component, wantErr)
+				case !isDown && (component.Status != StatusUp || component.Error != ""):
+					t.Errorf("%s = %+v, want up", name, component)
+				case component.LatencyMs < 0:
+					t.Errorf("%s latency = %v", name, component.LatencyMs)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckerDraining(t *testing.T) {
+	var calls atomic.Int32
+	c := NewChecker(time.Second)
+	c.Register("database", func(context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+
+	if report := c.Run(context.Background()); report.Status != StatusUp {
+		t.Fatalf("status before draining = %s, want %s", report.Status, StatusUp)
+	}
+	c.SetDraining()
+	report := c.Run(context.Background())
+	if report.Status != StatusDraining || len(report.Components) != 0 {
+		t.Errorf("report while draining = %+v, want %s with no components", report, StatusDraining)
+	}
+	if !c.Draining() || calls.Load() != 1 {
+		t.Errorf("checks ran %d times, want once, before draining", calls.Load())
+	}
+}
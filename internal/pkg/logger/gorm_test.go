@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// captureLogs sends the default logger to a buffer at debug level for the
+// rest of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(New(&buf, "json", "debug"))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+// records decodes the JSON lines in buf.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		out = append(out, record)
+	}
+	return out
+}
+
+func TestGormLoggerRedactsParams(t *testing.T) {
+	buf := captureLogs(t)
+	// DryRun builds every statement and traces it without a server.
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=academic dbname=academic"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 NewGormLogger(time.Second),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const secret = "$2a$10$N9qo8uLOickgx2ZMRZoMye"
+	ctx := WithRequestID(context.Background(), "req-1")
+	db.WithContext(ctx).Create(&entity.User{Username: "ani", Email: "ani@example.com", Password: secret, Role: "admin"})
+	var user entity.User
+	db.WithContext(ctx).Where("password = ?", secret).First(&user)
+
+	logged := records(t, buf)
+	if len(logged) != 2 {
+		t.Fatalf("logged %d records, want 2: %s", len(logged), buf)
+	}
+	if strings.Contains(buf.String(), secret) || strings.Contains(buf.String(), "ani@example.com") {
+		t.Errorf("bound values reached the log: %s", buf)
+	}
+	for _, record := range logged {
+		sql, _ := record["sql"].(string)
+		if record["msg"] != "sql" || !strings.Contains(sql, "$1") {
+			t.Errorf("record = %v, want the statement with its placeholders", record)
+		}
+		if record["request_id"] != "req-1" {
+			t.Errorf("request_id = %v, want req-1", record["request_id"])
+		}
+	}
+}
+
+func TestGormLoggerTrace(t *testing.T) {
+	statement := func() (string, int64) { return `SELECT * FROM "students" WHERE nim = $1`, 1 }
+	tests := []struct {
+		name      string
+		level     gormlogger.LogLevel
+		elapsed   time.Duration
+		err       error
+		wantMsg   string
+		wantLevel string
+	}{
+		{name: "statement", level: gormlogger.Info, elapsed: time.Millisecond, wantMsg: "sql", wantLevel: "DEBUG"},
+		{name: "slow statement", level: gormlogger.Info, elapsed: time.Second, wantMsg: "slow sql", wantLevel: "WARN"},
+		{name: "failed statement", level: gormlogger.Info, elapsed: time.Millisecond, err: errors.New("relation does not exist"), wantMsg: "sql error", wantLevel: "ERROR"},
+		{name: "record not found is not a failure", level: gormlogger.Info, elapsed: time.Millisecond, err: gorm.ErrRecordNotFound, wantMsg: "sql", wantLevel: "DEBUG"},
+		{name: "statements below the level", level: gormlogger.Warn, elapsed: time.Millisecond},
+		{name: "silent", level: gormlogger.Silent, elapsed: time.Second, err: errors.New("relation does not exist")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := captureLogs(t)
+			l := NewGormLogger(100 * time.Millisecond).LogMode(tt.level)
+			l.Trace(context.Background(), time.Now().Add(-tt.elapsed), statement, tt.err)
+
+			logged := records(t, buf)
+			if tt.wantMsg == "" {
+				if len(logged) != 0 {
+					t.Errorf("logged %v, want nothing", logged)
+				}
+				return
+			}
+			if len(logged) != 1 || logged[0]["msg"] != tt.wantMsg || logged[0]["level"] != tt.wantLevel {
+				t.Fatalf("logged %v, want one %s %q", logged, tt.wantLevel, tt.wantMsg)
+			}
+			if logged[0]["sql"] != `SELECT * FROM "students" WHERE nim = $1` {
+				t.Errorf("sql = %v", logged[0]["sql"])
+			}
+		})
+	}
+}
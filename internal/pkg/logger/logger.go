@@ -0,0 +1,75 @@
+// File: internal/pkg/logger/logger.go
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type contextKey struct{}
+
+// New builds the process logger. format is "json" (default) or "text";
+// level is one of debug, info, warn or error.
+func New(w io.Writer, format, level string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: ParseLevel(level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(handler)
+}
+
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// WithRequestID stores the request ID in ctx so that use cases,
+// repositories and the GORM logger can tag their output with it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// FromContext returns the default logger, tagged with the request ID and
+// the active trace and span IDs when ctx carries them.
+func FromContext(ctx context.Context) *slog.Logger {
+	log := slog.Default()
+	if id := RequestID(ctx); id != "" {
+		log = log.With("request_id", id)
+	}
+	if ctx != nil {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			log = log.With("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+		}
+	}
+	return log
+}
+
+// Detach returns a fresh context that keeps the request ID and span of ctx
+// but none of its cancellation, for work that outlives the request.
+func Detach(ctx context.Context) context.Context {
+	detached := WithRequestID(context.Background(), RequestID(ctx))
+	return trace.ContextWithSpanContext(detached, trace.SpanContextFromContext(ctx))
+}
@@ -0,0 +1,188 @@
+// File: internal/pkg/metrics/metrics.go
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "academic"
+
+// registry is private to the service so tests and tools importing the
+// package never collide with prometheus.DefaultRegisterer.
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Login attempts by result.",
+	}, []string{"result"})
+
+	enrollmentsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "enrollments_created_total",
+		Help:      "Enrollments created.",
+	})
+
+	gradesSubmitted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grades_submitted_total",
+		Help:      "Grades recorded on enrollments.",
+	})
+
+	eventsPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_published_total",
+		Help:      "Domain events published from the outbox by event type.",
+	}, []string{"type"})
+
+	eventPublishFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_publish_failures_total",
+		Help:      "Failed attempts to publish a domain event by event type.",
+	}, []string{"type"})
+
+	deadEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_dead_total",
+		Help:      "Domain events given up on after the maximum publish attempts by event type.",
+	}, []string{"type"})
+
+	webhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_delivery_attempts_total",
+		Help:      "Webhook delivery attempts by event type and result (succeeded, failed or dead).",
+	}, []string{"type", "result"})
+
+	seatFeedLagged = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "seat_feed_lagged_total",
+		Help:      "Seat stream clients disconnected for not keeping up.",
+	})
+
+	waitlistPromotions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "waitlist_promotions_total",
+		Help:      "Students enrolled from a waitlist.",
+	})
+
+	krsReviews = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "krs_reviews_total",
+		Help:      "Advisor decisions on submitted KRS, by decision.",
+	}, []string{"decision"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		logins,
+		enrollmentsCreated,
+		gradesSubmitted,
+		eventsPublished,
+		eventPublishFailures,
+		deadEvents,
+		webhookDeliveries,
+		seatFeedLagged,
+		waitlistPromotions,
+		krsReviews,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// RegisterDBStats exposes the connection pool statistics of db as
+// go_sql_* metrics labelled with dbName.
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// RegisterSeatFeed exposes the number of open seat streams, as reported
+// by subscribers.
+func RegisterSeatFeed(subscribers func() int) error {
+	return registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "seat_feed_subscribers",
+		Help:      "Open seat streams.",
+	}, func() float64 { return float64(subscribers()) }))
+}
+
+// ObserveHTTPRequest records one request. route must be the route template
+// (e.g. /api/v1/students/:id), never the raw path, to keep cardinality
+// bounded.
+func ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+func RecordLogin(success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	logins.WithLabelValues(result).Inc()
+}
+
+func RecordEnrollmentCreated() {
+	enrollmentsCreated.Inc()
+}
+
+func RecordGradeSubmitted() {
+	gradesSubmitted.Inc()
+}
+
+func RecordEventPublished(eventType string) {
+	eventsPublished.WithLabelValues(eventType).Inc()
+}
+
+func RecordEventPublishFailed(eventType string) {
+	eventPublishFailures.WithLabelValues(eventType).Inc()
+}
+
+func RecordEventDead(eventType string) {
+	deadEvents.WithLabelValues(eventType).Inc()
+}
+
+// RecordWebhookDelivery counts one delivery attempt. result is succeeded,
+// failed (to be retried) or dead (out of attempts).
+func RecordWebhookDelivery(eventType, result string) {
+	webhookDeliveries.WithLabelValues(eventType, result).Inc()
+}
+
+func RecordSeatFeedLagged() {
+	seatFeedLagged.Inc()
+}
+
+func RecordWaitlistPromotion() {
+	waitlistPromotions.Inc()
+}
+
+func RecordKRSReview(decision string) {
+	krsReviews.WithLabelValues(decision).Inc()
+}
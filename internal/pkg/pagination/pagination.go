@@ -0,0 +1,33 @@
+// File: internal/pkg/pagination/pagination.go
+package pagination
+
+// Limits bounds offset pagination. They come from config so that list
+// endpoints agree on the same defaults.
+type Limits struct {
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+// Normalize turns raw query values into a usable page and page size:
+// missing or invalid values fall back to the first page and the default
+// size, and oversized pages are capped at the maximum.
+func (l Limits) Normalize(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	switch {
+	case pageSize < 1:
+		pageSize = l.DefaultPageSize
+	case pageSize > l.MaxPageSize:
+		pageSize = l.MaxPageSize
+	}
+	return page, pageSize
+}
+
+// TotalPages returns how many pages of pageSize items hold total items.
+func TotalPages(total int64, pageSize int) int {
+	if pageSize < 1 {
+		return 0
+	}
+	return int((total + int64(pageSize) - 1) / int64(pageSize))
+}
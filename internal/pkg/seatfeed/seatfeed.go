@@ -0,0 +1,233 @@
+// File: internal/pkg/seatfeed/seatfeed.go
+
+// Package seatfeed fans seat counts out to the clients watching a course.
+//
+// Every subscriber has a bounded buffer. A subscriber that lets it fill up
+// is dropped rather than holding up the others: its channel is closed and
+// Lagged reports true, after which the client is expected to reconnect and
+// start over from a fresh snapshot.
+package seatfeed
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrTooManySubscribers is returned by Subscribe when the hub is full.
+	ErrTooManySubscribers = errors.New("too many seat feed subscribers")
+
+	// ErrClientLimit is returned by Subscribe when the client already
+	// holds as many subscriptions as it may.
+	ErrClientLimit = errors.New("too many seat feed subscriptions from this client")
+
+	// ErrClosed is returned by Subscribe once the hub is closed.
+	ErrClosed = errors.New("seat feed closed")
+)
+
+// Key identifies the seats of a course in one term.
+type Key struct {
+	CourseID     uuid.UUID
+	AcademicYear string
+	Semester     int
+}
+
+// Seats is the state of a course's seats in one term. Remaining is nil
+// when the course has no capacity limit.
+type Seats struct {
+	CourseID     uuid.UUID `json:"course_id"`
+	AcademicYear string    `json:"academic_year"`
+	Semester     int       `json:"semester"`
+	Capacity     int       `json:"capacity"`
+	Enrolled     int64     `json:"enrolled"`
+	Remaining    *int64    `json:"remaining"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (s Seats) Key() Key {
+	return Key{CourseID: s.CourseID, AcademicYear: s.AcademicYear, Semester: s.Semester}
+}
+
+// same reports whether a and b show the same seats, whenever computed.
+func same(a, b Seats) bool {
+	a.UpdatedAt, b.UpdatedAt = time.Time{}, time.Time{}
+	if (a.Remaining == nil) != (b.Remaining == nil) || a.Remaining != nil && *a.Remaining != *b.Remaining {
+		return false
+	}
+	a.Remaining, b.Remaining = nil, nil
+	return a == b
+}
+
+type Subscription struct {
+	hub    *Hub
+	key    Key
+	client string
+	c      chan Seats
+	lagged bool
+}
+
+// Updates delivers the seats every time they change. It is closed when
+// the subscription is closed or dropped for lagging.
+func (s *Subscription) Updates() <-chan Seats {
+	return s.c
+}
+
+// Lagged reports whether the hub dropped the subscription because its
+// buffer was full. It is only meaningful once Updates is closed.
+func (s *Subscription) Lagged() bool {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.lagged
+}
+
+func (s *Subscription) Close() {
+	s.hub.remove(s, false)
+}
+
+// Hub keeps the subscriptions of one instance.
+type Hub struct {
+	buffer         int
+	maxSubscribers int
+	maxPerClient   int
+
+	mu        sync.Mutex
+	subs      map[Key]map[*Subscription]struct{}
+	last      map[Key]Seats
+	count     int
+	perClient map[string]int
+	closed    bool
+}
+
+// NewHub returns a hub giving each subscriber a buffer of the given size
+// and holding up to maxSubscribers at once, and up to maxPerClient of them
+// for one client unless it is 0.
+func NewHub(buffer, maxSubscribers, maxPerClient int) *Hub {
+	return &Hub{
+		buffer:         buffer,
+		maxSubscribers: maxSubscribers,
+		maxPerClient:   maxPerClient,
+		subs:           make(map[Key]map[*Subscription]struct{}),
+		last:           make(map[Key]Seats),
+		perClient:      make(map[string]int),
+	}
+}
+
+// Subscribe watches key on behalf of client, which identifies whoever
+// holds the subscription, e.g. its IP address.
+func (h *Hub) Subscribe(key Key, client string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+	if h.maxPerClient > 0 && h.perClient[client] >= h.maxPerClient {
+		return nil, ErrClientLimit
+	}
+	if h.count >= h.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+	s := &Subscription{hub: h, key: key, client: client, c: make(chan Seats, h.buffer)}
+	if h.subs[key] == nil {
+		h.subs[key] = make(map[*Subscription]struct{})
+	}
+	h.subs[key][s] = struct{}{}
+	h.count++
+	h.perClient[client]++
+	return s, nil
+}
+
+// Publish sends seats to the subscribers of its course and term, unless
+// they were the last seats sent. Subscribers with a full buffer are
+// dropped; Publish never blocks on them.
+func (h *Hub) Publish(seats Seats) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := seats.Key()
+	subs := h.subs[key]
+	if len(subs) == 0 {
+		return
+	}
+	if last, ok := h.last[key]; ok && same(last, seats) {
+		return
+	}
+	h.last[key] = seats
+	for s := range subs {
+		select {
+		case s.c <- seats:
+		default:
+			h.removeLocked(s, true)
+		}
+	}
+}
+
+// Keys returns the courses and terms somebody is watching.
+func (h *Hub) Keys() []Key {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]Key, 0, len(h.subs))
+	for key := range h.subs {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Watched reports whether anybody is watching key.
+func (h *Hub) Watched(key Key) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[key]) > 0
+}
+
+// Subscribers returns how many subscriptions are open.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Close ends every subscription and refuses new ones, so open streams
+// finish and a graceful shutdown need not wait for their clients.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, subs := range h.subs {
+		for s := range subs {
+			h.removeLocked(s, false)
+		}
+	}
+}
+
+func (h *Hub) remove(s *Subscription, lagged bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(s, lagged)
+}
+
+func (h *Hub) removeLocked(s *Subscription, lagged bool) {
+	subs, ok := h.subs[s.key]
+	if !ok {
+		return
+	}
+	if _, ok := subs[s]; !ok {
+		return
+	}
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(h.subs, s.key)
+		delete(h.last, s.key)
+	}
+	h.count--
+	if h.perClient[s.client]--; h.perClient[s.client] == 0 {
+		delete(h.perClient, s.client)
+	}
+	s.lagged = lagged
+	close(s.c)
+}
@@ -0,0 +1,119 @@
+package seatfeed
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var algorithms = Key{CourseID: uuid.MustParse("0b8f4a52-6c1d-4e3f-9a7b-2c5d8e1f3a6b"), AcademicYear: "2024/2025", Semester: 1}
+
+func seats(enrolled int64) Seats {
+	return Seats{CourseID: algorithms.CourseID, AcademicYear: algorithms.AcademicYear, Semester: algorithms.Semester, Capacity: 40, Enrolled: enrolled}
+}
+
+func TestHubDropsSlowSubscribers(t *testing.T) {
+	h := NewHub(2, 10, 0)
+	slow, err := h.Subscribe(algorithms, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fast, err := h.Subscribe(algorithms, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fast reads every update; slow reads none and overflows its buffer
+	// on the third.
+	for enrolled := int64(1); enrolled <= 3; enrolled++ {
+		h.Publish(seats(enrolled))
+		if got := <-fast.Updates(); got.Enrolled != enrolled {
+			t.Fatalf("fast subscriber got %d enrolled, want %d", got.Enrolled, enrolled)
+		}
+	}
+
+	var buffered []int64
+	for s := range slow.Updates() {
+		buffered = append(buffered, s.Enrolled)
+	}
+	if len(buffered) != 2 || !slow.Lagged() {
+		t.Errorf("slow subscriber got %v and lagged = %v, want the two buffered updates and dropped", buffered, slow.Lagged())
+	}
+	if fast.Lagged() || h.Subscribers() != 1 {
+		t.Errorf("fast lagged = %v with %d subscribers, want it kept as the only one", fast.Lagged(), h.Subscribers())
+	}
+}
+
+func TestHubSkipsUnchangedSeats(t *testing.T) {
+	h := NewHub(4, 10, 0)
+	sub, err := h.Subscribe(algorithms, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Publish(seats(1))
+	h.Publish(seats(1))
+	h.Publish(seats(2))
+	sub.Close()
+
+	var got []int64
+	for s := range sub.Updates() {
+		got = append(got, s.Enrolled)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("updates = %v, want [1 2]", got)
+	}
+	if sub.Lagged() {
+		t.Error("a closed subscription reports lagging")
+	}
+}
+
+func TestHubSubscriberCap(t *testing.T) {
+	h := NewHub(1, 2, 0)
+	first, err := h.Subscribe(algorithms, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := Key{CourseID: uuid.New(), AcademicYear: "2024/2025", Semester: 1}
+	if _, err := h.Subscribe(other, "192.0.2.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h.Subscribe(algorithms, "192.0.2.1"); !errors.Is(err, ErrTooManySubscribers) {
+		t.Fatalf("Subscribe() past the cap = %v, want %v", err, ErrTooManySubscribers)
+	}
+	first.Close()
+	if _, err := h.Subscribe(algorithms, "192.0.2.1"); err != nil {
+		t.Errorf("Subscribe() after one closed = %v", err)
+	}
+
+	h.Close()
+	if h.Subscribers() != 0 {
+		t.Errorf("subscribers after Close = %d, want 0", h.Subscribers())
+	}
+	if _, err := h.Subscribe(algorithms, "192.0.2.1"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Subscribe() after Close = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestHubClientCap(t *testing.T) {
+	h := NewHub(1, 10, 2)
+	first, err := h.Subscribe(algorithms, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Subscribe(algorithms, "192.0.2.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h.Subscribe(algorithms, "192.0.2.1"); !errors.Is(err, ErrClientLimit) {
+		t.Fatalf("Subscribe() past the client cap = %v, want %v", err, ErrClientLimit)
+	}
+	if _, err := h.Subscribe(algorithms, "192.0.2.2"); err != nil {
+		t.Errorf("Subscribe() from another client = %v", err)
+	}
+	first.Close()
+	if _, err := h.Subscribe(algorithms, "192.0.2.1"); err != nil {
+		t.Errorf("Subscribe() after one closed = %v", err)
+	}
+}
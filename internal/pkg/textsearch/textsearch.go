@@ -0,0 +1,173 @@
+// File: internal/pkg/textsearch/textsearch.go
+
+// Package textsearch holds the text rules of the search endpoint that do
+// not depend on the database: folding spelling variants, splitting a query
+// into terms, fuzzy word matching and highlighting.
+//
+// Fold must stay in step with the search_fold SQL function of migration
+// 000008, which Postgres indexes and SQLite registers from this package.
+package textsearch
+
+import (
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// variants rewrites the old Indonesian spellings still common in names to
+// their current form (Soekarno and Sukarno, Djoko and Joko), and ch to kh
+// (Chairul and Khairul). The rules apply in order, like the nested
+// replace() calls of search_fold.
+var variants = []struct{ from, to string }{
+	{"oe", "u"},
+	{"dj", "j"},
+	{"tj", "c"},
+	{"sj", "sy"},
+	{"nj", "ny"},
+	{"ch", "kh"},
+}
+
+// Fold lowercases s, rewrites spelling variants and collapses repeated
+// letters, so that Muhammad and Muhamad, or Soekarno and Sukarno, fold
+// to the same text.
+func Fold(s string) string {
+	s = strings.ToLower(s)
+	for _, v := range variants {
+		s = strings.ReplaceAll(s, v.from, v.to)
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	var last rune = -1
+	for _, r := range s {
+		if r != last || !unicode.IsLetter(r) {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
+
+// Terms folds q and splits it into distinct words of letters and digits.
+// Punctuation is dropped, so terms are safe to build a tsquery from.
+func Terms(q string) []string {
+	var terms []string
+	for _, word := range words(Fold(q)) {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// fuzzyThreshold is how similar a misspelt term must be to a word to count
+// as a match. It is the default pg_trgm.word_similarity_threshold, which
+// the <% operator applies in Postgres.
+const fuzzyThreshold = 0.6
+
+// MatchWord scores how well term, already folded, matches word: 1 for the
+// same word, 0.8 when the word starts with the term, their WordSimilarity
+// when it reaches fuzzyThreshold, and 0 otherwise. Terms without letters
+// are numbers or codes and must match exactly or as a prefix.
+func MatchWord(term, word string) float64 {
+	word = Fold(word)
+	switch {
+	case word == term:
+		return 1
+	case strings.HasPrefix(word, term):
+		return 0.8
+	}
+	if !strings.ContainsFunc(term, unicode.IsLetter) {
+		return 0
+	}
+	if s := WordSimilarity(term, word); s >= fuzzyThreshold {
+		return s
+	}
+	return 0
+}
+
+// Score rates text against terms as the mean of each term's best word
+// match. It is 0 unless every term matches some word.
+func Score(terms []string, text string) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+	textWords := words(text)
+	var total float64
+	for _, term := range terms {
+		var best float64
+		for _, word := range textWords {
+			best = max(best, MatchWord(term, word))
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total / float64(len(terms))
+}
+
+// WordSimilarity is the share of the trigrams of term found in word, the
+// measure pg_trgm's word_similarity applies to a single word. Words are
+// padded by two spaces in front and one behind, as pg_trgm pads them.
+func WordSimilarity(term, word string) float64 {
+	x, y := trigrams(term), trigrams(word)
+	shared := 0
+	for t := range x {
+		if _, ok := y[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(x))
+}
+
+func trigrams(word string) map[string]struct{} {
+	runes := []rune("  " + word + " ")
+	set := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = struct{}{}
+	}
+	return set
+}
+
+// Highlight returns text, HTML-escaped, with the words that match a term
+// wrapped in <mark> tags.
+func Highlight(text string, terms []string) string {
+	var b strings.Builder
+	start := -1
+	flush := func(end int) {
+		word := text[start:end]
+		escaped := html.EscapeString(word)
+		for _, term := range terms {
+			if MatchWord(term, word) > 0 {
+				escaped = "<mark>" + escaped + "</mark>"
+				break
+			}
+		}
+		b.WriteString(escaped)
+		start = -1
+	}
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			flush(i)
+		}
+		if !inWord {
+			b.WriteString(html.EscapeString(string(r)))
+		}
+	}
+	if start >= 0 {
+		flush(len(text))
+	}
+	return b.String()
+}
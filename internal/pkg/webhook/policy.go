@@ -0,0 +1,98 @@
+// File: internal/pkg/webhook/policy.go
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Policy decides which hosts webhooks may be sent to. Subscriptions are
+// registered by admins but requested by the service, so a URL pointing at
+// loopback, private, link-local or unspecified addresses would let them
+// reach internal services and the cloud metadata endpoint. Hosts on the
+// allowlist are exempt, for receivers that do live inside the network.
+type Policy struct {
+	allowed map[string]bool
+	lookup  func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+func NewPolicy(allowedHosts []string) *Policy {
+	allowed := make(map[string]bool, len(allowedHosts))
+	for _, host := range allowedHosts {
+		allowed[strings.ToLower(strings.TrimSpace(host))] = true
+	}
+	return &Policy{allowed: allowed, lookup: net.DefaultResolver.LookupIPAddr}
+}
+
+// CheckHost reports an error when host is, or resolves to, an internal
+// address and is not allowed. A host that does not resolve passes: the
+// sender checks the address again when it connects.
+func (p *Policy) CheckHost(ctx context.Context, host string) error {
+	if p.allows(host) {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return checkIP(ip)
+	}
+	addrs, err := p.lookup(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := checkIP(addr.IP); err != nil {
+			return fmt.Errorf("%s resolves to %w", host, err)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) allows(host string) bool {
+	return p.allowed[strings.ToLower(strings.Trim(host, "[]"))]
+}
+
+// dialContext dials addr, refusing internal addresses unless its host is
+// allowed. The address is checked after resolution, so a name that
+// resolves differently than at registration is caught too.
+func (p *Policy) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if p.allows(host) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		checked := *dialer
+		checked.Control = func(_, address string, _ syscall.RawConn) error {
+			ip, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			return checkIP(net.ParseIP(ip))
+		}
+		return checked.DialContext(ctx, network, addr)
+	}
+}
+
+func checkIP(ip net.IP) error {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("%s, an internal address", ip)
+	}
+	return nil
+}
+
+// transport is a fresh http.Transport that dials through the policy. It
+// ignores proxy settings: the address a proxy connects to cannot be
+// checked here.
+func (p *Policy) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = p.dialContext(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+	return transport
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPolicyCheckHost(t *testing.T) {
+	// resolved stands in for DNS.
+	resolved := map[string]string{
+		"hooks.example.com":   "93.184.216.34",
+		"intranet.example":    "10.1.2.3",
+		"receiver.internal":   "10.9.9.9",
+		"rebound.example.com": "127.0.0.1",
+	}
+	tests := []struct {
+		host    string
+		wantErr bool
+	}{
+		{host: "93.184.216.34"},
+		{host: "hooks.example.com"},
+		{host: "127.0.0.1", wantErr: true},
+		{host: "::1", wantErr: true},
+		{host: "169.254.169.254", wantErr: true},
+		{host: "10.0.0.5", wantErr: true},
+		{host: "192.168.1.10", wantErr: true},
+		{host: "fd00::1", wantErr: true},
+		{host: "0.0.0.0", wantErr: true},
+		{host: "::ffff:127.0.0.1", wantErr: true},
+		{host: "intranet.example", wantErr: true},
+		{host: "rebound.example.com", wantErr: true},
+		{host: "receiver.internal"},
+		{host: "Receiver.Internal"},
+		{host: "unresolvable.example"},
+	}
+	policy := NewPolicy([]string{"receiver.internal"})
+	policy.lookup = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		ip, ok := resolved[host]
+		if !ok {
+			return nil, errors.New("no such host")
+		}
+		return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := policy.CheckHost(context.Background(), tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckHost(%q) = %v, want error %v", tt.host, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSenderRefusesInternalAddresses(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		allowed []string
+		wantErr bool
+	}{
+		{name: "loopback", wantErr: true},
+		{name: "allowed host", allowed: []string{"127.0.0.1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			sender := NewSender(time.Second, NewPolicy(tt.allowed))
+			defer sender.Close()
+
+			status, err := sender.Send(context.Background(), Request{URL: server.URL, Body: []byte(`{}`)})
+			if tt.wantErr {
+				if status != 0 || err == nil || requests.Load() != 0 {
+					t.Errorf("Send() = %d, %v with %d requests, want no connection", status, err, requests.Load())
+				}
+				return
+			}
+			if err != nil || requests.Load() != 1 {
+				t.Errorf("Send() = %d, %v with %d requests, want one delivery", status, err, requests.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1725265800, 0)
+	body := []byte(`{"type":"StudentStatusChanged"}`)
+
+	tests := []struct {
+		name   string
+		secret string
+		at     time.Time
+		body   []byte
+		// want was computed with:
+		//   printf '<t>.<body>' | openssl dgst -sha256 -hmac <secret>
+		want string
+	}{
+		{
+			name:   "reference",
+			secret: "whsec_test",
+			at:     at,
+			body:   body,
+			want:   "t=1725265800,v1=f3dd11c513d61dd22ef52606b96efba2472acb2d59ef68ab7fa9ed6c6a8bd8a0",
+		},
+		{
+			name:   "sub-second time is truncated",
+			secret: "whsec_test",
+			at:     at.Add(900 * time.Millisecond),
+			body:   body,
+			want:   "t=1725265800,v1=f3dd11c513d61dd22ef52606b96efba2472acb2d59ef68ab7fa9ed6c6a8bd8a0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.at, tt.body); got != tt.want {
+				t.Errorf("Sign() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	reference := Sign("whsec_test", at, body)
+	for name, got := range map[string]string{
+		"secret": Sign("whsec_other", at, body),
+		"time":   Sign("whsec_test", at.Add(time.Second), body),
+		"body":   Sign("whsec_test", at, []byte(`{"type":"KRSSubmitted"}`)),
+	} {
+		if got == reference {
+			t.Errorf("changing the %s does not change the signature", name)
+		}
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(a, "whsec_") || len(a) != len("whsec_")+64 || a == b {
+		t.Errorf("NewSecret() = %q and %q, want distinct whsec_ secrets of 32 bytes", a, b)
+	}
+}
+
+func TestSenderSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		response   string
+		wantStatus int
+		wantErr    string
+	}{
+		{name: "accepted", status: http.StatusNoContent, wantStatus: http.StatusNoContent},
+		{name: "rejected with a body", status: http.StatusBadRequest, response: "  unknown event\n", wantStatus: http.StatusBadRequest, wantErr: "endpoint responded 400 Bad Request: unknown event"},
+		{name: "rejected without a body", status: http.StatusBadGateway, wantStatus: http.StatusBadGateway, wantErr: "endpoint responded 502 Bad Gateway"},
+		{name: "long error body is cut", status: http.StatusInternalServerError, response: strings.Repeat("x", 2000), wantStatus: http.StatusInternalServerError, wantErr: strings.Repeat("x", maxErrorBody)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(`{"id":"e1"}`)
+			var got *http.Request
+			var gotBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				gotBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			sender := NewSender(time.Second, NewPolicy([]string{"127.0.0.1"}))
+			defer sender.Close()
+			before := time.Now()
+			status, err := sender.Send(context.Background(), Request{
+				URL:        server.URL,
+				Secret:     "whsec_test",
+				DeliveryID: "d1",
+				EventID:    "e1",
+				EventType:  "StudentStatusChanged",
+				Body:       body,
+			})
+
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Send() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.HasSuffix(err.Error(), tt.wantErr)):
+				t.Errorf("Send() = %v, want an error ending in %q", err, tt.wantErr)
+			}
+			if tt.wantErr != "" && len(err.Error()) > maxErrorBody+100 {
+				t.Errorf("error quotes %d bytes of the response", len(err.Error()))
+			}
+
+			if got.Method != http.MethodPost || string(gotBody) != string(body) {
+				t.Errorf("request = %s %q, want POST %q", got.Method, gotBody, body)
+			}
+			for header, want := range map[string]string{
+				"Content-Type": "application/json",
+				"X-Webhook-ID": "d1",
+				"X-Event-ID":   "e1",
+				"X-Event-Type": "StudentStatusChanged",
+			} {
+				if v := got.Header.Get(header); v != want {
+					t.Errorf("%s = %q, want %q", header, v, want)
+				}
+			}
+			// The signature is over the time the request was sent.
+			signature := got.Header.Get(SignatureHeader)
+			if signature != Sign("whsec_test", before, body) && signature != Sign("whsec_test", time.Now(), body) {
+				t.Errorf("%s = %q does not sign the body", SignatureHeader, signature)
+			}
+		})
+	}
+}
+
+func TestSenderUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	status, err := NewSender(time.Second, NewPolicy([]string{"127.0.0.1"})).Send(context.Background(), Request{URL: url, Body: []byte(`{}`)})
+	if status != 0 || err == nil {
+		t.Errorf("Send() = %d, %v, want 0 and an error", status, err)
+	}
+}
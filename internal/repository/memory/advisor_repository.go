@@ -0,0 +1,106 @@
+// File: internal/repository/memory/advisor_repository.go
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// advisorRepository keeps the assignments in a slice of its own, since a
+// table expects soft deletes.
+type advisorRepository struct {
+	mu          sync.RWMutex
+	assignments []*entity.AdvisorAssignment
+}
+
+func NewAdvisorRepository() repository.AdvisorRepository {
+	return &advisorRepository{}
+}
+
+func (r *advisorRepository) Create(ctx context.Context, assignment *entity.AdvisorAssignment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The partial unique index of the migrations: one current advisor per
+	// student.
+	if assignment.EndedAt == nil && slices.ContainsFunc(r.assignments, func(a *entity.AdvisorAssignment) bool {
+		return a.EndedAt == nil && a.StudentID == assignment.StudentID
+	}) {
+		return &apperror.Error{
+			Kind:    apperror.KindConflict,
+			Message: "student_id already exists",
+			Field:   "student_id",
+		}
+	}
+
+	if err := assignment.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if assignment.CreatedAt.IsZero() {
+		assignment.CreatedAt = now()
+	}
+	stored := *assignment
+	r.assignments = append(r.assignments, &stored)
+	return nil
+}
+
+func (r *advisorRepository) FindCurrent(ctx context.Context, studentID uuid.UUID) (*entity.AdvisorAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, a := range r.assignments {
+		if a.StudentID == studentID && a.EndedAt == nil {
+			found := *a
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *advisorRepository) FindByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.AdvisorAssignment, error) {
+	r.mu.RLock()
+	var assignments []*entity.AdvisorAssignment
+	for _, a := range r.assignments {
+		if a.StudentID == studentID {
+			found := *a
+			assignments = append(assignments, &found)
+		}
+	}
+	r.mu.RUnlock()
+
+	// ORDER BY started_at DESC, created_at DESC, id DESC
+	slices.SortStableFunc(assignments, func(a, b *entity.AdvisorAssignment) int {
+		if c := b.StartedAt.Compare(a.StartedAt); c != 0 {
+			return c
+		}
+		return compareKeysets(repository.Keyset{CreatedAt: b.CreatedAt, ID: b.ID}, repository.Keyset{CreatedAt: a.CreatedAt, ID: a.ID})
+	})
+	return assignments, nil
+}
+
+func (r *advisorRepository) End(ctx context.Context, studentID uuid.UUID, endedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, a := range r.assignments {
+		if a.StudentID == studentID && a.EndedAt == nil {
+			ended := *a
+			endedAt := endedAt.Truncate(time.Microsecond)
+			ended.EndedAt = &endedAt
+			r.assignments[i] = &ended
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (r *advisorRepository) snapshot() func() {
+	return snapshotRows(&r.mu, &r.assignments)
+}
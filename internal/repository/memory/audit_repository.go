@@ -0,0 +1,94 @@
+// File: internal/repository/memory/audit_repository.go
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// auditRepository keeps the log in ID order. It does not use table: audit
+// entries have sequential IDs and are never updated.
+type auditRepository struct {
+	mu      sync.RWMutex
+	entries []*entity.AuditLog
+}
+
+func NewAuditRepository() repository.AuditRepository {
+	return &auditRepository{}
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *entity.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = int64(len(r.entries)) + 1
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = now()
+	}
+	stored := *entry
+	r.entries = append(r.entries, &stored)
+	return nil
+}
+
+func (r *auditRepository) LatestHash(ctx context.Context) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.entries) == 0 {
+		return "", nil
+	}
+	return r.entries[len(r.entries)-1].Hash, nil
+}
+
+func (r *auditRepository) FindAll(ctx context.Context, filter repository.AuditFilter, pageNum, pageSize int) ([]*entity.AuditLog, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*entity.AuditLog
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if e := r.entries[i]; matchesAudit(e, filter) {
+			found := *e
+			matched = append(matched, &found)
+		}
+	}
+	entries, total := page(matched, pageNum, pageSize)
+	return entries, total, nil
+}
+
+func (r *auditRepository) FindAfter(ctx context.Context, afterID int64, limit int) ([]*entity.AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// IDs are positions in entries, starting at 1.
+	start := min(max(afterID, 0), int64(len(r.entries)))
+	end := min(start+int64(limit), int64(len(r.entries)))
+	entries := make([]*entity.AuditLog, 0, end-start)
+	for _, e := range r.entries[start:end] {
+		found := *e
+		entries = append(entries, &found)
+	}
+	return entries, nil
+}
+
+func matchesAudit(e *entity.AuditLog, filter repository.AuditFilter) bool {
+	switch {
+	case filter.EntityType != "" && e.EntityType != filter.EntityType:
+		return false
+	case filter.EntityID != nil && e.EntityID != *filter.EntityID:
+		return false
+	case filter.ActorID != nil && (e.ActorID == nil || *e.ActorID != *filter.ActorID):
+		return false
+	case !filter.From.IsZero() && e.CreatedAt.Before(filter.From):
+		return false
+	case !filter.To.IsZero() && !e.CreatedAt.Before(filter.To):
+		return false
+	}
+	return true
+}
+
+func (r *auditRepository) snapshot() func() {
+	return snapshotRows(&r.mu, &r.entries)
+}
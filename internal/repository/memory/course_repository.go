@@ -0,0 +1,70 @@
+// File: internal/repository/memory/course_repository.go
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type courseRepository struct {
+	courses *table[entity.Course]
+}
+
+func NewCourseRepository() repository.CourseRepository {
+	return &courseRepository{courses: newTable[entity.Course]([]string{"code"})}
+}
+
+func (r *courseRepository) Create(ctx context.Context, course *entity.Course) error {
+	return r.courses.insert(course)
+}
+
+func (r *courseRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Course, error) {
+	return r.courses.findByID(id)
+}
+
+func (r *courseRepository) FindByCode(ctx context.Context, code string) (*entity.Course, error) {
+	return r.courses.first(func(c *entity.Course) bool { return c.Code == code })
+}
+
+func (r *courseRepository) FindAll(ctx context.Context, pageNum, pageSize int, spec query.Spec) ([]*entity.Course, int64, error) {
+	courses, total := page(r.courses.find(query.Courses, spec), pageNum, pageSize)
+	return courses, total, nil
+}
+
+func (r *courseRepository) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	return r.courses.update(id, version, changes)
+}
+
+func (r *courseRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return r.courses.softDelete(id, version)
+}
+
+func (r *courseRepository) FindDeleted(ctx context.Context, pageNum, pageSize int) ([]*entity.Course, int64, error) {
+	courses, total := r.courses.findDeleted(pageNum, pageSize)
+	return courses, total, nil
+}
+
+func (r *courseRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Course, error) {
+	return r.courses.findDeletedByID(id)
+}
+
+func (r *courseRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.courses.restore(id)
+}
+
+func (r *courseRepository) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	return r.courses.purgeByID(id, deletedBefore)
+}
+
+func (r *courseRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return r.courses.purge(everyRow, deletedBefore), nil
+}
+
+func (r *courseRepository) snapshot() func() {
+	return r.courses.snapshot()
+}
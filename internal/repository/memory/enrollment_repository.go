@@ -0,0 +1,140 @@
+// File: internal/repository/memory/enrollment_repository.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type enrollmentRepository struct {
+	enrollments *table[entity.Enrollment]
+	submissions repository.KRSRepository
+	waitlists   *waitlistRepository
+}
+
+// NewEnrollmentRepository keeps enrollments without checking that the
+// referenced student and course exist; there are no foreign keys here.
+// Seat counts look up the KRS of each enrollment in submissions and its
+// promotion in waitlists, which must be made by this package.
+func NewEnrollmentRepository(submissions repository.KRSRepository, waitlists repository.WaitlistRepository) repository.EnrollmentRepository {
+	return &enrollmentRepository{
+		enrollments: newTable[entity.Enrollment]([]string{"student_id", "course_id", "academic_year", "semester"}),
+		submissions: submissions,
+		waitlists:   waitlists.(*waitlistRepository),
+	}
+}
+
+func (r *enrollmentRepository) Create(ctx context.Context, enrollment *entity.Enrollment) error {
+	return r.enrollments.insert(enrollment)
+}
+
+func (r *enrollmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error) {
+	return r.enrollments.findByID(id)
+}
+
+func (r *enrollmentRepository) FindByStudentCourse(ctx context.Context, studentID, courseID uuid.UUID, academicYear string, semester int) (*entity.Enrollment, error) {
+	return r.enrollments.first(func(e *entity.Enrollment) bool {
+		return e.StudentID == studentID && e.CourseID == courseID &&
+			e.AcademicYear == academicYear && e.Semester == semester
+	})
+}
+
+func (r *enrollmentRepository) FindAll(ctx context.Context, pageNum, pageSize int, spec query.Spec) ([]*entity.Enrollment, int64, error) {
+	enrollments, total := page(r.enrollments.find(query.Enrollments, spec), pageNum, pageSize)
+	return enrollments, total, nil
+}
+
+func (r *enrollmentRepository) FindPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Enrollment], error) {
+	return r.enrollments.window(r.enrollments.findNewest(query.Enrollments, spec), page), nil
+}
+
+func (r *enrollmentRepository) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	return int64(len(r.enrollments.find(query.Enrollments, spec))), nil
+}
+
+func (r *enrollmentRepository) CountActiveByCourse(ctx context.Context, courseID uuid.UUID, academicYear string, semester int, krs []string) (int64, error) {
+	active := r.enrollments.selectRows(func(e *entity.Enrollment) bool {
+		return e.CourseID == courseID && e.AcademicYear == academicYear &&
+			e.Semester == semester && e.Status != "dropped"
+	}, func(a, b *entity.Enrollment) int { return 0 })
+	if krs == nil {
+		return int64(len(active)), nil
+	}
+
+	var taken int64
+	for _, e := range active {
+		if r.waitlists.promoted(e.ID) {
+			taken++
+			continue
+		}
+		submission, err := r.submissions.FindByStudentTerm(ctx, e.StudentID, e.AcademicYear, e.Semester)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return 0, err
+		}
+		if slices.Contains(krs, submission.Status) {
+			taken++
+		}
+	}
+	return taken, nil
+}
+
+func (r *enrollmentRepository) FindActiveByStudent(ctx context.Context, studentID uuid.UUID, academicYear string, semester int) ([]*entity.Enrollment, error) {
+	return r.enrollments.selectRows(func(e *entity.Enrollment) bool {
+		return e.StudentID == studentID && e.AcademicYear == academicYear &&
+			e.Semester == semester && e.Status != "dropped"
+	}, func(a, b *entity.Enrollment) int {
+		return compareKeysets(r.enrollments.keyset(a), r.enrollments.keyset(b))
+	}), nil
+}
+
+func (r *enrollmentRepository) Stream(ctx context.Context, spec query.Spec, fn func(*entity.Enrollment) error) error {
+	for _, enrollment := range r.enrollments.find(query.Enrollments, spec) {
+		if err := fn(enrollment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *enrollmentRepository) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	return r.enrollments.update(id, version, changes)
+}
+
+func (r *enrollmentRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return r.enrollments.softDelete(id, version)
+}
+
+func (r *enrollmentRepository) FindDeleted(ctx context.Context, pageNum, pageSize int) ([]*entity.Enrollment, int64, error) {
+	enrollments, total := r.enrollments.findDeleted(pageNum, pageSize)
+	return enrollments, total, nil
+}
+
+func (r *enrollmentRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error) {
+	return r.enrollments.findDeletedByID(id)
+}
+
+func (r *enrollmentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.enrollments.restore(id)
+}
+
+func (r *enrollmentRepository) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	return r.enrollments.purgeByID(id, deletedBefore)
+}
+
+func (r *enrollmentRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return r.enrollments.purge(everyRow, deletedBefore), nil
+}
+
+func (r *enrollmentRepository) snapshot() func() {
+	return r.enrollments.snapshot()
+}
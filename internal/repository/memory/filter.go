@@ -0,0 +1,52 @@
+// File: internal/repository/memory/filter.go
+package memory
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// contains mimics the repositories' `column ILIKE '%' || term || '%'`.
+// The term is a LIKE pattern too, so % and _ in it act as wildcards just
+// as they do in Postgres.
+func contains(value, term string) bool {
+	return ilike(value, "%"+term+"%")
+}
+
+// ilike reports whether value matches the SQL LIKE pattern, ignoring
+// case. % matches any run of characters, _ exactly one, and a backslash
+// escapes the character after it.
+func ilike(value, pattern string) bool {
+	var expr strings.Builder
+	expr.WriteString(`(?is)^`)
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(`.*`)
+		case r == '_':
+			expr.WriteString(`.`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString(`$`)
+	return regexp.MustCompile(expr.String()).MatchString(value)
+}
+
+// compareKeysets orders keysets ascending by created_at, then by id
+// compared byte by byte as Postgres compares uuid values.
+func compareKeysets(a, b repository.Keyset) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
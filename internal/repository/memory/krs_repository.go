@@ -0,0 +1,204 @@
+// File: internal/repository/memory/krs_repository.go
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// krsRepository keeps submissions and reviews in slices of their own,
+// since a table expects soft deletes; the table resolves the columns of
+// Update.
+type krsRepository struct {
+	columns *table[entity.KRSSubmission]
+
+	mu          sync.RWMutex
+	submissions []*entity.KRSSubmission
+	reviews     []*entity.KRSReview
+}
+
+func NewKRSRepository() repository.KRSRepository {
+	return &krsRepository{columns: newTable[entity.KRSSubmission]()}
+}
+
+func (r *krsRepository) Create(ctx context.Context, submission *entity.KRSSubmission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.ContainsFunc(r.submissions, func(k *entity.KRSSubmission) bool {
+		return k.StudentID == submission.StudentID && k.AcademicYear == submission.AcademicYear && k.Semester == submission.Semester
+	}) {
+		return &apperror.Error{
+			Kind:    apperror.KindConflict,
+			Message: "student_id_academic_year_semester already exists",
+			Field:   "student_id_academic_year_semester",
+		}
+	}
+
+	if err := submission.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if submission.Status == "" {
+		submission.Status = entity.KRSDraft
+	}
+	ts := now()
+	if submission.Version == 0 {
+		submission.Version = 1
+	}
+	if submission.CreatedAt.IsZero() {
+		submission.CreatedAt = ts
+	}
+	if submission.UpdatedAt.IsZero() {
+		submission.UpdatedAt = ts
+	}
+	stored := *submission
+	stored.Reviews = nil
+	r.submissions = append(r.submissions, &stored)
+	return nil
+}
+
+func (r *krsRepository) find(match func(*entity.KRSSubmission) bool) (*entity.KRSSubmission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, k := range r.submissions {
+		if match(k) {
+			found := *k
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *krsRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.KRSSubmission, error) {
+	return r.find(func(k *entity.KRSSubmission) bool { return k.ID == id })
+}
+
+func (r *krsRepository) FindByStudentTerm(ctx context.Context, studentID uuid.UUID, academicYear string, semester int) (*entity.KRSSubmission, error) {
+	return r.find(func(k *entity.KRSSubmission) bool {
+		return k.StudentID == studentID && k.AcademicYear == academicYear && k.Semester == semester
+	})
+}
+
+func (r *krsRepository) selectSubmissions(match func(*entity.KRSSubmission) bool) []*entity.KRSSubmission {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var submissions []*entity.KRSSubmission
+	for _, k := range r.submissions {
+		if match(k) {
+			found := *k
+			submissions = append(submissions, &found)
+		}
+	}
+	return submissions
+}
+
+func (r *krsRepository) FindByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.KRSSubmission, error) {
+	submissions := r.selectSubmissions(func(k *entity.KRSSubmission) bool { return k.StudentID == studentID })
+
+	// ORDER BY academic_year DESC, semester DESC
+	slices.SortStableFunc(submissions, func(a, b *entity.KRSSubmission) int {
+		if c := cmp.Compare(b.AcademicYear, a.AcademicYear); c != 0 {
+			return c
+		}
+		return b.Semester - a.Semester
+	})
+	return submissions, nil
+}
+
+func (r *krsRepository) FindSubmitted(ctx context.Context, advisorID uuid.UUID, pageNum, pageSize int) ([]*entity.KRSSubmission, int64, error) {
+	submissions := r.selectSubmissions(func(k *entity.KRSSubmission) bool {
+		return k.Status == entity.KRSSubmitted && k.AdvisorID != nil && *k.AdvisorID == advisorID
+	})
+
+	// ORDER BY submitted_at, id
+	slices.SortStableFunc(submissions, func(a, b *entity.KRSSubmission) int {
+		if c := submittedAt(a).Compare(submittedAt(b)); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	submissions, total := page(submissions, pageNum, pageSize)
+	return submissions, total, nil
+}
+
+func submittedAt(k *entity.KRSSubmission) time.Time {
+	if k.SubmittedAt == nil {
+		return time.Time{}
+	}
+	return *k.SubmittedAt
+}
+
+func (r *krsRepository) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, k := range r.submissions {
+		if k.ID != id || k.Version != version {
+			continue
+		}
+		updated := *k
+		for column, value := range changes {
+			if err := r.columns.set(&updated, column, value); err != nil {
+				return err
+			}
+		}
+		updated.Version = version + 1
+		updated.UpdatedAt = now()
+		r.submissions[i] = &updated
+		return nil
+	}
+	return repository.ErrVersionConflict
+}
+
+func (r *krsRepository) AddReview(ctx context.Context, review *entity.KRSReview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := review.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if review.CreatedAt.IsZero() {
+		review.CreatedAt = now()
+	}
+	stored := *review
+	r.reviews = append(r.reviews, &stored)
+	return nil
+}
+
+func (r *krsRepository) FindReviews(ctx context.Context, submissionID uuid.UUID) ([]*entity.KRSReview, error) {
+	r.mu.RLock()
+	var reviews []*entity.KRSReview
+	for _, review := range r.reviews {
+		if review.SubmissionID == submissionID {
+			found := *review
+			reviews = append(reviews, &found)
+		}
+	}
+	r.mu.RUnlock()
+
+	// ORDER BY created_at, id
+	slices.SortStableFunc(reviews, func(a, b *entity.KRSReview) int {
+		return compareKeysets(repository.Keyset{CreatedAt: a.CreatedAt, ID: a.ID}, repository.Keyset{CreatedAt: b.CreatedAt, ID: b.ID})
+	})
+	return reviews, nil
+}
+
+func (r *krsRepository) snapshot() func() {
+	restoreSubmissions := snapshotRows(&r.mu, &r.submissions)
+	restoreReviews := snapshotRows(&r.mu, &r.reviews)
+	return func() {
+		restoreSubmissions()
+		restoreReviews()
+	}
+}
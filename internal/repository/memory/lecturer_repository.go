@@ -0,0 +1,89 @@
+// File: internal/repository/memory/lecturer_repository.go
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type lecturerRepository struct {
+	lecturers *table[entity.Lecturer]
+}
+
+func NewLecturerRepository() repository.LecturerRepository {
+	return &lecturerRepository{lecturers: newTable[entity.Lecturer]([]string{"nip"}, []string{"email"})}
+}
+
+func (r *lecturerRepository) Create(ctx context.Context, lecturer *entity.Lecturer) error {
+	return r.lecturers.insert(lecturer)
+}
+
+func (r *lecturerRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Lecturer, error) {
+	return r.lecturers.findByID(id)
+}
+
+func (r *lecturerRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Lecturer, error) {
+	return r.lecturers.first(func(l *entity.Lecturer) bool {
+		return l.UserID != nil && *l.UserID == userID
+	})
+}
+
+func (r *lecturerRepository) FindAll(ctx context.Context, pageNum, pageSize int, spec query.Spec) ([]*entity.Lecturer, int64, error) {
+	lecturers, total := page(r.lecturers.find(query.Lecturers, spec), pageNum, pageSize)
+	return lecturers, total, nil
+}
+
+func (r *lecturerRepository) FindPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Lecturer], error) {
+	return r.lecturers.window(r.lecturers.findNewest(query.Lecturers, spec), page), nil
+}
+
+func (r *lecturerRepository) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	return int64(len(r.lecturers.find(query.Lecturers, spec))), nil
+}
+
+func (r *lecturerRepository) Stream(ctx context.Context, spec query.Spec, fn func(*entity.Lecturer) error) error {
+	for _, lecturer := range r.lecturers.find(query.Lecturers, spec) {
+		if err := fn(lecturer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *lecturerRepository) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	return r.lecturers.update(id, version, changes)
+}
+
+func (r *lecturerRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return r.lecturers.softDelete(id, version)
+}
+
+func (r *lecturerRepository) FindDeleted(ctx context.Context, pageNum, pageSize int) ([]*entity.Lecturer, int64, error) {
+	lecturers, total := r.lecturers.findDeleted(pageNum, pageSize)
+	return lecturers, total, nil
+}
+
+func (r *lecturerRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Lecturer, error) {
+	return r.lecturers.findDeletedByID(id)
+}
+
+func (r *lecturerRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.lecturers.restore(id)
+}
+
+func (r *lecturerRepository) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	return r.lecturers.purgeByID(id, deletedBefore)
+}
+
+func (r *lecturerRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return r.lecturers.purge(everyRow, deletedBefore), nil
+}
+
+func (r *lecturerRepository) snapshot() func() {
+	return r.lecturers.snapshot()
+}
@@ -0,0 +1,119 @@
+// File: internal/repository/memory/outbox_repository.go
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// outboxRepository keeps the events in ID order, like auditRepository.
+type outboxRepository struct {
+	mu     sync.RWMutex
+	events []*entity.OutboxEvent
+	nextID int64
+	relay  sync.Mutex
+}
+
+func NewOutboxRepository() repository.OutboxRepository {
+	return &outboxRepository{}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *entity.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	event.ID = r.nextID
+	stored := *event
+	r.events = append(r.events, &stored)
+	return nil
+}
+
+func (r *outboxRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []*entity.OutboxEvent
+	seen := make(map[string]bool)
+	for _, e := range r.events {
+		if len(events) == limit {
+			break
+		}
+		if e.PublishedAt != nil || e.DeadAt != nil || seen[e.OrderingKey] {
+			continue
+		}
+		seen[e.OrderingKey] = true
+		if e.NextAttemptAt == nil || !e.NextAttemptAt.After(now) {
+			found := *e
+			events = append(events, &found)
+		}
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id int64, at time.Time) error {
+	return r.update(id, func(e *entity.OutboxEvent) {
+		at := at.UTC()
+		e.PublishedAt, e.NextAttemptAt = &at, nil
+	})
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	return r.update(id, func(e *entity.OutboxEvent) {
+		next := nextAttemptAt.UTC()
+		e.Attempts++
+		e.LastError, e.NextAttemptAt = lastError, &next
+	})
+}
+
+func (r *outboxRepository) MarkDead(ctx context.Context, id int64, lastError string, at time.Time) error {
+	return r.update(id, func(e *entity.OutboxEvent) {
+		at := at.UTC()
+		e.Attempts++
+		e.LastError, e.NextAttemptAt, e.DeadAt = lastError, nil, &at
+	})
+}
+
+func (r *outboxRepository) update(id int64, fn func(*entity.OutboxEvent)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range r.events {
+		if e.ID == id {
+			fn(e)
+		}
+	}
+	return nil
+}
+
+func (r *outboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.events[:0]
+	for _, e := range r.events {
+		if e.PublishedAt == nil || !e.PublishedAt.Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	deleted := int64(len(r.events) - len(kept))
+	clear(r.events[len(kept):])
+	r.events = kept
+	return deleted, nil
+}
+
+func (r *outboxRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	if !r.relay.TryLock() {
+		return nil, false, nil
+	}
+	return r.relay.Unlock, true, nil
+}
+
+// snapshot leaves nextID alone, like a sequence.
+func (r *outboxRepository) snapshot() func() {
+	return snapshotRows(&r.mu, &r.events)
+}
@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/haninhammoud01/go-academic-service/internal/repository/repotest"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		students, lecturers, courses := NewStudentRepository(), NewLecturerRepository(), NewCourseRepository()
+		krs, waitlists := NewKRSRepository(), NewWaitlistRepository()
+		return repotest.Repositories{
+			Users:       NewUserRepository(),
+			Students:    students,
+			Lecturers:   lecturers,
+			Courses:     courses,
+			Enrollments: NewEnrollmentRepository(krs, waitlists),
+			Search:      NewSearchRepository(students, lecturers, courses),
+			Audit:       NewAuditRepository(),
+			Outbox:      NewOutboxRepository(),
+			Webhooks:    NewWebhookSubscriptionRepository(),
+			Deliveries:  NewWebhookDeliveryRepository(),
+			SeatBus:     NewSeatChangeBus(),
+			Waitlists:   waitlists,
+			Advisors:    NewAdvisorRepository(),
+			KRS:         krs,
+		}
+	})
+}
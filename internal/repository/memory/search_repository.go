@@ -0,0 +1,67 @@
+// File: internal/repository/memory/search_repository.go
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/textsearch"
+)
+
+type searchRepository struct {
+	students  *table[entity.Student]
+	lecturers *table[entity.Lecturer]
+	courses   *table[entity.Course]
+}
+
+// NewSearchRepository searches the rows of repositories made by this
+// package, ranking with textsearch.Score. Like the trigram index in
+// Postgres it finds misspelt words.
+func NewSearchRepository(students repository.StudentRepository, lecturers repository.LecturerRepository, courses repository.CourseRepository) repository.SearchRepository {
+	return &searchRepository{
+		students:  students.(*studentRepository).students,
+		lecturers: lecturers.(*lecturerRepository).lecturers,
+		courses:   courses.(*courseRepository).courses,
+	}
+}
+
+func (r *searchRepository) Search(ctx context.Context, terms []string, kinds []string, limit int) ([]repository.SearchHit, error) {
+	hits := []repository.SearchHit{}
+	add := func(hit repository.SearchHit) {
+		if hit.Rank = textsearch.Score(terms, hit.Title+" "+hit.Subtitle); hit.Rank > 0 {
+			hits = append(hits, hit)
+		}
+	}
+
+	if slices.Contains(kinds, repository.SearchStudent) {
+		for _, s := range r.students.selectRows(everyRow, unordered) {
+			add(repository.SearchHit{Type: repository.SearchStudent, ID: s.ID, Title: s.Name, Subtitle: s.NIM, Detail: s.Major})
+		}
+	}
+	if slices.Contains(kinds, repository.SearchLecturer) {
+		for _, l := range r.lecturers.selectRows(everyRow, unordered) {
+			add(repository.SearchHit{Type: repository.SearchLecturer, ID: l.ID, Title: l.Name, Subtitle: l.NIP, Detail: l.Department})
+		}
+	}
+	if slices.Contains(kinds, repository.SearchCourse) {
+		for _, c := range r.courses.selectRows(everyRow, unordered) {
+			add(repository.SearchHit{Type: repository.SearchCourse, ID: c.ID, Title: c.Name, Subtitle: c.Code, Detail: c.Department})
+		}
+	}
+
+	slices.SortStableFunc(hits, func(a, b repository.SearchHit) int {
+		if c := cmp.Compare(b.Rank, a.Rank); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+	return hits[:min(limit, len(hits))], nil
+}
+
+func everyRow[T any](*T) bool { return true }
+
+func unordered[T any](a, b *T) int { return 0 }
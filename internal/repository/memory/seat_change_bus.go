@@ -0,0 +1,42 @@
+// File: internal/repository/memory/seat_change_bus.go
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// seatChangeBus hands changes straight to the listeners of this process.
+type seatChangeBus struct {
+	mu        sync.RWMutex
+	listeners map[*func(repository.SeatChange)]struct{}
+}
+
+func NewSeatChangeBus() repository.SeatChangeBus {
+	return &seatChangeBus{listeners: make(map[*func(repository.SeatChange)]struct{})}
+}
+
+func (b *seatChangeBus) Announce(ctx context.Context, change repository.SeatChange) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for fn := range b.listeners {
+		(*fn)(change)
+	}
+	return nil
+}
+
+func (b *seatChangeBus) Listen(ctx context.Context, fn func(repository.SeatChange)) error {
+	b.mu.Lock()
+	b.listeners[&fn] = struct{}{}
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.listeners, &fn)
+	b.mu.Unlock()
+	return ctx.Err()
+}
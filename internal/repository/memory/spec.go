@@ -0,0 +1,149 @@
+// File: internal/repository/memory/spec.go
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+)
+
+// find returns the live rows matching the filters and search term of spec,
+// sorted as applyOrder sorts them in Postgres.
+func (t *table[T]) find(resource *query.Resource, spec query.Spec) []*T {
+	return t.selectRows(func(row *T) bool { return t.matches(row, resource, spec) }, t.order(resource, spec))
+}
+
+// findNewest is find in keyset order, which ignores the sort of spec.
+func (t *table[T]) findNewest(resource *query.Resource, spec query.Spec) []*T {
+	return t.selectRows(func(row *T) bool { return t.matches(row, resource, spec) }, t.newestFirst)
+}
+
+func (t *table[T]) matches(row *T, resource *query.Resource, spec query.Spec) bool {
+	for _, filter := range spec.Filters {
+		if !matchFilter(t.field(row, filter.Field), filter) {
+			return false
+		}
+	}
+	if spec.Search == "" || len(resource.Search) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(resource.Search, func(field string) bool {
+		value, ok := t.field(row, field).(string)
+		return ok && contains(value, spec.Search)
+	})
+}
+
+// field returns the value of column in row with pointers dereferenced, or
+// nil for NULL.
+func (t *table[T]) field(row *T, column string) any {
+	v := reflect.ValueOf(t.value(row, column))
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// matchFilter follows SQL in that no comparison matches NULL.
+func matchFilter(value any, filter query.Filter) bool {
+	if value == nil {
+		return false
+	}
+	switch filter.Op {
+	case query.Like:
+		s, ok := value.(string)
+		return ok && contains(s, filter.Value.(string))
+	case query.In:
+		return slices.ContainsFunc(filter.Value.([]any), func(operand any) bool {
+			return compareValues(value, operand) == 0
+		})
+	}
+
+	c := compareValues(value, filter.Value)
+	switch filter.Op {
+	case query.Eq:
+		return c == 0
+	case query.Ne:
+		return c != 0
+	case query.Gt:
+		return c > 0
+	case query.Gte:
+		return c >= 0
+	case query.Lt:
+		return c < 0
+	case query.Lte:
+		return c <= 0
+	}
+	return false
+}
+
+// compareValues compares two values of one of the query types. Values of
+// different types, which the allowlist rules out, compare as unequal.
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b)
+		}
+	case int:
+		if b, ok := b.(int); ok {
+			return cmp.Compare(a, b)
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b)
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b)
+		}
+	case uuid.UUID:
+		if b, ok := b.(uuid.UUID); ok {
+			return bytes.Compare(a[:], b[:])
+		}
+	}
+	return -1
+}
+
+// order returns a comparison for the sort of spec: NULLs of nullable
+// fields last, then id in the direction of the last key.
+func (t *table[T]) order(resource *query.Resource, spec query.Spec) func(a, b *T) int {
+	sorts := spec.OrderBy(resource)
+	return func(a, b *T) int {
+		for _, sort := range sorts {
+			x, y := t.field(a, sort.Field), t.field(b, sort.Field)
+			switch {
+			case x == nil && y == nil:
+				continue
+			case x == nil:
+				return 1
+			case y == nil:
+				return -1
+			}
+			c := compareValues(x, y)
+			if sort.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		x, y := t.id(a), t.id(b)
+		c := bytes.Compare(x[:], y[:])
+		if sorts[len(sorts)-1].Desc {
+			c = -c
+		}
+		return c
+	}
+}
@@ -0,0 +1,87 @@
+// File: internal/repository/memory/student_repository.go
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type studentRepository struct {
+	students *table[entity.Student]
+}
+
+func NewStudentRepository() repository.StudentRepository {
+	return &studentRepository{students: newTable[entity.Student]([]string{"nim"}, []string{"email"})}
+}
+
+func (r *studentRepository) Create(ctx context.Context, student *entity.Student) error {
+	return r.students.insert(student)
+}
+
+func (r *studentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Student, error) {
+	return r.students.findByID(id)
+}
+
+func (r *studentRepository) FindByNIM(ctx context.Context, nim string) (*entity.Student, error) {
+	return r.students.first(func(s *entity.Student) bool { return s.NIM == nim })
+}
+
+func (r *studentRepository) FindAll(ctx context.Context, pageNum, pageSize int, spec query.Spec) ([]*entity.Student, int64, error) {
+	students, total := page(r.students.find(query.Students, spec), pageNum, pageSize)
+	return students, total, nil
+}
+
+func (r *studentRepository) FindPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Student], error) {
+	return r.students.window(r.students.findNewest(query.Students, spec), page), nil
+}
+
+func (r *studentRepository) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	return int64(len(r.students.find(query.Students, spec))), nil
+}
+
+func (r *studentRepository) Stream(ctx context.Context, spec query.Spec, fn func(*entity.Student) error) error {
+	for _, student := range r.students.find(query.Students, spec) {
+		if err := fn(student); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *studentRepository) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	return r.students.update(id, version, changes)
+}
+
+func (r *studentRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return r.students.softDelete(id, version)
+}
+
+func (r *studentRepository) FindDeleted(ctx context.Context, pageNum, pageSize int) ([]*entity.Student, int64, error) {
+	students, total := r.students.findDeleted(pageNum, pageSize)
+	return students, total, nil
+}
+
+func (r *studentRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Student, error) {
+	return r.students.findDeletedByID(id)
+}
+
+func (r *studentRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.students.restore(id)
+}
+
+func (r *studentRepository) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	return r.students.purgeByID(id, deletedBefore)
+}
+
+func (r *studentRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return r.students.purge(everyRow, deletedBefore), nil
+}
+
+func (r *studentRepository) snapshot() func() {
+	return r.students.snapshot()
+}
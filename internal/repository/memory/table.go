@@ -0,0 +1,389 @@
+// File: internal/repository/memory/table.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var schemaCache sync.Map
+
+// table holds the rows of one entity the way its Postgres table would.
+// Columns are resolved through the GORM schema of T, so change maps use
+// the same column names as the Postgres repositories, and defaults and
+// timestamps are filled in the way GORM and the database fill them.
+//
+// Rows are stored and returned as copies; pointer fields are shared.
+type table[T any] struct {
+	schema *schema.Schema
+	// unique lists the column sets under a unique index. As in the SQL
+	// migrations, the indexes are partial: soft-deleted rows take no part.
+	unique [][]string
+
+	mu   sync.RWMutex
+	rows []*T
+}
+
+func newTable[T any](unique ...[]string) *table[T] {
+	s, err := schema.Parse(new(T), &schemaCache, schema.NamingStrategy{})
+	if err != nil {
+		panic(fmt.Sprintf("memory: parse schema of %T: %v", *new(T), err))
+	}
+	return &table[T]{schema: s, unique: unique}
+}
+
+// now matches the microsecond precision of Postgres timestamps.
+func now() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+func (t *table[T]) value(row *T, column string) interface{} {
+	v, _ := t.schema.LookUpField(column).ValueOf(context.Background(), reflect.ValueOf(row).Elem())
+	return v
+}
+
+func (t *table[T]) id(row *T) uuid.UUID {
+	return t.value(row, "id").(uuid.UUID)
+}
+
+func (t *table[T]) keyset(row *T) repository.Keyset {
+	return repository.Keyset{CreatedAt: t.value(row, "created_at").(time.Time), ID: t.id(row)}
+}
+
+// newestFirst orders like `ORDER BY created_at DESC, id DESC`.
+func (t *table[T]) newestFirst(a, b *T) int {
+	return compareKeysets(t.keyset(b), t.keyset(a))
+}
+
+func (t *table[T]) version(row *T) int {
+	return t.value(row, "version").(int)
+}
+
+func (t *table[T]) deleted(row *T) bool {
+	return t.value(row, "deleted_at").(gorm.DeletedAt).Valid
+}
+
+func (t *table[T]) set(row *T, column string, value interface{}) error {
+	field := t.schema.LookUpField(column)
+	if field == nil || field.DBName == "" {
+		return fmt.Errorf("column %q of relation %q does not exist", column, t.schema.Table)
+	}
+	return field.Set(context.Background(), reflect.ValueOf(row).Elem(), value)
+}
+
+// insert stores a copy of row after filling in what GORM and the
+// database would: the BeforeCreate hook (which assigns the ID), column
+// defaults for zero values and the timestamps. The filled-in values are
+// written back to row, as GORM does on Create.
+func (t *table[T]) insert(row *T) error {
+	ctx := context.Background()
+	rv := reflect.ValueOf(row).Elem()
+	ts := now()
+
+	if hook, ok := any(row).(interface{ BeforeCreate(*gorm.DB) error }); ok {
+		if err := hook.BeforeCreate(nil); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range t.schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if _, zero := field.ValueOf(ctx, rv); !zero {
+			continue
+		}
+		var value interface{}
+		switch {
+		case field.AutoCreateTime != 0 || field.AutoUpdateTime != 0:
+			value = ts
+		case !field.HasDefaultValue:
+			continue
+		case field.DefaultValueInterface != nil:
+			value = field.DefaultValueInterface
+		case field.DataType == schema.Time:
+			value = ts
+		default:
+			continue
+		}
+		if err := field.Set(ctx, rv, value); err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.checkUnique(row, uuid.Nil); err != nil {
+		return err
+	}
+	stored := *row
+	t.rows = append(t.rows, &stored)
+	return nil
+}
+
+// checkUnique reports a conflict the way translateError does for a
+// unique violation. self is skipped so a row can keep its own values.
+func (t *table[T]) checkUnique(row *T, self uuid.UUID) error {
+	for _, columns := range t.unique {
+		for _, other := range t.rows {
+			if t.id(other) == self || t.deleted(other) || !t.sameValues(row, other, columns) {
+				continue
+			}
+			field := strings.Join(columns, "_")
+			return &apperror.Error{
+				Kind:    apperror.KindConflict,
+				Message: fmt.Sprintf("%s already exists", field),
+				Field:   field,
+			}
+		}
+	}
+	return nil
+}
+
+func (t *table[T]) sameValues(a, b *T, columns []string) bool {
+	for _, column := range columns {
+		if t.value(a, column) != t.value(b, column) {
+			return false
+		}
+	}
+	return true
+}
+
+// first returns a copy of the first live row matching match, or
+// repository.ErrNotFound like the Postgres repositories.
+func (t *table[T]) first(match func(*T) bool) (*T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, row := range t.rows {
+		if !t.deleted(row) && match(row) {
+			found := *row
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (t *table[T]) findByID(id uuid.UUID) (*T, error) {
+	return t.first(func(row *T) bool { return t.id(row) == id })
+}
+
+// selectRows returns copies of the live rows matching match, sorted with
+// compare. The sort is stable, so ties keep insertion order.
+func (t *table[T]) selectRows(match func(*T) bool, compare func(a, b *T) int) []*T {
+	t.mu.RLock()
+	var rows []*T
+	for _, row := range t.rows {
+		if !t.deleted(row) && match(row) {
+			found := *row
+			rows = append(rows, &found)
+		}
+	}
+	t.mu.RUnlock()
+
+	slices.SortStableFunc(rows, compare)
+	return rows
+}
+
+// page applies the repositories' offset pagination and returns the rows
+// of the page together with the total count.
+func page[T any](rows []*T, page, pageSize int) ([]*T, int64) {
+	total := int64(len(rows))
+	offset := (page - 1) * pageSize
+	if offset >= len(rows) {
+		return []*T{}, total
+	}
+	return rows[offset:min(offset+pageSize, len(rows))], total
+}
+
+// update applies changes to the live row with id and version, bumping the
+// version and updated_at, with the same semantics as the versioned
+// Postgres updates.
+func (t *table[T]) update(id uuid.UUID, version int, changes map[string]interface{}) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, row := range t.rows {
+		if t.id(row) != id || t.deleted(row) || t.version(row) != version {
+			continue
+		}
+		updated := *row
+		for column, value := range changes {
+			if err := t.set(&updated, column, value); err != nil {
+				return err
+			}
+		}
+		if err := t.set(&updated, "version", version+1); err != nil {
+			return err
+		}
+		if err := t.set(&updated, "updated_at", now()); err != nil {
+			return err
+		}
+		if err := t.checkUnique(&updated, id); err != nil {
+			return err
+		}
+		t.rows[i] = &updated
+		return nil
+	}
+	return repository.ErrVersionConflict
+}
+
+// softDelete stamps deleted_at on the live row with id and version.
+func (t *table[T]) softDelete(id uuid.UUID, version int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, row := range t.rows {
+		if t.id(row) != id || t.deleted(row) || t.version(row) != version {
+			continue
+		}
+		deleted := *row
+		if err := t.set(&deleted, "deleted_at", gorm.DeletedAt{Time: now(), Valid: true}); err != nil {
+			return err
+		}
+		t.rows[i] = &deleted
+		return nil
+	}
+	return repository.ErrVersionConflict
+}
+
+// window applies the repositories' keyset pagination to rows sorted with
+// newestFirst, including their guesses for HasPrev and HasNext.
+func (t *table[T]) window(rows []*T, page repository.KeysetPage) repository.Window[T] {
+	position := func(key repository.Keyset) (int, bool) {
+		return slices.BinarySearchFunc(rows, key, func(row *T, key repository.Keyset) int {
+			return compareKeysets(key, t.keyset(row))
+		})
+	}
+
+	switch {
+	case page.Before != nil:
+		end, _ := position(*page.Before)
+		start := max(0, end-page.Limit)
+		return repository.Window[T]{Items: rows[start:end], HasPrev: start > 0, HasNext: true}
+	case page.After != nil:
+		start, found := position(*page.After)
+		if found {
+			start++
+		}
+		end := min(start+page.Limit, len(rows))
+		return repository.Window[T]{Items: rows[start:end], HasPrev: true, HasNext: end < len(rows)}
+	default:
+		end := min(page.Limit, len(rows))
+		return repository.Window[T]{Items: rows[:end], HasNext: end < len(rows)}
+	}
+}
+
+func (t *table[T]) deletedAt(row *T) time.Time {
+	return t.value(row, "deleted_at").(gorm.DeletedAt).Time
+}
+
+// findDeleted returns copies of the soft-deleted rows, most recently
+// deleted first, with the repositories' offset pagination.
+func (t *table[T]) findDeleted(pageNum, pageSize int) ([]*T, int64) {
+	t.mu.RLock()
+	var rows []*T
+	for _, row := range t.rows {
+		if t.deleted(row) {
+			found := *row
+			rows = append(rows, &found)
+		}
+	}
+	t.mu.RUnlock()
+
+	slices.SortStableFunc(rows, func(a, b *T) int {
+		if c := t.deletedAt(b).Compare(t.deletedAt(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(t.id(b).String(), t.id(a).String())
+	})
+	return page(rows, pageNum, pageSize)
+}
+
+func (t *table[T]) findDeletedByID(id uuid.UUID) (*T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, row := range t.rows {
+		if t.id(row) == id && t.deleted(row) {
+			found := *row
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// restore clears deleted_at on the soft-deleted row with id, bumping the
+// version and updated_at, unless a live row now holds one of its unique
+// values.
+func (t *table[T]) restore(id uuid.UUID) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, row := range t.rows {
+		if t.id(row) != id || !t.deleted(row) {
+			continue
+		}
+		restored := *row
+		if err := t.set(&restored, "deleted_at", gorm.DeletedAt{}); err != nil {
+			return err
+		}
+		if err := t.set(&restored, "version", t.version(row)+1); err != nil {
+			return err
+		}
+		if err := t.set(&restored, "updated_at", now()); err != nil {
+			return err
+		}
+		if err := t.checkUnique(&restored, id); err != nil {
+			return err
+		}
+		t.rows[i] = &restored
+		return nil
+	}
+	return repository.ErrNotFound
+}
+
+// purge removes the rows deleted before deletedBefore that match match
+// and returns how many it removed.
+func (t *table[T]) purge(match func(*T) bool, deletedBefore time.Time) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	kept := t.rows[:0]
+	var purged int64
+	for _, row := range t.rows {
+		if t.deleted(row) && t.deletedAt(row).Before(deletedBefore) && match(row) {
+			purged++
+			continue
+		}
+		kept = append(kept, row)
+	}
+	clear(t.rows[len(kept):])
+	t.rows = kept
+	return purged
+}
+
+// purgeByID is purge for the row with id, reporting
+// repository.ErrNotFound when it was not purged.
+func (t *table[T]) purgeByID(id uuid.UUID, deletedBefore time.Time) error {
+	if t.purge(func(row *T) bool { return t.id(row) == id }, deletedBefore) == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (t *table[T]) snapshot() func() {
+	return snapshotRows(&t.mu, &t.rows)
+}
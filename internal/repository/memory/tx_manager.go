@@ -0,0 +1,83 @@
+// File: internal/repository/memory/tx_manager.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type txKey struct{}
+
+// snapshotter is a repository of this package whose contents a unit of
+// work can roll back.
+type snapshotter interface {
+	// snapshot saves the current contents and returns a function that
+	// puts them back.
+	snapshot() (restore func())
+}
+
+type txManager struct {
+	mu    sync.Mutex
+	repos []snapshotter
+}
+
+// NewTxManager is the TxManager for tests and in-memory wiring, over
+// repositories made by this package. Units of work run one at a time,
+// which gives them the isolation SERIALIZABLE gives on Postgres; nested
+// calls join the outer unit. When fn fails, repos are rolled back to
+// where they were when the unit started. Writes made outside a unit of
+// work while it runs are rolled back with it, and IDs handed out stay
+// used, as with a Postgres sequence.
+func NewTxManager(repos ...interface{}) repository.TxManager {
+	m := &txManager{}
+	for _, repo := range repos {
+		s, ok := repo.(snapshotter)
+		if !ok {
+			panic(fmt.Sprintf("memory: %T cannot take part in a unit of work", repo))
+		}
+		m.repos = append(m.repos, s)
+	}
+	return m
+}
+
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	restore := make([]func(), len(m.repos))
+	for i, repo := range m.repos {
+		restore[i] = repo.snapshot()
+	}
+	err := fn(context.WithValue(ctx, txKey{}, true))
+	if err != nil {
+		for _, r := range restore {
+			r()
+		}
+	}
+	return err
+}
+
+// snapshotRows saves copies of the rows guarded by mu and returns a
+// function that puts them back. Rows are copied because the slice-backed
+// repositories update them in place.
+func snapshotRows[T any](mu *sync.RWMutex, rows *[]*T) func() {
+	mu.RLock()
+	saved := make([]*T, len(*rows))
+	for i, row := range *rows {
+		copied := *row
+		saved[i] = &copied
+	}
+	mu.RUnlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		*rows = saved
+	}
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+func TestTxManagerRollback(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name string
+		// nestedErr is returned by a nested unit, whose error the outer
+		// unit handles.
+		nestedErr error
+		err       error
+		wantName  string
+		wantLogs  int64
+	}{
+		{name: "commit keeps the writes", wantName: "Renamed", wantLogs: 1},
+		{name: "failure rolls every repository back", err: errFailed, wantName: "Original"},
+		{name: "a failed nested unit joins the outer one", nestedErr: errFailed, wantName: "Renamed", wantLogs: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			students, audit := NewStudentRepository(), NewAuditRepository()
+			tx := NewTxManager(students, audit)
+
+			student := &entity.Student{NIM: "2024001", Name: "Original", Email: "s@example.com", Major: "CS", EnrollmentYear: 2024}
+			if err := students.Create(ctx, student); err != nil {
+				t.Fatal(err)
+			}
+
+			err := tx.WithinTx(ctx, func(ctx context.Context) error {
+				if err := students.Update(ctx, student.ID, student.Version, map[string]interface{}{"name": "Renamed"}); err != nil {
+					return err
+				}
+				entry := &entity.AuditLog{Action: entity.AuditUpdate, EntityType: entity.AuditStudent, EntityID: student.ID, CreatedAt: time.Now()}
+				if err := audit.Create(ctx, entry); err != nil {
+					return err
+				}
+				if tt.nestedErr != nil {
+					if err := tx.WithinTx(ctx, func(ctx context.Context) error { return tt.nestedErr }); !errors.Is(err, tt.nestedErr) {
+						t.Errorf("nested WithinTx() = %v, want %v", err, tt.nestedErr)
+					}
+				}
+				return tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("WithinTx() = %v, want %v", err, tt.err)
+			}
+
+			got, err := students.FindByID(ctx, student.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("name = %q, want %q", got.Name, tt.wantName)
+			}
+			_, total, err := audit.FindAll(ctx, repository.AuditFilter{}, 1, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != tt.wantLogs {
+				t.Errorf("%d audit entries, want %d", total, tt.wantLogs)
+			}
+		})
+	}
+}
+
+func TestNewTxManagerRejectsForeignRepositories(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewTxManager accepted a repository it cannot roll back")
+		}
+	}()
+	NewTxManager(uuid.New())
+}
@@ -0,0 +1,38 @@
+// File: internal/repository/memory/user_repository.go
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type userRepository struct {
+	users *table[entity.User]
+}
+
+func NewUserRepository() repository.UserRepository {
+	return &userRepository{users: newTable[entity.User]([]string{"username"}, []string{"email"})}
+}
+
+func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
+	return r.users.insert(user)
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	return r.users.findByID(id)
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return r.users.first(func(u *entity.User) bool { return u.Email == email })
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return r.users.first(func(u *entity.User) bool { return u.Username == username })
+}
+
+func (r *userRepository) snapshot() func() {
+	return r.users.snapshot()
+}
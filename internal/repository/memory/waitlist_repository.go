@@ -0,0 +1,137 @@
+// File: internal/repository/memory/waitlist_repository.go
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// waitlistRepository keeps the entries in a slice of its own, since a
+// table expects soft deletes; the table resolves the columns of Update.
+type waitlistRepository struct {
+	columns *table[entity.WaitlistEntry]
+
+	mu      sync.RWMutex
+	entries []*entity.WaitlistEntry
+}
+
+func NewWaitlistRepository() repository.WaitlistRepository {
+	return &waitlistRepository{columns: newTable[entity.WaitlistEntry]()}
+}
+
+func (r *waitlistRepository) Create(ctx context.Context, entry *entity.WaitlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The partial unique index of the migrations: a student waits at most
+	// once for a course term.
+	if entry.Status == "" {
+		entry.Status = entity.WaitlistWaiting
+	}
+	if entry.Status == entity.WaitlistWaiting && slices.ContainsFunc(r.entries, func(e *entity.WaitlistEntry) bool {
+		return e.Status == entity.WaitlistWaiting && e.StudentID == entry.StudentID && e.CourseID == entry.CourseID &&
+			e.AcademicYear == entry.AcademicYear && e.Semester == entry.Semester
+	}) {
+		return &apperror.Error{
+			Kind:    apperror.KindConflict,
+			Message: "student_id_course_id_academic_year_semester already exists",
+			Field:   "student_id_course_id_academic_year_semester",
+		}
+	}
+
+	if err := entry.BeforeCreate(nil); err != nil {
+		return err
+	}
+	ts := now()
+	if entry.Version == 0 {
+		entry.Version = 1
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = ts
+	}
+	if entry.UpdatedAt.IsZero() {
+		entry.UpdatedAt = ts
+	}
+	stored := *entry
+	r.entries = append(r.entries, &stored)
+	return nil
+}
+
+func (r *waitlistRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, e := range r.entries {
+		if e.ID == id {
+			found := *e
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *waitlistRepository) FindWaiting(ctx context.Context, courseID uuid.UUID, academicYear string, semester int) ([]*entity.WaitlistEntry, error) {
+	r.mu.RLock()
+	var waiting []*entity.WaitlistEntry
+	for _, e := range r.entries {
+		if e.Status == entity.WaitlistWaiting && e.CourseID == courseID && e.AcademicYear == academicYear && e.Semester == semester {
+			found := *e
+			waiting = append(waiting, &found)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortStableFunc(waiting, compareWaitlistEntries)
+	return waiting, nil
+}
+
+func (r *waitlistRepository) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, e := range r.entries {
+		if e.ID != id || e.Version != version {
+			continue
+		}
+		updated := *e
+		for column, value := range changes {
+			if err := r.columns.set(&updated, column, value); err != nil {
+				return err
+			}
+		}
+		updated.Version = version + 1
+		updated.UpdatedAt = now()
+		r.entries[i] = &updated
+		return nil
+	}
+	return repository.ErrVersionConflict
+}
+
+// compareWaitlistEntries orders like `ORDER BY position, created_at, id`.
+func compareWaitlistEntries(a, b *entity.WaitlistEntry) int {
+	if a.Position != b.Position {
+		return a.Position - b.Position
+	}
+	return compareKeysets(repository.Keyset{CreatedAt: a.CreatedAt, ID: a.ID}, repository.Keyset{CreatedAt: b.CreatedAt, ID: b.ID})
+}
+
+// promoted reports whether the enrollment with id was made by promoting
+// an entry.
+func (r *waitlistRepository) promoted(enrollmentID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.ContainsFunc(r.entries, func(e *entity.WaitlistEntry) bool {
+		return e.Status == entity.WaitlistPromoted && e.EnrollmentID != nil && *e.EnrollmentID == enrollmentID
+	})
+}
+
+func (r *waitlistRepository) snapshot() func() {
+	return snapshotRows(&r.mu, &r.entries)
+}
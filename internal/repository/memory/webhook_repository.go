@@ -0,0 +1,199 @@
+// File: internal/repository/memory/webhook_repository.go
+package memory
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type webhookSubscriptionRepository struct {
+	subscriptions *table[entity.WebhookSubscription]
+}
+
+func NewWebhookSubscriptionRepository() repository.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{subscriptions: newTable[entity.WebhookSubscription]()}
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	return r.subscriptions.insert(subscription)
+}
+
+func (r *webhookSubscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	return r.subscriptions.findByID(id)
+}
+
+func (r *webhookSubscriptionRepository) FindAll(ctx context.Context, pageNum, pageSize int) ([]*entity.WebhookSubscription, int64, error) {
+	subscriptions, total := page(r.subscriptions.selectRows(everyRow, r.subscriptions.newestFirst), pageNum, pageSize)
+	return subscriptions, total, nil
+}
+
+func (r *webhookSubscriptionRepository) FindActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	active := func(s *entity.WebhookSubscription) bool { return s.Active }
+	byID := func(a, b *entity.WebhookSubscription) int { return bytes.Compare(a.ID[:], b.ID[:]) }
+	return r.subscriptions.selectRows(active, byID), nil
+}
+
+func (r *webhookSubscriptionRepository) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	return r.subscriptions.update(id, version, changes)
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return r.subscriptions.softDelete(id, version)
+}
+
+// webhookDeliveryRepository keeps the deliveries in insertion order. It
+// does not store them in a table, which expects soft deletes, but uses
+// one to resolve the columns of Update.
+type webhookDeliveryRepository struct {
+	columns *table[entity.WebhookDelivery]
+
+	mu         sync.RWMutex
+	deliveries []*entity.WebhookDelivery
+	dispatch   sync.Mutex
+}
+
+func NewWebhookDeliveryRepository() repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{columns: newTable[entity.WebhookDelivery]()}
+}
+
+func (r *webhookDeliveryRepository) CreateMany(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ts := now()
+	for _, delivery := range deliveries {
+		if slices.ContainsFunc(r.deliveries, func(d *entity.WebhookDelivery) bool {
+			return d.SubscriptionID == delivery.SubscriptionID && d.EventID == delivery.EventID
+		}) {
+			continue
+		}
+		if err := delivery.BeforeCreate(nil); err != nil {
+			return err
+		}
+		delivery.CreatedAt, delivery.UpdatedAt = ts, ts
+		stored := *delivery
+		r.deliveries = append(r.deliveries, &stored)
+	}
+	return nil
+}
+
+func (r *webhookDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.deliveries {
+		if d.ID == id {
+			found := *d
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *webhookDeliveryRepository) FindAll(ctx context.Context, filter repository.DeliveryFilter, pageNum, pageSize int) ([]*entity.WebhookDelivery, int64, error) {
+	r.mu.RLock()
+	var matched []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if matchesDelivery(d, filter) {
+			found := *d
+			matched = append(matched, &found)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortStableFunc(matched, func(a, b *entity.WebhookDelivery) int {
+		return compareKeysets(repository.Keyset{CreatedAt: b.CreatedAt, ID: b.ID}, repository.Keyset{CreatedAt: a.CreatedAt, ID: a.ID})
+	})
+	deliveries, total := page(matched, pageNum, pageSize)
+	return deliveries, total, nil
+}
+
+func (r *webhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	var due []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == entity.DeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			found := *d
+			due = append(due, &found)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortStableFunc(due, func(a, b *entity.WebhookDelivery) int {
+		if c := a.NextAttemptAt.Compare(*b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	return due[:min(limit, len(due))], nil
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, id uuid.UUID, changes map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, d := range r.deliveries {
+		if d.ID != id {
+			continue
+		}
+		updated := *d
+		for column, value := range changes {
+			if err := r.columns.set(&updated, column, value); err != nil {
+				return err
+			}
+		}
+		updated.UpdatedAt = now()
+		r.deliveries[i] = &updated
+	}
+	return nil
+}
+
+func (r *webhookDeliveryRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.deliveries[:0]
+	for _, d := range r.deliveries {
+		if d.Status == entity.DeliveryPending || !d.UpdatedAt.Before(before) {
+			kept = append(kept, d)
+		}
+	}
+	deleted := int64(len(r.deliveries) - len(kept))
+	clear(r.deliveries[len(kept):])
+	r.deliveries = kept
+	return deleted, nil
+}
+
+func (r *webhookDeliveryRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	if !r.dispatch.TryLock() {
+		return nil, false, nil
+	}
+	return r.dispatch.Unlock, true, nil
+}
+
+func matchesDelivery(d *entity.WebhookDelivery, filter repository.DeliveryFilter) bool {
+	switch {
+	case filter.SubscriptionID != nil && d.SubscriptionID != *filter.SubscriptionID:
+		return false
+	case filter.Status != "" && d.Status != filter.Status:
+		return false
+	case filter.EventType != "" && d.EventType != filter.EventType:
+		return false
+	}
+	return true
+}
+
+func (r *webhookSubscriptionRepository) snapshot() func() {
+	return r.subscriptions.snapshot()
+}
+
+func (r *webhookDeliveryRepository) snapshot() func() {
+	return snapshotRows(&r.mu, &r.deliveries)
+}
@@ -0,0 +1,68 @@
+// File: internal/repository/postgres/advisor_repository_impl.go
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type advisorRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewAdvisorRepository(db *gorm.DB, timeouts QueryTimeouts) repository.AdvisorRepository {
+	return &advisorRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *advisorRepositoryImpl) Create(ctx context.Context, assignment *entity.AdvisorAssignment) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(assignment).Error)
+}
+
+func (r *advisorRepositoryImpl) FindCurrent(ctx context.Context, studentID uuid.UUID) (*entity.AdvisorAssignment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var assignment entity.AdvisorAssignment
+	if err := conn(ctx, r.db).First(&assignment, "student_id = ? AND ended_at IS NULL", studentID).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &assignment, nil
+}
+
+func (r *advisorRepositoryImpl) FindByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.AdvisorAssignment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var assignments []*entity.AdvisorAssignment
+	err := conn(ctx, r.db).Where("student_id = ?", studentID).
+		Order("started_at DESC, created_at DESC, id DESC").Find(&assignments).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return assignments, nil
+}
+
+func (r *advisorRepositoryImpl) End(ctx context.Context, studentID uuid.UUID, endedAt time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.AdvisorAssignment{}).
+		Where("student_id = ? AND ended_at IS NULL", studentID).
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// File: internal/repository/postgres/audit_repository_impl.go
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type auditRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewAuditRepository(db *gorm.DB, timeouts QueryTimeouts) repository.AuditRepository {
+	return &auditRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *auditRepositoryImpl) Create(ctx context.Context, entry *entity.AuditLog) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(entry).Error)
+}
+
+// LatestHash reads from the primary: the next entry is chained to it.
+func (r *auditRepositoryImpl) LatestHash(ctx context.Context) (string, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var entry entity.AuditLog
+	err := conn(ctx, r.db).Select("hash").Order("id DESC").Take(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return entry.Hash, translateError(err)
+}
+
+func (r *auditRepositoryImpl) FindAll(ctx context.Context, filter repository.AuditFilter, page, pageSize int) ([]*entity.AuditLog, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	db := onReplica(conn(ctx, r.db)).Model(&entity.AuditLog{})
+	if filter.EntityType != "" {
+		db = db.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		db = db.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.ActorID != nil {
+		db = db.Where("actor_id = ?", *filter.ActorID)
+	}
+	// Entries are written with UTC timestamps.
+	if !filter.From.IsZero() {
+		db = db.Where("created_at >= ?", filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		db = db.Where("created_at < ?", filter.To.UTC())
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	var entries []*entity.AuditLog
+	err := db.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&entries).Error
+	if err != nil {
+		return nil, 0, translateError(err)
+	}
+	return entries, total, nil
+}
+
+func (r *auditRepositoryImpl) FindAfter(ctx context.Context, afterID int64, limit int) ([]*entity.AuditLog, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var entries []*entity.AuditLog
+	err := onReplica(conn(ctx, r.db)).Where("id > ?", afterID).Order("id").Limit(limit).Find(&entries).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+// File: internal/repository/postgres/course_repository_impl.go
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type courseRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewCourseRepository(db *gorm.DB, timeouts QueryTimeouts) repository.CourseRepository {
+	return &courseRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *courseRepositoryImpl) Create(ctx context.Context, course *entity.Course) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(course).Error)
+}
+
+func (r *courseRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.Course, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var course entity.Course
+	if err := conn(ctx, r.db).First(&course, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &course, nil
+}
+
+func (r *courseRepositoryImpl) FindByCode(ctx context.Context, code string) (*entity.Course, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var course entity.Course
+	if err := conn(ctx, r.db).First(&course, "code = ?", code).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &course, nil
+}
+
+func (r *courseRepositoryImpl) FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Course, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var courses []*entity.Course
+	var total int64
+
+	db := listScope(ctx, r.db, &entity.Course{}, query.Courses, spec)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	offset := (page - 1) * pageSize
+	db = applySelect(applyOrder(db, query.Courses, spec), spec)
+	if err := db.Offset(offset).Limit(pageSize).Find(&courses).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	return courses, total, nil
+}
+
+func (r *courseRepositoryImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.Course{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(withVersionBump(changes))
+	return checkVersioned(result)
+}
+
+func (r *courseRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("id = ? AND version = ?", id, version).Delete(&entity.Course{})
+	return checkVersioned(result)
+}
+
+func (r *courseRepositoryImpl) FindDeleted(ctx context.Context, page, pageSize int) ([]*entity.Course, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	return findDeleted[entity.Course](ctx, r.db, page, pageSize)
+}
+
+func (r *courseRepositoryImpl) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Course, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return findDeletedByID[entity.Course](ctx, r.db, id)
+}
+
+func (r *courseRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return restore[entity.Course](ctx, r.db, id)
+}
+
+func (r *courseRepositoryImpl) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return purge[entity.Course](ctx, r.db, id, deletedBefore)
+}
+
+func (r *courseRepositoryImpl) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	return purgeDeleted[entity.Course](ctx, r.db, deletedBefore)
+}
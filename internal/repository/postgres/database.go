@@ -0,0 +1,52 @@
+// File: internal/repository/postgres/database.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ReplicaResolver names the dbresolver configuration holding the read
+// replicas. No global resolver is registered, so statements stay on the
+// primary unless they opt in with onReplica.
+const ReplicaResolver = "replicas"
+
+// QueryTimeouts bounds how long a statement may run, by query class:
+// Default covers point lookups and writes, List the paginated list queries
+// and Report the streaming exports. Zero disables the bound.
+type QueryTimeouts struct {
+	Default time.Duration
+	List    time.Duration
+	Report  time.Duration
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// RegisterReplicas routes statements marked with onReplica to the given
+// connection pools, chosen at random per statement.
+func RegisterReplicas(db *gorm.DB, replicas []*sql.DB) error {
+	if len(replicas) == 0 {
+		return nil
+	}
+	dialectors := make([]gorm.Dialector, 0, len(replicas))
+	for _, replica := range replicas {
+		dialectors = append(dialectors, postgres.New(postgres.Config{Conn: replica}))
+	}
+	return db.Use(dbresolver.Register(dbresolver.Config{Replicas: dialectors}, ReplicaResolver))
+}
+
+// onReplica lets a read run on a replica. Only use it where replication
+// lag is acceptable: never right after a write the caller expects to see.
+func onReplica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(ReplicaResolver))
+}
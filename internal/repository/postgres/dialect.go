@@ -0,0 +1,85 @@
+// File: internal/repository/postgres/dialect.go
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"gorm.io/gorm"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// The repositories also run on SQLite (see repository/sqlite). Queries are
+// kept portable; the few differences between the two databases live here.
+
+// ilike returns a case-insensitive `column LIKE ?` condition. SQLite has no
+// ILIKE, but its LIKE ignores ASCII case; it needs the backslash escape
+// spelled out to match Postgres.
+func ilike(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "sqlite" {
+		return column + ` LIKE ? ESCAPE '\'`
+	}
+	return column + " ILIKE ?"
+}
+
+// timestamp prepares t for comparison with a stored timestamp. SQLite
+// compares timestamps as text, and the service writes them in UTC there.
+func timestamp(db *gorm.DB, t time.Time) time.Time {
+	if db.Dialector.Name() == "sqlite" {
+		return t.UTC()
+	}
+	return t
+}
+
+// translateSQLiteError maps SQLite constraint and locking errors to the
+// same domain errors translateError produces for Postgres.
+func translateSQLiteError(err error, liteErr *gosqlite.Error) error {
+	switch liteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		field := sqliteConstraintField(liteErr.Error())
+		return &apperror.Error{
+			Kind:    apperror.KindConflict,
+			Message: fmt.Sprintf("%s already exists", field),
+			Field:   field,
+			Err:     err,
+		}
+	case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		return apperror.Wrap(apperror.KindValidation, "referenced record does not exist", err)
+	case sqlite3.SQLITE_CONSTRAINT_CHECK:
+		return apperror.Wrap(apperror.KindValidation, "value violates a check constraint", err)
+	}
+	if isSQLiteBusy(liteErr) {
+		return apperror.Unavailable("database is busy", err)
+	}
+	return err
+}
+
+// isSQLiteBusy reports a lock held by another connection, including the
+// extended BUSY and LOCKED codes.
+func isSQLiteBusy(liteErr *gosqlite.Error) bool {
+	primary := liteErr.Code() & 0xff
+	return primary == sqlite3.SQLITE_BUSY || primary == sqlite3.SQLITE_LOCKED
+}
+
+// sqliteConstraintField derives the field from a message such as
+// "UNIQUE constraint failed: students.nim (2067)". Composite constraints
+// list every column; they are joined with "_" like the Postgres
+// constraint names, e.g. student_id_course_id_academic_year_semester.
+func sqliteConstraintField(message string) string {
+	_, columns, ok := strings.Cut(message, "constraint failed: ")
+	if !ok {
+		return "record"
+	}
+	if i := strings.LastIndex(columns, " ("); i >= 0 {
+		columns = columns[:i]
+	}
+	var names []string
+	for _, column := range strings.Split(columns, ", ") {
+		_, name, _ := strings.Cut(column, ".")
+		names = append(names, name)
+	}
+	return strings.Join(names, "_")
+}
@@ -0,0 +1,203 @@
+// File: internal/repository/postgres/enrollment_repository_impl.go
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type enrollmentRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewEnrollmentRepository(db *gorm.DB, timeouts QueryTimeouts) repository.EnrollmentRepository {
+	return &enrollmentRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *enrollmentRepositoryImpl) Create(ctx context.Context, enrollment *entity.Enrollment) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(enrollment).Error)
+}
+
+func (r *enrollmentRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var enrollment entity.Enrollment
+	if err := conn(ctx, r.db).First(&enrollment, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &enrollment, nil
+}
+
+func (r *enrollmentRepositoryImpl) FindByStudentCourse(ctx context.Context, studentID, courseID uuid.UUID, academicYear string, semester int) (*entity.Enrollment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var enrollment entity.Enrollment
+	err := conn(ctx, r.db).
+		Where("student_id = ? AND course_id = ? AND academic_year = ? AND semester = ?", studentID, courseID, academicYear, semester).
+		First(&enrollment).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &enrollment, nil
+}
+
+func (r *enrollmentRepositoryImpl) FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Enrollment, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var enrollments []*entity.Enrollment
+	var total int64
+
+	db := listScope(ctx, r.db, &entity.Enrollment{}, query.Enrollments, spec)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	offset := (page - 1) * pageSize
+	db = applySelect(applyOrder(db, query.Enrollments, spec), spec)
+	if err := db.Offset(offset).Limit(pageSize).Find(&enrollments).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	return enrollments, total, nil
+}
+
+func (r *enrollmentRepositoryImpl) FindPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Enrollment], error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	db := listScope(ctx, r.db, &entity.Enrollment{}, query.Enrollments, spec)
+	return findPage[entity.Enrollment](applySelect(db, spec), page)
+}
+
+func (r *enrollmentRepositoryImpl) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var total int64
+	err := listScope(ctx, r.db, &entity.Enrollment{}, query.Enrollments, spec).Count(&total).Error
+	return total, translateError(err)
+}
+
+// CountActiveByCourse counts the seats taken in a course for one term.
+// Dropped enrollments free their seat.
+func (r *enrollmentRepositoryImpl) CountActiveByCourse(ctx context.Context, courseID uuid.UUID, academicYear string, semester int, krs []string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var count int64
+	db := conn(ctx, r.db).Model(&entity.Enrollment{}).
+		Where("enrollments.course_id = ? AND enrollments.academic_year = ? AND enrollments.semester = ? AND enrollments.status <> ?", courseID, academicYear, semester, "dropped")
+	if krs != nil {
+		db = db.Where(`(EXISTS (SELECT 1 FROM krs_submissions k WHERE k.student_id = enrollments.student_id
+			AND k.academic_year = enrollments.academic_year AND k.semester = enrollments.semester AND k.status IN ?)
+			OR EXISTS (SELECT 1 FROM waitlist_entries w WHERE w.enrollment_id = enrollments.id AND w.status = ?))`,
+			krs, entity.WaitlistPromoted)
+	}
+	err := db.Count(&count).Error
+	return count, translateError(err)
+}
+
+func (r *enrollmentRepositoryImpl) FindActiveByStudent(ctx context.Context, studentID uuid.UUID, academicYear string, semester int) ([]*entity.Enrollment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var enrollments []*entity.Enrollment
+	err := conn(ctx, r.db).
+		Where("student_id = ? AND academic_year = ? AND semester = ? AND status <> ?", studentID, academicYear, semester, "dropped").
+		Order("created_at, id").Find(&enrollments).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return enrollments, nil
+}
+
+func (r *enrollmentRepositoryImpl) Stream(ctx context.Context, spec query.Spec, fn func(*entity.Enrollment) error) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	db := listScope(ctx, r.db, &entity.Enrollment{}, query.Enrollments, spec)
+
+	rows, err := applyOrder(db, query.Enrollments, spec).Rows()
+	if err != nil {
+		return translateError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var enrollment entity.Enrollment
+		if err := r.db.ScanRows(rows, &enrollment); err != nil {
+			return translateError(err)
+		}
+		if err := fn(&enrollment); err != nil {
+			return err
+		}
+	}
+	return translateError(rows.Err())
+}
+
+func (r *enrollmentRepositoryImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.Enrollment{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(withVersionBump(changes))
+	return checkVersioned(result)
+}
+
+func (r *enrollmentRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("id = ? AND version = ?", id, version).Delete(&entity.Enrollment{})
+	return checkVersioned(result)
+}
+
+func (r *enrollmentRepositoryImpl) FindDeleted(ctx context.Context, page, pageSize int) ([]*entity.Enrollment, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	return findDeleted[entity.Enrollment](ctx, r.db, page, pageSize)
+}
+
+func (r *enrollmentRepositoryImpl) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return findDeletedByID[entity.Enrollment](ctx, r.db, id)
+}
+
+func (r *enrollmentRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return restore[entity.Enrollment](ctx, r.db, id)
+}
+
+func (r *enrollmentRepositoryImpl) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return purge[entity.Enrollment](ctx, r.db, id, deletedBefore)
+}
+
+func (r *enrollmentRepositoryImpl) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	return purgeDeleted[entity.Enrollment](ctx, r.db, deletedBefore)
+}
@@ -0,0 +1,94 @@
+// File: internal/repository/postgres/errors.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgQueryCanceled       = "57014"
+)
+
+// translateError converts driver errors into domain errors.
+// gorm.ErrRecordNotFound becomes repository.ErrNotFound; anything it does
+// not recognise is returned as is.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return repository.ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgUniqueViolation:
+			field := constraintField(pgErr.TableName, pgErr.ConstraintName)
+			return &apperror.Error{
+				Kind:    apperror.KindConflict,
+				Message: fmt.Sprintf("%s already exists", field),
+				Field:   field,
+				Err:     err,
+			}
+		case pgErr.Code == pgForeignKeyViolation:
+			return apperror.Wrap(apperror.KindValidation, "referenced record does not exist", err)
+		case pgErr.Code == pgCheckViolation:
+			return apperror.Wrap(apperror.KindValidation, "value violates a check constraint", err)
+		case pgErr.Code == pgQueryCanceled:
+			// Raised by the server-side statement_timeout.
+			return apperror.Unavailable("query timed out", err)
+		case strings.HasPrefix(pgErr.Code, "08"), strings.HasPrefix(pgErr.Code, "57P"):
+			// Connection exceptions and operator intervention (shutdown, ...)
+			return apperror.Unavailable("database unavailable", err)
+		}
+		return err
+	}
+
+	var liteErr *gosqlite.Error
+	if errors.As(err, &liteErr) {
+		return translateSQLiteError(err, liteErr)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperror.Unavailable("query timed out", err)
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) {
+		return apperror.Unavailable("database unavailable", err)
+	}
+
+	return err
+}
+
+// constraintField derives the column name from a unique constraint, handling
+// both the GORM naming (idx_students_nim, uni_students_nim) and the
+// Postgres default used by the SQL migrations (students_nim_key).
+func constraintField(table, constraint string) string {
+	name := constraint
+	name = strings.TrimPrefix(name, "idx_")
+	name = strings.TrimPrefix(name, "uni_")
+	name = strings.TrimSuffix(name, "_key")
+	if table != "" {
+		name = strings.TrimPrefix(name, table+"_")
+	}
+	if name == "" {
+		return "record"
+	}
+	return name
+}
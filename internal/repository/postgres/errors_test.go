@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	plain := errors.New("syntax error")
+	tests := []struct {
+		name      string
+		err       error
+		wantKind  apperror.Kind
+		wantField string
+		// same reports that err is returned untouched.
+		same bool
+		// is, when set, is the sentinel err is replaced by.
+		is error
+	}{
+		{name: "nil", err: nil, same: true},
+		{name: "record not found", err: gorm.ErrRecordNotFound, wantKind: apperror.KindNotFound, is: repository.ErrNotFound},
+		{name: "unknown error", err: plain, wantKind: apperror.KindInternal, same: true},
+		{
+			name:      "unique violation",
+			err:       &pgconn.PgError{Code: pgUniqueViolation, TableName: "students", ConstraintName: "idx_students_nim"},
+			wantKind:  apperror.KindConflict,
+			wantField: "nim",
+		},
+		{
+			name:      "wrapped unique violation",
+			err:       fmt.Errorf("create: %w", &pgconn.PgError{Code: pgUniqueViolation, TableName: "students", ConstraintName: "students_email_key"}),
+			wantKind:  apperror.KindConflict,
+			wantField: "email",
+		},
+		{name: "foreign key violation", err: &pgconn.PgError{Code: pgForeignKeyViolation}, wantKind: apperror.KindValidation},
+		{name: "check violation", err: &pgconn.PgError{Code: pgCheckViolation}, wantKind: apperror.KindValidation},
+		{name: "statement timeout", err: &pgconn.PgError{Code: pgQueryCanceled}, wantKind: apperror.KindUnavailable},
+		{name: "context deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), wantKind: apperror.KindUnavailable},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, wantKind: apperror.KindUnavailable},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, wantKind: apperror.KindUnavailable},
+		{name: "other server error", err: &pgconn.PgError{Code: "42601"}, wantKind: apperror.KindInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translateError(tt.err)
+			if tt.same {
+				if got != tt.err {
+					t.Errorf("translateError() = %v, want the error untouched", got)
+				}
+				return
+			}
+			if kind := apperror.KindOf(got); kind != tt.wantKind {
+				t.Errorf("kind = %v, want %v", kind, tt.wantKind)
+			}
+			if field := apperror.FieldOf(got); field != tt.wantField {
+				t.Errorf("field = %q, want %q", field, tt.wantField)
+			}
+			if tt.is != nil {
+				if got != tt.is {
+					t.Errorf("translateError() = %v, want %v", got, tt.is)
+				}
+				return
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("translateError() = %v does not wrap %v", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestConstraintField(t *testing.T) {
+	tests := []struct {
+		table, constraint string
+		want              string
+	}{
+		{table: "students", constraint: "idx_students_nim", want: "nim"},
+		{table: "students", constraint: "uni_students_email", want: "email"},
+		{table: "lecturers", constraint: "lecturers_nip_key", want: "nip"},
+		{table: "", constraint: "idx_courses_code", want: "courses_code"},
+		{table: "users", constraint: "", want: "record"},
+	}
+	for _, tt := range tests {
+		if got := constraintField(tt.table, tt.constraint); got != tt.want {
+			t.Errorf("constraintField(%q, %q) = %q, want %q", tt.table, tt.constraint, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// File: internal/repository/postgres/health.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/health"
+)
+
+// PingCheck verifies that a connection to the database can be used.
+func PingCheck(db *sql.DB) health.CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// MigrationCheck verifies that golang-migrate's schema_migrations table is
+// at expected and not left dirty by a failed migration.
+func MigrationCheck(db *sql.DB, expected uint) health.CheckFunc {
+	return func(ctx context.Context) error {
+		var (
+			version uint
+			dirty   bool
+		)
+		err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("no migrations applied, expected version %d", expected)
+		case err != nil:
+			return fmt.Errorf("failed to read migration version: %w", err)
+		case dirty:
+			return fmt.Errorf("migration %d is dirty", version)
+		case version != expected:
+			return fmt.Errorf("schema is at version %d, expected %d", version, expected)
+		}
+		return nil
+	}
+}
+
+// PoolCheck fails when every connection the pool may open is in use, so
+// an exhausted replica is taken out of rotation instead of queueing more
+// requests.
+func PoolCheck(db *sql.DB) health.CheckFunc {
+	return func(ctx context.Context) error {
+		stats := db.Stats()
+		if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections {
+			return fmt.Errorf("connection pool exhausted: %d of %d in use", stats.InUse, stats.MaxOpenConnections)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// migrationsTable is a database/sql driver whose only table is
+// schema_migrations, holding rows. err, when set, fails every query.
+type migrationsTable struct {
+	rows [][]driver.Value
+	err  error
+}
+
+func (m *migrationsTable) Connect(context.Context) (driver.Conn, error) { return m, nil }
+func (m *migrationsTable) Driver() driver.Driver                        { return m }
+func (m *migrationsTable) Open(string) (driver.Conn, error)             { return m, nil }
+func (m *migrationsTable) Prepare(string) (driver.Stmt, error)          { return nil, driver.ErrSkip }
+func (m *migrationsTable) Close() error                                 { return nil }
+func (m *migrationsTable) Begin() (driver.Tx, error)                    { return nil, driver.ErrSkip }
+
+func (m *migrationsTable) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &migrationRows{rows: m.rows}, nil
+}
+
+type migrationRows struct {
+	rows [][]driver.Value
+}
+
+func (r *migrationRows) Columns() []string { return []string{"version", "dirty"} }
+func (r *migrationRows) Close() error      { return nil }
+
+func (r *migrationRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestMigrationCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   *migrationsTable
+		wantErr string
+	}{
+		{name: "at the expected version", table: &migrationsTable{rows: [][]driver.Value{{int64(9), false}}}},
+		{name: "behind", table: &migrationsTable{rows: [][]driver.Value{{int64(8), false}}}, wantErr: "schema is at version 8, expected 9"},
+		{name: "ahead", table: &migrationsTable{rows: [][]driver.Value{{int64(10), false}}}, wantErr: "schema is at version 10, expected 9"},
+		{name: "dirty", table: &migrationsTable{rows: [][]driver.Value{{int64(9), true}}}, wantErr: "migration 9 is dirty"},
+		{name: "nothing applied", table: &migrationsTable{}, wantErr: "no migrations applied, expected version 9"},
+		{
+			name:    "no migrations table",
+			table:   &migrationsTable{err: errors.New(`relation "schema_migrations" does not exist`)},
+			wantErr: `failed to read migration version: relation "schema_migrations" does not exist`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := sql.OpenDB(tt.table)
+			defer db.Close()
+
+			err := MigrationCheck(db, 9)(context.Background())
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("MigrationCheck() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Errorf("MigrationCheck() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPoolCheck(t *testing.T) {
+	ctx := context.Background()
+	db := sql.OpenDB(&migrationsTable{})
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	check := PoolCheck(db)
+
+	if err := check(ctx); err != nil {
+		t.Fatalf("PoolCheck() with a free connection = %v", err)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := check(ctx); err == nil || err.Error() != "connection pool exhausted: 1 of 1 in use" {
+		t.Errorf("PoolCheck() with every connection in use = %v", err)
+	}
+	conn.Close()
+	if err := check(ctx); err != nil {
+		t.Errorf("PoolCheck() after the connection returned = %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// File: internal/repository/postgres/keyset.go
+package postgres
+
+import (
+	"slices"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// newestFirst is the order of the created_at lists. The id tie-breaker
+// makes it total, so offset pages do not shuffle rows that share a
+// timestamp and keyset pages can resume exactly where they stopped.
+const newestFirst = "created_at DESC, id DESC"
+
+// findPage reads one keyset page of query, which must select from a table
+// with created_at and id columns. It fetches one row beyond the limit to
+// learn whether the list continues. A page before a cursor is read in
+// ascending order and flipped, so both directions use the same index.
+func findPage[T any](query *gorm.DB, page repository.KeysetPage) (repository.Window[T], error) {
+	switch {
+	case page.Before != nil:
+		query = query.Where("(created_at, id) > (?, ?)", page.Before.CreatedAt, page.Before.ID).Order("created_at ASC, id ASC")
+	case page.After != nil:
+		query = query.Where("(created_at, id) < (?, ?)", page.After.CreatedAt, page.After.ID).Order(newestFirst)
+	default:
+		query = query.Order(newestFirst)
+	}
+
+	var rows []*T
+	if err := query.Limit(page.Limit + 1).Find(&rows).Error; err != nil {
+		return repository.Window[T]{}, translateError(err)
+	}
+	more := len(rows) > page.Limit
+	rows = rows[:min(len(rows), page.Limit)]
+
+	if page.Before != nil {
+		slices.Reverse(rows)
+		return repository.Window[T]{Items: rows, HasPrev: more, HasNext: true}, nil
+	}
+	return repository.Window[T]{Items: rows, HasPrev: page.After != nil, HasNext: more}, nil
+}
@@ -0,0 +1,115 @@
+// File: internal/repository/postgres/krs_repository_impl.go
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type krsRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewKRSRepository(db *gorm.DB, timeouts QueryTimeouts) repository.KRSRepository {
+	return &krsRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *krsRepositoryImpl) Create(ctx context.Context, submission *entity.KRSSubmission) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Omit("Reviews").Create(submission).Error)
+}
+
+func (r *krsRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.KRSSubmission, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var submission entity.KRSSubmission
+	if err := conn(ctx, r.db).First(&submission, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &submission, nil
+}
+
+func (r *krsRepositoryImpl) FindByStudentTerm(ctx context.Context, studentID uuid.UUID, academicYear string, semester int) (*entity.KRSSubmission, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var submission entity.KRSSubmission
+	err := conn(ctx, r.db).
+		Where("student_id = ? AND academic_year = ? AND semester = ?", studentID, academicYear, semester).
+		First(&submission).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &submission, nil
+}
+
+func (r *krsRepositoryImpl) FindByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.KRSSubmission, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var submissions []*entity.KRSSubmission
+	err := conn(ctx, r.db).Where("student_id = ?", studentID).
+		Order("academic_year DESC, semester DESC").Find(&submissions).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return submissions, nil
+}
+
+func (r *krsRepositoryImpl) FindSubmitted(ctx context.Context, advisorID uuid.UUID, page, pageSize int) ([]*entity.KRSSubmission, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var submissions []*entity.KRSSubmission
+	var total int64
+
+	db := conn(ctx, r.db).Model(&entity.KRSSubmission{}).
+		Where("advisor_id = ? AND status = ?", advisorID, entity.KRSSubmitted)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	offset := (page - 1) * pageSize
+	if err := db.Order("submitted_at, id").Offset(offset).Limit(pageSize).Find(&submissions).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+	return submissions, total, nil
+}
+
+func (r *krsRepositoryImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.KRSSubmission{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(withVersionBump(changes))
+	return checkVersioned(result)
+}
+
+func (r *krsRepositoryImpl) AddReview(ctx context.Context, review *entity.KRSReview) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(review).Error)
+}
+
+func (r *krsRepositoryImpl) FindReviews(ctx context.Context, submissionID uuid.UUID) ([]*entity.KRSReview, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var reviews []*entity.KRSReview
+	err := conn(ctx, r.db).Where("submission_id = ?", submissionID).
+		Order("created_at, id").Find(&reviews).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return reviews, nil
+}
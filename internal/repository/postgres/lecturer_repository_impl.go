@@ -3,65 +3,165 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
 	"gorm.io/gorm"
 )
 
 type lecturerRepositoryImpl struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts QueryTimeouts
 }
 
-func NewLecturerRepository(db *gorm.DB) repository.LecturerRepository {
-	return &lecturerRepositoryImpl{db: db}
+func NewLecturerRepository(db *gorm.DB, timeouts QueryTimeouts) repository.LecturerRepository {
+	return &lecturerRepositoryImpl{db: db, timeouts: timeouts}
 }
 
 func (r *lecturerRepositoryImpl) Create(ctx context.Context, lecturer *entity.Lecturer) error {
-	return r.db.WithContext(ctx).Create(lecturer).Error
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(lecturer).Error)
 }
 
 func (r *lecturerRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.Lecturer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
 	var lecturer entity.Lecturer
-	if err := r.db.WithContext(ctx).First(&lecturer, "id = ?", id).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, r.db).First(&lecturer, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &lecturer, nil
 }
 
-func (r *lecturerRepositoryImpl) FindAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Lecturer, int64, error) {
+func (r *lecturerRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Lecturer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var lecturer entity.Lecturer
+	if err := conn(ctx, r.db).First(&lecturer, "user_id = ?", userID).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &lecturer, nil
+}
+
+func (r *lecturerRepositoryImpl) FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Lecturer, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
 	var lecturers []*entity.Lecturer
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Lecturer{})
+	db := listScope(ctx, r.db, &entity.Lecturer{}, query.Lecturers, spec)
 
-	if department, ok := filters["department"].(string); ok && department != "" {
-		query = query.Where("department ILIKE ?", "%"+department+"%")
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
 	}
-	if status, ok := filters["status"].(string); ok && status != "" {
-		query = query.Where("status = ?", status)
-	}
-	if search, ok := filters["search"].(string); ok && search != "" {
-		query = query.Where("name ILIKE ? OR nip ILIKE ?", "%"+search+"%", "%"+search+"%")
+
+	offset := (page - 1) * pageSize
+	db = applySelect(applyOrder(db, query.Lecturers, spec), spec)
+	if err := db.Offset(offset).Limit(pageSize).Find(&lecturers).Error; err != nil {
+		return nil, 0, translateError(err)
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	return lecturers, total, nil
+}
+
+func (r *lecturerRepositoryImpl) FindPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Lecturer], error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	db := listScope(ctx, r.db, &entity.Lecturer{}, query.Lecturers, spec)
+	return findPage[entity.Lecturer](applySelect(db, spec), page)
+}
+
+func (r *lecturerRepositoryImpl) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var total int64
+	err := listScope(ctx, r.db, &entity.Lecturer{}, query.Lecturers, spec).Count(&total).Error
+	return total, translateError(err)
+}
+
+func (r *lecturerRepositoryImpl) Stream(ctx context.Context, spec query.Spec, fn func(*entity.Lecturer) error) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	db := listScope(ctx, r.db, &entity.Lecturer{}, query.Lecturers, spec)
+
+	rows, err := applyOrder(db, query.Lecturers, spec).Rows()
+	if err != nil {
+		return translateError(err)
 	}
+	defer rows.Close()
 
-	offset := (page - 1) * pageSize
-	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&lecturers).Error; err != nil {
-		return nil, 0, err
+	for rows.Next() {
+		var lecturer entity.Lecturer
+		if err := r.db.ScanRows(rows, &lecturer); err != nil {
+			return translateError(err)
+		}
+		if err := fn(&lecturer); err != nil {
+			return err
+		}
 	}
+	return translateError(rows.Err())
+}
 
-	return lecturers, total, nil
+func (r *lecturerRepositoryImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.Lecturer{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(withVersionBump(changes))
+	return checkVersioned(result)
 }
 
-func (r *lecturerRepositoryImpl) Update(ctx context.Context, lecturer *entity.Lecturer) error {
-	return r.db.WithContext(ctx).Save(lecturer).Error
+func (r *lecturerRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("id = ? AND version = ?", id, version).Delete(&entity.Lecturer{})
+	return checkVersioned(result)
+}
+
+func (r *lecturerRepositoryImpl) FindDeleted(ctx context.Context, page, pageSize int) ([]*entity.Lecturer, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	return findDeleted[entity.Lecturer](ctx, r.db, page, pageSize)
 }
 
-func (r *lecturerRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Lecturer{}, "id = ?", id).Error
+func (r *lecturerRepositoryImpl) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Lecturer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return findDeletedByID[entity.Lecturer](ctx, r.db, id)
+}
+
+func (r *lecturerRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return restore[entity.Lecturer](ctx, r.db, id)
+}
+
+func (r *lecturerRepositoryImpl) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return purge[entity.Lecturer](ctx, r.db, id, deletedBefore)
+}
+
+func (r *lecturerRepositoryImpl) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	return purgeDeleted[entity.Lecturer](ctx, r.db, deletedBefore)
 }
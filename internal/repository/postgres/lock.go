@@ -0,0 +1,50 @@
+// File: internal/repository/postgres/lock.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Advisory lock keys of the background workers that must run on one
+// instance at a time.
+const (
+	outboxRelayLock       int64 = 0x6f7574626f78   // "outbox"
+	webhookDispatcherLock int64 = 0x776562686f6f6b // "webhook"
+)
+
+// tryAdvisoryLock takes a session-level advisory lock on a connection of
+// its own, held until release. SQLite has a single writer process, so
+// there is nothing to coordinate there.
+func tryAdvisoryLock(ctx context.Context, db *gorm.DB, key int64) (func(), bool, error) {
+	if db.Dialector.Name() == "sqlite" {
+		return func() {}, true, nil
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, false, err
+	}
+	c, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, translateError(err)
+	}
+	var ok bool
+	if err := c.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&ok); err != nil {
+		c.Close()
+		return nil, false, fmt.Errorf("failed to take advisory lock: %w", translateError(err))
+	}
+	if !ok {
+		c.Close()
+		return nil, false, nil
+	}
+	release := func() {
+		// Closing the connection would release the lock too, but the pool
+		// keeps it open.
+		_, _ = c.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		c.Close()
+	}
+	return release, true, nil
+}
@@ -0,0 +1,90 @@
+// File: internal/repository/postgres/outbox_repository_impl.go
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type outboxRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewOutboxRepository(db *gorm.DB, timeouts QueryTimeouts) repository.OutboxRepository {
+	return &outboxRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *outboxRepositoryImpl) Create(ctx context.Context, event *entity.OutboxEvent) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(event).Error)
+}
+
+// FindDue reads from the primary: a replica may not have seen an event's
+// publication yet, which would publish it twice.
+func (r *outboxRepositoryImpl) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var events []*entity.OutboxEvent
+	err := conn(ctx, r.db).
+		Where("published_at IS NULL AND dead_at IS NULL").
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now.UTC()).
+		Where(`NOT EXISTS (SELECT 1 FROM outbox_events earlier
+			WHERE earlier.ordering_key = outbox_events.ordering_key AND earlier.id < outbox_events.id
+			AND earlier.published_at IS NULL AND earlier.dead_at IS NULL)`).
+		Order("id").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return events, nil
+}
+
+func (r *outboxRepositoryImpl) MarkPublished(ctx context.Context, id int64, at time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Model(&entity.OutboxEvent{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"published_at": at.UTC(), "next_attempt_at": nil}).Error)
+}
+
+func (r *outboxRepositoryImpl) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Model(&entity.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt.UTC(),
+	}).Error)
+}
+
+func (r *outboxRepositoryImpl) MarkDead(ctx context.Context, id int64, lastError string, at time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Model(&entity.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      lastError,
+		"next_attempt_at": nil,
+		"dead_at":         at.UTC(),
+	}).Error)
+}
+
+func (r *outboxRepositoryImpl) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("published_at < ?", before.UTC()).Delete(&entity.OutboxEvent{})
+	return result.RowsAffected, translateError(result.Error)
+}
+
+func (r *outboxRepositoryImpl) TryLock(ctx context.Context) (func(), bool, error) {
+	return tryAdvisoryLock(ctx, r.db, outboxRelayLock)
+}
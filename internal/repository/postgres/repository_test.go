@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/haninhammoud01/go-academic-service/database"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/repotest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestRepositoryContract needs a Postgres database, given as a DSN in
+// TEST_DATABASE_DSN, e.g.
+//
+//	TEST_DATABASE_DSN="host=localhost user=postgres password=postgres dbname=academic_test sslmode=disable"
+//
+// The tests migrate a schema of their own and drop it afterwards.
+func TestRepositoryContract(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db := openTestSchema(t, dsn)
+
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		if err := db.Exec("TRUNCATE users, students, lecturers, courses, enrollments, audit_logs, outbox_events, webhook_subscriptions, webhook_deliveries CASCADE").Error; err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		var timeouts QueryTimeouts
+		return repotest.Repositories{
+			Users:       NewUserRepository(db, timeouts),
+			Students:    NewStudentRepository(db, timeouts),
+			Lecturers:   NewLecturerRepository(db, timeouts),
+			Courses:     NewCourseRepository(db, timeouts),
+			Enrollments: NewEnrollmentRepository(db, timeouts),
+			Search:      NewSearchRepository(db, timeouts),
+			Audit:       NewAuditRepository(db, timeouts),
+			Outbox:      NewOutboxRepository(db, timeouts),
+			Webhooks:    NewWebhookSubscriptionRepository(db, timeouts),
+			Deliveries:  NewWebhookDeliveryRepository(db, timeouts),
+			SeatBus:     NewSeatChangeBus(db),
+			Waitlists:   NewWaitlistRepository(db, timeouts),
+			Advisors:    NewAdvisorRepository(db, timeouts),
+			KRS:         NewKRSRepository(db, timeouts),
+		}
+	})
+}
+
+// openTestSchema creates a uniquely named schema, applies the SQL
+// migrations to it and returns a connection whose search_path points there.
+func openTestSchema(t *testing.T, dsn string) *gorm.DB {
+	t.Helper()
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatal(err)
+	}
+	schema := "contract_" + hex.EncodeToString(suffix)
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	db, err := gorm.Open(postgres.Open(dsn+" search_path="+schema+",public"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect to %s: %v", schema, err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrations, err := database.ForDriver("postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		migration, err := fs.ReadFile(migrations, file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Exec(string(migration)).Error; err != nil {
+			t.Fatalf("apply %s: %v", file, err)
+		}
+	}
+	return db
+}
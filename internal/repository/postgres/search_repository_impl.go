@@ -0,0 +1,127 @@
+// File: internal/repository/postgres/search_repository_impl.go
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/textsearch"
+	"gorm.io/gorm"
+)
+
+// searchTarget is how one kind of record is searched. Title and subtitle
+// are matched; all three are returned. The names are constants, never
+// request input, so they are spliced into the SQL.
+type searchTarget struct {
+	kind, table, title, subtitle, detail string
+}
+
+var searchTargets = []searchTarget{
+	{repository.SearchStudent, "students", "name", "nim", "major"},
+	{repository.SearchLecturer, "lecturers", "name", "nip", "department"},
+	{repository.SearchCourse, "courses", "name", "code", "department"},
+}
+
+// sqliteSearchCandidates caps the rows SQLite reads per kind before they
+// are ranked in Go.
+const sqliteSearchCandidates = 500
+
+type searchRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+// NewSearchRepository searches with the full-text and trigram indexes of
+// migration 000008 on Postgres. On SQLite, which has neither, it matches
+// folded substrings and ranks in Go, so misspelt terms are not found.
+func NewSearchRepository(db *gorm.DB, timeouts QueryTimeouts) repository.SearchRepository {
+	return &searchRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *searchRepositoryImpl) Search(ctx context.Context, terms []string, kinds []string, limit int) ([]repository.SearchHit, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var targets []searchTarget
+	for _, target := range searchTargets {
+		if slices.Contains(kinds, target.kind) {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 || len(terms) == 0 {
+		return []repository.SearchHit{}, nil
+	}
+
+	if r.db.Dialector.Name() == "sqlite" {
+		return r.searchSQLite(ctx, targets, terms, limit)
+	}
+
+	// Each term matches a word prefix in the tsvector; the whole phrase
+	// also matches near misses through the trigram index.
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+	args := map[string]any{
+		"tsquery": strings.Join(prefixes, " & "),
+		"phrase":  strings.Join(terms, " "),
+		"limit":   limit,
+	}
+
+	selects := make([]string, len(targets))
+	for i, t := range targets {
+		// Must match the index expressions of migration 000008.
+		document := fmt.Sprintf("to_tsvector('simple', search_fold(%s) || ' ' || search_fold(%s))", t.title, t.subtitle)
+		selects[i] = fmt.Sprintf(`SELECT '%s' AS type, id, %s AS title, %s AS subtitle, %s AS detail,
+	ts_rank(%s, to_tsquery('simple', @tsquery)) + word_similarity(@phrase, search_fold(%s)) AS rank
+FROM %s
+WHERE deleted_at IS NULL
+	AND (%s @@ to_tsquery('simple', @tsquery) OR @phrase <%% search_fold(%s))`,
+			t.kind, t.title, t.subtitle, t.detail, document, t.title, t.table, document, t.title)
+	}
+	sql := "SELECT * FROM (" + strings.Join(selects, "\nUNION ALL\n") + ") AS hits ORDER BY rank DESC, title LIMIT @limit"
+
+	hits := []repository.SearchHit{}
+	if err := onReplica(conn(ctx, r.db)).Raw(sql, args).Scan(&hits).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return hits, nil
+}
+
+// searchSQLite reads the rows whose folded title and subtitle contain
+// every term, through the search_fold function the sqlite package
+// registers, and ranks them with textsearch.Score.
+func (r *searchRepositoryImpl) searchSQLite(ctx context.Context, targets []searchTarget, terms []string, limit int) ([]repository.SearchHit, error) {
+	rows := []repository.SearchHit{}
+	for _, t := range targets {
+		query := onReplica(conn(ctx, r.db)).Table(t.table).
+			Select(fmt.Sprintf("'%s' AS type, id, %s AS title, %s AS subtitle, %s AS detail", t.kind, t.title, t.subtitle, t.detail)).
+			Where("deleted_at IS NULL")
+		for _, term := range terms {
+			query = query.Where(fmt.Sprintf("instr(search_fold(%s || ' ' || %s), ?) > 0", t.title, t.subtitle), term)
+		}
+		var found []repository.SearchHit
+		if err := query.Limit(sqliteSearchCandidates).Scan(&found).Error; err != nil {
+			return nil, translateError(err)
+		}
+		rows = append(rows, found...)
+	}
+
+	ranked := rows[:0]
+	for _, row := range rows {
+		if row.Rank = textsearch.Score(terms, row.Title+" "+row.Subtitle); row.Rank > 0 {
+			ranked = append(ranked, row)
+		}
+	}
+	slices.SortStableFunc(ranked, func(a, b repository.SearchHit) int {
+		if c := cmp.Compare(b.Rank, a.Rank); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+	return ranked[:min(limit, len(ranked))], nil
+}
@@ -0,0 +1,93 @@
+// File: internal/repository/postgres/seat_change_bus.go
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// seatChannel is the NOTIFY channel carrying seat changes.
+const seatChannel = "course_seats"
+
+// seatChangeBus uses LISTEN/NOTIFY on Postgres. SQLite serves a single
+// process, so there the changes go straight to its listeners.
+type seatChangeBus struct {
+	db *gorm.DB
+
+	mu        sync.RWMutex
+	listeners map[*func(repository.SeatChange)]struct{}
+}
+
+func NewSeatChangeBus(db *gorm.DB) repository.SeatChangeBus {
+	return &seatChangeBus{db: db, listeners: make(map[*func(repository.SeatChange)]struct{})}
+}
+
+func (b *seatChangeBus) Announce(ctx context.Context, change repository.SeatChange) error {
+	if b.db.Dialector.Name() == "sqlite" {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		for fn := range b.listeners {
+			(*fn)(change)
+		}
+		return nil
+	}
+
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	return translateError(b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", seatChannel, string(payload)).Error)
+}
+
+func (b *seatChangeBus) Listen(ctx context.Context, fn func(repository.SeatChange)) error {
+	if b.db.Dialector.Name() == "sqlite" {
+		b.mu.Lock()
+		b.listeners[&fn] = struct{}{}
+		b.mu.Unlock()
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.listeners, &fn)
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+
+	sqlDB, err := b.db.DB()
+	if err != nil {
+		return err
+	}
+	c, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return translateError(err)
+	}
+	defer c.Close()
+
+	var listenErr error
+	_ = c.Raw(func(driverConn any) error {
+		pgConn := driverConn.(*stdlib.Conn).Conn()
+		if _, err := pgConn.Exec(ctx, "LISTEN "+seatChannel); err != nil {
+			listenErr = fmt.Errorf("failed to listen for seat changes: %w", translateError(err))
+			return driver.ErrBadConn
+		}
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				listenErr = translateError(err)
+				// The connection is still subscribed: keep it out of the pool.
+				return driver.ErrBadConn
+			}
+			var change repository.SeatChange
+			if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+				continue
+			}
+			fn(change)
+		}
+	})
+	return listenErr
+}
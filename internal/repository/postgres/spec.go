@@ -0,0 +1,102 @@
+// File: internal/repository/postgres/spec.go
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The field names in a query.Spec were checked against the resource
+// allowlist when it was parsed. They are still passed as clause.Column so
+// GORM quotes them, and every operand is a bound parameter.
+
+// listScope starts a list query on model, on a replica, narrowed down by
+// the filters and search term of spec.
+func listScope(ctx context.Context, db *gorm.DB, model any, resource *query.Resource, spec query.Spec) *gorm.DB {
+	return applyFilters(onReplica(conn(ctx, db)).Model(model), resource, spec)
+}
+
+// applyFilters adds the filters and search term of spec to db.
+func applyFilters(db *gorm.DB, resource *query.Resource, spec query.Spec) *gorm.DB {
+	for _, filter := range spec.Filters {
+		column := clause.Column{Name: filter.Field}
+		switch filter.Op {
+		case query.Eq:
+			db = db.Where(clause.Eq{Column: column, Value: filter.Value})
+		case query.Ne:
+			db = db.Where(clause.Neq{Column: column, Value: filter.Value})
+		case query.Gt:
+			db = db.Where(clause.Gt{Column: column, Value: filter.Value})
+		case query.Gte:
+			db = db.Where(clause.Gte{Column: column, Value: filter.Value})
+		case query.Lt:
+			db = db.Where(clause.Lt{Column: column, Value: filter.Value})
+		case query.Lte:
+			db = db.Where(clause.Lte{Column: column, Value: filter.Value})
+		case query.In:
+			db = db.Where(clause.IN{Column: column, Values: filter.Value.([]any)})
+		case query.Like:
+			db = db.Where(ilike(db, "?"), column, "%"+filter.Value.(string)+"%")
+		}
+	}
+
+	if spec.Search != "" && len(resource.Search) > 0 {
+		conditions := make([]string, len(resource.Search))
+		args := make([]any, 0, 2*len(resource.Search))
+		for i, field := range resource.Search {
+			conditions[i] = ilike(db, "?")
+			args = append(args, clause.Column{Name: field}, "%"+spec.Search+"%")
+		}
+		db = db.Where(strings.Join(conditions, " OR "), args...)
+	}
+	return db
+}
+
+// applyOrder sorts db by spec, or by the resource default, with id as the
+// final key so that the order is total and pages are stable. The id takes
+// the direction of the last key, which for the newest-first lists matches
+// the keyset index.
+func applyOrder(db *gorm.DB, resource *query.Resource, spec query.Spec) *gorm.DB {
+	sorts := spec.OrderBy(resource)
+	terms := make([]string, 0, len(sorts)+1)
+	columns := make([]any, 0, len(sorts)+1)
+	for _, sort := range sorts {
+		term := "? ASC"
+		if sort.Desc {
+			term = "? DESC"
+		}
+		if resource.Fields[sort.Field].Nullable {
+			// Postgres and SQLite disagree on where NULLs go by default.
+			term += " NULLS LAST"
+		}
+		terms = append(terms, term)
+		columns = append(columns, clause.Column{Name: sort.Field})
+	}
+	if sorts[len(sorts)-1].Desc {
+		terms = append(terms, "? DESC")
+	} else {
+		terms = append(terms, "? ASC")
+	}
+	columns = append(columns, clause.Column{Name: "id"})
+
+	return db.Order(clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(terms, ", "), Vars: columns}})
+}
+
+// applySelect reads only the fields spec asks for, plus id and created_at,
+// which keyset cursors are built from.
+func applySelect(db *gorm.DB, spec query.Spec) *gorm.DB {
+	if len(spec.Fields) == 0 {
+		return db
+	}
+	columns := []string{"id", "created_at"}
+	for _, field := range spec.Fields {
+		if field != "id" && field != "created_at" {
+			columns = append(columns, field)
+		}
+	}
+	return db.Select(columns)
+}
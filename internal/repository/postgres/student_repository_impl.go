@@ -5,76 +5,174 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
 	"gorm.io/gorm"
 )
 
 type studentRepositoryImpl struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts QueryTimeouts
 }
 
-func NewStudentRepository(db *gorm.DB) repository.StudentRepository {
-	return &studentRepositoryImpl{db: db}
+func NewStudentRepository(db *gorm.DB, timeouts QueryTimeouts) repository.StudentRepository {
+	return &studentRepositoryImpl{db: db, timeouts: timeouts}
 }
 
 func (r *studentRepositoryImpl) Create(ctx context.Context, student *entity.Student) error {
-	return r.db.WithContext(ctx).Create(student).Error
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(student).Error)
 }
 
 func (r *studentRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.Student, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
 	var student entity.Student
-	if err := r.db.WithContext(ctx).First(&student, "id = ?", id).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, r.db).First(&student, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &student, nil
 }
 
 func (r *studentRepositoryImpl) FindByNIM(ctx context.Context, nim string) (*entity.Student, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
 	var student entity.Student
-	if err := r.db.WithContext(ctx).First(&student, "nim = ?", nim).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, r.db).First(&student, "nim = ?", nim).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &student, nil
 }
 
-func (r *studentRepositoryImpl) FindAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Student, int64, error) {
+func (r *studentRepositoryImpl) FindAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Student, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
 	var students []*entity.Student
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Student{})
-
-	// Apply filters
-	if major, ok := filters["major"].(string); ok && major != "" {
-		query = query.Where("major ILIKE ?", "%"+major+"%")
-	}
-	if status, ok := filters["status"].(string); ok && status != "" {
-		query = query.Where("status = ?", status)
-	}
-	if search, ok := filters["search"].(string); ok && search != "" {
-		query = query.Where("name ILIKE ? OR nim ILIKE ?", "%"+search+"%", "%"+search+"%")
-	}
+	db := listScope(ctx, r.db, &entity.Student{}, query.Students, spec)
 
 	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
 	}
 
 	// Apply pagination
 	offset := (page - 1) * pageSize
-	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&students).Error; err != nil {
-		return nil, 0, err
+	db = applySelect(applyOrder(db, query.Students, spec), spec)
+	if err := db.Offset(offset).Limit(pageSize).Find(&students).Error; err != nil {
+		return nil, 0, translateError(err)
 	}
 
 	return students, total, nil
 }
 
-func (r *studentRepositoryImpl) Update(ctx context.Context, student *entity.Student) error {
-	return r.db.WithContext(ctx).Save(student).Error
+// FindPage reads one keyset page. Unlike FindAll it never counts: callers
+// that want a total ask Count separately.
+func (r *studentRepositoryImpl) FindPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Student], error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	db := listScope(ctx, r.db, &entity.Student{}, query.Students, spec)
+	return findPage[entity.Student](applySelect(db, spec), page)
+}
+
+func (r *studentRepositoryImpl) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var total int64
+	err := listScope(ctx, r.db, &entity.Student{}, query.Students, spec).Count(&total).Error
+	return total, translateError(err)
+}
+
+// Stream walks every student matching spec row by row so callers can
+// export large result sets without buffering them in memory.
+func (r *studentRepositoryImpl) Stream(ctx context.Context, spec query.Spec, fn func(*entity.Student) error) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	db := listScope(ctx, r.db, &entity.Student{}, query.Students, spec)
+
+	rows, err := applyOrder(db, query.Students, spec).Rows()
+	if err != nil {
+		return translateError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var student entity.Student
+		if err := r.db.ScanRows(rows, &student); err != nil {
+			return translateError(err)
+		}
+		if err := fn(&student); err != nil {
+			return err
+		}
+	}
+	return translateError(rows.Err())
 }
 
-func (r *studentRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Student{}, "id = ?", id).Error
+// Update writes only the given columns, leaving every other field as stored.
+// The version predicate and increment happen in the same statement, so two
+// concurrent writers cannot both succeed against the same version.
+func (r *studentRepositoryImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.Student{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(withVersionBump(changes))
+	return checkVersioned(result)
+}
+
+func (r *studentRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("id = ? AND version = ?", id, version).Delete(&entity.Student{})
+	return checkVersioned(result)
+}
+
+func (r *studentRepositoryImpl) FindDeleted(ctx context.Context, page, pageSize int) ([]*entity.Student, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	return findDeleted[entity.Student](ctx, r.db, page, pageSize)
+}
+
+func (r *studentRepositoryImpl) FindDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Student, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return findDeletedByID[entity.Student](ctx, r.db, id)
+}
+
+func (r *studentRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return restore[entity.Student](ctx, r.db, id)
+}
+
+func (r *studentRepositoryImpl) Purge(ctx context.Context, id uuid.UUID, deletedBefore time.Time) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return purge[entity.Student](ctx, r.db, id, deletedBefore)
+}
+
+func (r *studentRepositoryImpl) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	return purgeDeleted[entity.Student](ctx, r.db, deletedBefore)
 }
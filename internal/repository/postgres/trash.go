@@ -0,0 +1,73 @@
+// File: internal/repository/postgres/trash.go
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// The helpers below implement repository.Trash for any entity with the
+// id, version and deleted_at columns. They run unscoped, so GORM does not
+// add its `deleted_at IS NULL` condition, and select deleted rows
+// explicitly instead.
+
+const recentlyDeletedFirst = "deleted_at DESC, id DESC"
+
+func findDeleted[T any](ctx context.Context, db *gorm.DB, page, pageSize int) ([]*T, int64, error) {
+	query := onReplica(conn(ctx, db)).Unscoped().Model(new(T)).Where("deleted_at IS NOT NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	var rows []*T
+	err := query.Order(recentlyDeletedFirst).Offset((page - 1) * pageSize).Limit(pageSize).Find(&rows).Error
+	if err != nil {
+		return nil, 0, translateError(err)
+	}
+	return rows, total, nil
+}
+
+func findDeletedByID[T any](ctx context.Context, db *gorm.DB, id uuid.UUID) (*T, error) {
+	var row T
+	if err := conn(ctx, db).Unscoped().Where("deleted_at IS NOT NULL").First(&row, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &row, nil
+}
+
+func restore[T any](ctx context.Context, db *gorm.DB, id uuid.UUID) error {
+	result := conn(ctx, db).Unscoped().Model(new(T)).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(withVersionBump(map[string]interface{}{"deleted_at": nil}))
+	return checkFound(result)
+}
+
+func purge[T any](ctx context.Context, db *gorm.DB, id uuid.UUID, deletedBefore time.Time) error {
+	result := conn(ctx, db).Unscoped().
+		Where("id = ? AND deleted_at < ?", id, timestamp(db, deletedBefore)).
+		Delete(new(T))
+	return checkFound(result)
+}
+
+func purgeDeleted[T any](ctx context.Context, db *gorm.DB, deletedBefore time.Time) (int64, error) {
+	result := conn(ctx, db).Unscoped().Where("deleted_at < ?", timestamp(db, deletedBefore)).Delete(new(T))
+	return result.RowsAffected, translateError(result.Error)
+}
+
+// checkFound turns a write that matched no rows into
+// repository.ErrNotFound.
+func checkFound(result *gorm.DB) error {
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// File: internal/repository/postgres/tx_manager.go
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+
+	// maxTxAttempts is how many times a unit of work is tried before a
+	// serialization failure or deadlock is reported to the caller.
+	maxTxAttempts  = 3
+	txRetryBackoff = 20 * time.Millisecond
+)
+
+type txKey struct{}
+
+// conn returns the transaction carried by ctx, or db when there is none.
+// Repositories use it for every statement so they join a unit of work
+// started by TxManager. Inside a transaction onReplica has no effect:
+// dbresolver keeps transactional statements on their connection.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager runs units of work in SERIALIZABLE transactions on the
+// primary, so read-check-write sequences such as the seat check on enroll
+// cannot interleave. Transactions the server aborts for a serialization
+// failure or deadlock are retried with jittered backoff. SQLite ignores
+// the isolation level: its write transactions are serialized anyway.
+func NewTxManager(db *gorm.DB) repository.TxManager {
+	return &txManager{db: db}
+}
+
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+		// Errors from fn were translated by the repositories already.
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if attempt == maxTxAttempts {
+			break
+		}
+
+		trace.SpanFromContext(ctx).AddEvent("db.transaction.retry", trace.WithAttributes(
+			attribute.Int("db.transaction.attempt", attempt),
+		))
+
+		// Full jitter keeps colliding transactions from retrying in lockstep.
+		backoff := time.Duration(rand.Int64N(int64(txRetryBackoff) << attempt))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return apperror.Unavailable("transaction aborted", errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+	return apperror.Unavailable("too many concurrent updates, retry later", err)
+}
+
+// isRetryable reports whether the transaction was aborted by the server
+// and may succeed when run again from the start. On SQLite that is a
+// lock still held once the busy timeout ran out.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+	}
+	var liteErr *gosqlite.Error
+	return errors.As(err, &liteErr) && isSQLiteBusy(liteErr)
+}
@@ -13,37 +13,50 @@ import (
 )
 
 type userRepositoryImpl struct {
-	db *gorm.DB
+	db       *gorm.DB
+	timeouts QueryTimeouts
 }
 
-func NewUserRepository(db *gorm.DB) repository.UserRepository {
-	return &userRepositoryImpl{db: db}
+func NewUserRepository(db *gorm.DB, timeouts QueryTimeouts) repository.UserRepository {
+	return &userRepositoryImpl{db: db, timeouts: timeouts}
 }
 
 func (r *userRepositoryImpl) Create(ctx context.Context, user *entity.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(user).Error)
 }
 
 func (r *userRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
 	var user entity.User
-	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, r.db).First(&user, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &user, nil
 }
 
 func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
 	var user entity.User
-	if err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, r.db).First(&user, "email = ?", email).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &user, nil
 }
 
 func (r *userRepositoryImpl) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
 	var user entity.User
-	if err := r.db.WithContext(ctx).First(&user, "username = ?", username).Error; err != nil {
-		return nil, err
+	if err := conn(ctx, r.db).First(&user, "username = ?", username).Error; err != nil {
+		return nil, translateError(err)
 	}
 	return &user, nil
 }
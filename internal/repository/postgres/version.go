@@ -0,0 +1,30 @@
+// File: internal/repository/postgres/version.go
+package postgres
+
+import (
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// withVersionBump copies changes and adds the optimistic-lock increment.
+func withVersionBump(changes map[string]interface{}) map[string]interface{} {
+	bumped := make(map[string]interface{}, len(changes)+1)
+	for column, value := range changes {
+		bumped[column] = value
+	}
+	bumped["version"] = gorm.Expr("version + 1")
+	return bumped
+}
+
+// checkVersioned turns a versioned write that matched no rows into
+// ErrVersionConflict. Callers verify existence beforehand, so zero rows
+// means the version moved on.
+func checkVersioned(result *gorm.DB) error {
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// File: internal/repository/postgres/waitlist_repository_impl.go
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type waitlistRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewWaitlistRepository(db *gorm.DB, timeouts QueryTimeouts) repository.WaitlistRepository {
+	return &waitlistRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *waitlistRepositoryImpl) Create(ctx context.Context, entry *entity.WaitlistEntry) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(entry).Error)
+}
+
+func (r *waitlistRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var entry entity.WaitlistEntry
+	if err := conn(ctx, r.db).First(&entry, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepositoryImpl) FindWaiting(ctx context.Context, courseID uuid.UUID, academicYear string, semester int) ([]*entity.WaitlistEntry, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var entries []*entity.WaitlistEntry
+	err := conn(ctx, r.db).
+		Where("course_id = ? AND academic_year = ? AND semester = ? AND status = ?", courseID, academicYear, semester, entity.WaitlistWaiting).
+		Order("position, created_at, id").Find(&entries).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepositoryImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.WaitlistEntry{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(withVersionBump(changes))
+	return checkVersioned(result)
+}
@@ -0,0 +1,179 @@
+// File: internal/repository/postgres/webhook_repository_impl.go
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookSubscriptionRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB, timeouts QueryTimeouts) repository.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *webhookSubscriptionRepositoryImpl) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Create(subscription).Error)
+}
+
+func (r *webhookSubscriptionRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var subscription entity.WebhookSubscription
+	if err := conn(ctx, r.db).First(&subscription, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &subscription, nil
+}
+
+func (r *webhookSubscriptionRepositoryImpl) FindAll(ctx context.Context, page, pageSize int) ([]*entity.WebhookSubscription, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	db := conn(ctx, r.db).Model(&entity.WebhookSubscription{})
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	var subscriptions []*entity.WebhookSubscription
+	err := db.Order("created_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&subscriptions).Error
+	if err != nil {
+		return nil, 0, translateError(err)
+	}
+	return subscriptions, total, nil
+}
+
+func (r *webhookSubscriptionRepositoryImpl) FindActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var subscriptions []*entity.WebhookSubscription
+	if err := conn(ctx, r.db).Where("active = ?", true).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookSubscriptionRepositoryImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Model(&entity.WebhookSubscription{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(withVersionBump(changes))
+	return checkVersioned(result)
+}
+
+func (r *webhookSubscriptionRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("id = ? AND version = ?", id, version).Delete(&entity.WebhookSubscription{})
+	return checkVersioned(result)
+}
+
+type webhookDeliveryRepositoryImpl struct {
+	db       *gorm.DB
+	timeouts QueryTimeouts
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB, timeouts QueryTimeouts) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepositoryImpl{db: db, timeouts: timeouts}
+}
+
+func (r *webhookDeliveryRepositoryImpl) CreateMany(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(deliveries).Error)
+}
+
+func (r *webhookDeliveryRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	var delivery entity.WebhookDelivery
+	if err := conn(ctx, r.db).First(&delivery, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepositoryImpl) FindAll(ctx context.Context, filter repository.DeliveryFilter, page, pageSize int) ([]*entity.WebhookDelivery, int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	db := onReplica(conn(ctx, r.db)).Model(&entity.WebhookDelivery{})
+	if filter.SubscriptionID != nil {
+		db = db.Where("subscription_id = ?", *filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		db = db.Where("event_type = ?", filter.EventType)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	var deliveries []*entity.WebhookDelivery
+	err := db.Order("created_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, translateError(err)
+	}
+	return deliveries, total, nil
+}
+
+func (r *webhookDeliveryRepositoryImpl) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	var deliveries []*entity.WebhookDelivery
+	err := conn(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", entity.DeliveryPending, timestamp(r.db, now)).
+		Order("next_attempt_at, id").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepositoryImpl) Update(ctx context.Context, id uuid.UUID, changes map[string]interface{}) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Default)
+	defer cancel()
+
+	return translateError(conn(ctx, r.db).Model(&entity.WebhookDelivery{}).Where("id = ?", id).Updates(changes).Error)
+}
+
+func (r *webhookDeliveryRepositoryImpl) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	result := conn(ctx, r.db).Where("status <> ? AND updated_at < ?", entity.DeliveryPending, timestamp(r.db, before)).
+		Delete(&entity.WebhookDelivery{})
+	return result.RowsAffected, translateError(result.Error)
+}
+
+func (r *webhookDeliveryRepositoryImpl) TryLock(ctx context.Context) (func(), bool, error) {
+	return tryAdvisoryLock(ctx, r.db, webhookDispatcherLock)
+}
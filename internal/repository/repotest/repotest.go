@@ -0,0 +1,1369 @@
+// File: internal/repository/repotest/repotest.go
+
+// Package repotest is the contract every repository implementation must
+// honour. Each backend runs Run against its own repositories, so the
+// in-memory ones used in tests cannot drift from Postgres.
+package repotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/textsearch"
+)
+
+// Repositories is one set of repositories over the same, empty store.
+type Repositories struct {
+	Users       repository.UserRepository
+	Students    repository.StudentRepository
+	Lecturers   repository.LecturerRepository
+	Courses     repository.CourseRepository
+	Enrollments repository.EnrollmentRepository
+	Search      repository.SearchRepository
+	Audit       repository.AuditRepository
+	Outbox      repository.OutboxRepository
+	Webhooks    repository.WebhookSubscriptionRepository
+	Deliveries  repository.WebhookDeliveryRepository
+	SeatBus     repository.SeatChangeBus
+	Waitlists   repository.WaitlistRepository
+	Advisors    repository.AdvisorRepository
+	KRS         repository.KRSRepository
+}
+
+// Factory returns fresh, empty repositories for one test.
+type Factory func(t *testing.T) Repositories
+
+// base is the creation time of the first fixture; later ones are an hour
+// apart so the created_at ordering is unambiguous.
+var base = time.Date(2024, 8, 1, 8, 0, 0, 0, time.UTC)
+
+// Run runs the whole contract, one subtest per behaviour.
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, newRepos(t)) })
+	t.Run("Students", func(t *testing.T) { testStudents(t, newRepos(t)) })
+	t.Run("StudentFilters", func(t *testing.T) { testStudentFilters(t, newRepos(t)) })
+	t.Run("StudentKeyset", func(t *testing.T) { testStudentKeyset(t, newRepos(t)) })
+	t.Run("SoftDelete", func(t *testing.T) { testSoftDelete(t, newRepos(t)) })
+	t.Run("Trash", func(t *testing.T) { testTrash(t, newRepos(t)) })
+	t.Run("Lecturers", func(t *testing.T) { testLecturers(t, newRepos(t)) })
+	t.Run("Courses", func(t *testing.T) { testCourses(t, newRepos(t)) })
+	t.Run("Enrollments", func(t *testing.T) { testEnrollments(t, newRepos(t)) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newRepos(t)) })
+	t.Run("Audit", func(t *testing.T) { testAudit(t, newRepos(t)) })
+	t.Run("Outbox", func(t *testing.T) { testOutbox(t, newRepos(t)) })
+	t.Run("Webhooks", func(t *testing.T) { testWebhooks(t, newRepos(t)) })
+	t.Run("WebhookDeliveries", func(t *testing.T) { testWebhookDeliveries(t, newRepos(t)) })
+	t.Run("SeatChangeBus", func(t *testing.T) { testSeatChangeBus(t, newRepos(t)) })
+	t.Run("Waitlists", func(t *testing.T) { testWaitlists(t, newRepos(t)) })
+	t.Run("Advisors", func(t *testing.T) { testAdvisors(t, newRepos(t)) })
+	t.Run("KRS", func(t *testing.T) { testKRS(t, newRepos(t)) })
+}
+
+func testUsers(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := &entity.User{Username: "ani", Email: "ani@example.com", Password: "hash", Role: "student", IsActive: true}
+	mustDo(t, repos.Users.Create(ctx, user))
+	if user.ID == uuid.Nil {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	found, err := repos.Users.FindByEmail(ctx, "ani@example.com")
+	mustDo(t, err)
+	if found.ID != user.ID || found.Username != "ani" {
+		t.Errorf("FindByEmail = %+v, want user %s", found, user.ID)
+	}
+	found, err = repos.Users.FindByUsername(ctx, "ani")
+	mustDo(t, err)
+	if found.ID != user.ID {
+		t.Errorf("FindByUsername returned %s, want %s", found.ID, user.ID)
+	}
+	_, err = repos.Users.FindByEmail(ctx, "ANI@example.com")
+	expectNotFound(t, err)
+
+	err = repos.Users.Create(ctx, &entity.User{Username: "ani", Email: "other@example.com", Password: "hash", Role: "student"})
+	expectConflict(t, err, "username")
+	err = repos.Users.Create(ctx, &entity.User{Username: "budi", Email: "ani@example.com", Password: "hash", Role: "student"})
+	expectConflict(t, err, "email")
+}
+
+func testStudents(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	student := newStudent("2024001", "Ani Wijaya", "Computer Science", 0)
+	mustDo(t, repos.Students.Create(ctx, student))
+	if student.ID == uuid.Nil || student.Version != 1 || student.Status != "active" {
+		t.Fatalf("Create filled ID=%s version=%d status=%q, want an ID, 1 and active", student.ID, student.Version, student.Status)
+	}
+
+	found, err := repos.Students.FindByID(ctx, student.ID)
+	mustDo(t, err)
+	if found.NIM != student.NIM || found.Version != 1 || found.Status != "active" {
+		t.Errorf("FindByID = %+v, want the created student", found)
+	}
+	found, err = repos.Students.FindByNIM(ctx, "2024001")
+	mustDo(t, err)
+	if found.ID != student.ID {
+		t.Errorf("FindByNIM returned %s, want %s", found.ID, student.ID)
+	}
+	_, err = repos.Students.FindByID(ctx, uuid.New())
+	expectNotFound(t, err)
+
+	duplicate := newStudent("2024001", "Budi", "Mathematics", 1)
+	duplicate.Email = "budi@students.example.com"
+	expectConflict(t, repos.Students.Create(ctx, duplicate), "nim")
+	duplicate = newStudent("2024002", "Budi", "Mathematics", 1)
+	duplicate.Email = student.Email
+	expectConflict(t, repos.Students.Create(ctx, duplicate), "email")
+
+	mustDo(t, repos.Students.Update(ctx, student.ID, 1, map[string]interface{}{"name": "Ani W.", "gpa": 3.75}))
+	found, err = repos.Students.FindByID(ctx, student.ID)
+	mustDo(t, err)
+	if found.Name != "Ani W." || found.GPA != 3.75 || found.Version != 2 || found.NIM != "2024001" {
+		t.Errorf("after Update got name=%q gpa=%v version=%d nim=%q", found.Name, found.GPA, found.Version, found.NIM)
+	}
+	expectVersionConflict(t, repos.Students.Update(ctx, student.ID, 1, map[string]interface{}{"name": "stale"}))
+
+	other := newStudent("2024003", "Citra", "Physics", 2)
+	mustDo(t, repos.Students.Create(ctx, other))
+	expectConflict(t, repos.Students.Update(ctx, other.ID, 1, map[string]interface{}{"nim": "2024001"}), "nim")
+}
+
+func testStudentFilters(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	oldest := newStudent("2021001", "Ani Wijaya", "Computer Science", 0)
+	middle := newStudent("2022001", "Budi Santoso", "Information Systems", 1)
+	newest := newStudent("2023001", "Citra Lestari", "computer engineering", 2)
+	newest.Status = "graduated"
+	oldest.EnrollmentYear, middle.EnrollmentYear, newest.EnrollmentYear = 2021, 2022, 2023
+	oldest.GPA, middle.GPA, newest.GPA = 3.2, 3.8, 3.5
+	born := time.Date(2004, 5, 17, 0, 0, 0, 0, time.UTC)
+	middle.DateOfBirth = &born
+	for _, s := range []*entity.Student{oldest, middle, newest} {
+		mustDo(t, repos.Students.Create(ctx, s))
+	}
+
+	cases := []struct {
+		name string
+		spec query.Spec
+		want []*entity.Student
+	}{
+		{"none", query.Spec{}, []*entity.Student{newest, middle, oldest}},
+		{"like is case-insensitive", filtered(where(query.Students, "major", query.Like, "COMPUTER")), []*entity.Student{newest, oldest}},
+		{"search matches name", query.Spec{Search: "santoso"}, []*entity.Student{middle}},
+		{"search matches nim", query.Spec{Search: "2023"}, []*entity.Student{newest}},
+		{"search treats _ as a wildcard", query.Spec{Search: "202_001"}, []*entity.Student{newest, middle, oldest}},
+		{"eq is exact", filtered(where(query.Students, "status", query.Eq, "graduated")), []*entity.Student{newest}},
+		{"ne", filtered(where(query.Students, "status", query.Ne, "graduated")), []*entity.Student{middle, oldest}},
+		{"in", filtered(where(query.Students, "status", query.In, "graduated,dropped")), []*entity.Student{newest}},
+		{"gte on an integer", filtered(where(query.Students, "enrollment_year", query.Gte, "2022")), []*entity.Student{newest, middle}},
+		{"lt on a number", filtered(where(query.Students, "gpa", query.Lt, "3.5")), []*entity.Student{oldest}},
+		{"no comparison matches null", filtered(where(query.Students, "date_of_birth", query.Ne, "2000-01-01")), []*entity.Student{middle}},
+		{"filters combine", filtered(
+			where(query.Students, "major", query.Like, "computer"),
+			where(query.Students, "status", query.Eq, "active"),
+		), []*entity.Student{oldest}},
+		{"sort ascending", query.Spec{Sort: []query.Sort{{Field: "name"}}}, []*entity.Student{oldest, middle, newest}},
+		{"sort descending", query.Spec{Sort: []query.Sort{{Field: "gpa", Desc: true}}}, []*entity.Student{middle, newest, oldest}},
+		{"nulls sort last", query.Spec{Sort: []query.Sort{{Field: "date_of_birth", Desc: true}, {Field: "name"}}}, []*entity.Student{middle, oldest, newest}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, total, err := repos.Students.FindAll(ctx, 1, 10, tc.spec)
+			mustDo(t, err)
+			if total != int64(len(tc.want)) {
+				t.Errorf("total = %d, want %d", total, len(tc.want))
+			}
+			expectNIMs(t, "FindAll", got, tc.want)
+
+			var streamed []*entity.Student
+			mustDo(t, repos.Students.Stream(ctx, tc.spec, func(s *entity.Student) error {
+				streamed = append(streamed, s)
+				return nil
+			}))
+			expectNIMs(t, "Stream", streamed, tc.want)
+		})
+	}
+
+	got, total, err := repos.Students.FindAll(ctx, 2, 2, query.Spec{})
+	mustDo(t, err)
+	if total != 3 {
+		t.Errorf("paged total = %d, want 3", total)
+	}
+	expectNIMs(t, "FindAll page 2", got, []*entity.Student{oldest})
+
+	got, _, err = repos.Students.FindAll(ctx, 3, 2, query.Spec{})
+	mustDo(t, err)
+	expectNIMs(t, "FindAll past the end", got, nil)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repos.Students.Stream(ctx, query.Spec{}, func(*entity.Student) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Stream returned %v after %d calls, want the callback error after 1", err, calls)
+	}
+}
+
+func testStudentKeyset(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	var students []*entity.Student
+	for i, hour := range []int{0, 1, 1, 1, 2} {
+		s := newStudent(fmt.Sprintf("202400%d", i), "Student", "Computer Science", hour)
+		mustDo(t, repos.Students.Create(ctx, s))
+		students = append(students, s)
+	}
+	// Newest first, and the three rows created in the same hour by id
+	// descending, the order the keyset relies on.
+	want := slices.Clone(students)
+	slices.SortFunc(want, func(a, b *entity.Student) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(b.ID[:], a.ID[:])
+	})
+	keyOf := func(s *entity.Student) *repository.Keyset {
+		return &repository.Keyset{CreatedAt: s.CreatedAt, ID: s.ID}
+	}
+
+	late := newStudent("2024009", "Late", "Physics", 5)
+	var walked []*entity.Student
+	page := repository.KeysetPage{Limit: 2}
+	for i := 0; ; i++ {
+		window, err := repos.Students.FindPage(ctx, page, query.Spec{})
+		mustDo(t, err)
+		if window.HasPrev != (i > 0) {
+			t.Errorf("page %d HasPrev = %v", i, window.HasPrev)
+		}
+		walked = append(walked, window.Items...)
+		if !window.HasNext {
+			break
+		}
+		if i == 0 {
+			// A row inserted ahead of the cursor must not shift later pages.
+			mustDo(t, repos.Students.Create(ctx, late))
+		}
+		page.After = keyOf(window.Items[len(window.Items)-1])
+	}
+	expectNIMs(t, "FindPage forwards", walked, want)
+
+	window, err := repos.Students.FindPage(ctx, repository.KeysetPage{Before: keyOf(want[4]), Limit: 2}, query.Spec{})
+	mustDo(t, err)
+	expectNIMs(t, "FindPage before the last row", window.Items, want[2:4])
+	if !window.HasPrev || !window.HasNext {
+		t.Errorf("backwards page HasPrev = %v, HasNext = %v, want both", window.HasPrev, window.HasNext)
+	}
+	window, err = repos.Students.FindPage(ctx, repository.KeysetPage{Before: keyOf(want[1]), Limit: 2}, query.Spec{})
+	mustDo(t, err)
+	expectNIMs(t, "FindPage before the second row", window.Items, []*entity.Student{late, want[0]})
+	if window.HasPrev {
+		t.Error("first page reached backwards reports HasPrev")
+	}
+
+	// The keyset order wins over any sort in the spec.
+	spec := filtered(where(query.Students, "major", query.Like, "computer"))
+	spec.Sort = []query.Sort{{Field: "name"}}
+	window, err = repos.Students.FindPage(ctx, repository.KeysetPage{Limit: 10}, spec)
+	mustDo(t, err)
+	expectNIMs(t, "FindPage with filters", window.Items, want)
+	total, err := repos.Students.Count(ctx, spec)
+	mustDo(t, err)
+	if total != 5 {
+		t.Errorf("Count = %d, want 5", total)
+	}
+}
+
+func testSoftDelete(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	student := newStudent("2024001", "Ani Wijaya", "Computer Science", 0)
+	mustDo(t, repos.Students.Create(ctx, student))
+
+	expectVersionConflict(t, repos.Students.Delete(ctx, student.ID, 7))
+	mustDo(t, repos.Students.Delete(ctx, student.ID, 1))
+
+	_, err := repos.Students.FindByID(ctx, student.ID)
+	expectNotFound(t, err)
+	_, err = repos.Students.FindByNIM(ctx, student.NIM)
+	expectNotFound(t, err)
+	list, total, err := repos.Students.FindAll(ctx, 1, 10, query.Spec{})
+	mustDo(t, err)
+	if total != 0 || len(list) != 0 {
+		t.Errorf("FindAll after delete returned %d of %d rows, want none", len(list), total)
+	}
+
+	expectVersionConflict(t, repos.Students.Update(ctx, student.ID, 1, map[string]interface{}{"name": "ghost"}))
+	expectVersionConflict(t, repos.Students.Delete(ctx, student.ID, 1))
+
+	// The unique indexes only cover live rows, so the NIM is free again,
+	// and the deleted student cannot come back while it is taken.
+	reused := newStudent("2024001", "Budi", "Physics", 1)
+	reused.Email = "budi@students.example.com"
+	mustDo(t, repos.Students.Create(ctx, reused))
+	expectConflict(t, repos.Students.Restore(ctx, student.ID), "nim")
+}
+
+func testTrash(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	ani := newStudent("2024001", "Ani Wijaya", "Computer Science", 0)
+	budi := newStudent("2024002", "Budi Santoso", "Physics", 1)
+	live := newStudent("2024003", "Citra Lestari", "Physics", 2)
+	for _, student := range []*entity.Student{ani, budi, live} {
+		mustDo(t, repos.Students.Create(ctx, student))
+	}
+	mustDo(t, repos.Students.Delete(ctx, ani.ID, 1))
+	time.Sleep(2 * time.Millisecond)
+	mustDo(t, repos.Students.Delete(ctx, budi.ID, 1))
+
+	deleted, total, err := repos.Students.FindDeleted(ctx, 1, 10)
+	mustDo(t, err)
+	if total != 2 {
+		t.Errorf("FindDeleted total = %d, want 2", total)
+	}
+	expectNIMs(t, "FindDeleted", deleted, []*entity.Student{budi, ani})
+	if len(deleted) > 0 && !deleted[0].DeletedAt.Valid {
+		t.Errorf("FindDeleted returned deleted_at %+v, want it set", deleted[0].DeletedAt)
+	}
+	deleted, total, err = repos.Students.FindDeleted(ctx, 2, 1)
+	mustDo(t, err)
+	if total != 2 {
+		t.Errorf("FindDeleted page 2 total = %d, want 2", total)
+	}
+	expectNIMs(t, "FindDeleted page 2", deleted, []*entity.Student{ani})
+
+	found, err := repos.Students.FindDeletedByID(ctx, ani.ID)
+	mustDo(t, err)
+	if found.NIM != ani.NIM {
+		t.Errorf("FindDeletedByID = %+v, want %s", found, ani.NIM)
+	}
+	_, err = repos.Students.FindDeletedByID(ctx, live.ID)
+	expectNotFound(t, err)
+	expectNotFound(t, repos.Students.Restore(ctx, live.ID))
+
+	// A restored student is live again under a new version.
+	mustDo(t, repos.Students.Restore(ctx, ani.ID))
+	found, err = repos.Students.FindByID(ctx, ani.ID)
+	mustDo(t, err)
+	if found.Version != 2 || found.DeletedAt.Valid {
+		t.Errorf("restored student has version %d, deleted_at %+v; want 2 and none", found.Version, found.DeletedAt)
+	}
+	expectNotFound(t, repos.Students.Restore(ctx, ani.ID))
+
+	// Purging needs the row to have been deleted before the cutoff.
+	expectNotFound(t, repos.Students.Purge(ctx, budi.ID, time.Now().Add(-time.Hour)))
+	expectNotFound(t, repos.Students.Purge(ctx, live.ID, time.Now().Add(time.Hour)))
+	mustDo(t, repos.Students.Purge(ctx, budi.ID, time.Now().Add(time.Hour)))
+	_, err = repos.Students.FindDeletedByID(ctx, budi.ID)
+	expectNotFound(t, err)
+
+	mustDo(t, repos.Students.Delete(ctx, live.ID, 1))
+	purged, err := repos.Students.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	mustDo(t, err)
+	if purged != 0 {
+		t.Errorf("PurgeDeleted before the deletes purged %d rows, want 0", purged)
+	}
+	purged, err = repos.Students.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+	mustDo(t, err)
+	if purged != 1 {
+		t.Errorf("PurgeDeleted purged %d rows, want 1", purged)
+	}
+	if _, total, _ := repos.Students.FindDeleted(ctx, 1, 10); total != 0 {
+		t.Errorf("FindDeleted after the purge found %d rows, want none", total)
+	}
+	if _, total, _ := repos.Students.FindAll(ctx, 1, 10, query.Spec{}); total != 1 {
+		t.Errorf("FindAll after the purge found %d students, want the restored one", total)
+	}
+
+	// The other repositories share the behaviour; check one round trip
+	// each, including their partial unique indexes.
+	lecturer := newLecturer("198001", "Dewi Anggraini", "Informatics", 0)
+	mustDo(t, repos.Lecturers.Create(ctx, lecturer))
+	mustDo(t, repos.Lecturers.Delete(ctx, lecturer.ID, 1))
+	reused := newLecturer("198001", "Eko Prasetyo", "Informatics", 1)
+	reused.Email = "eko@staff.example.com"
+	mustDo(t, repos.Lecturers.Create(ctx, reused))
+	expectConflict(t, repos.Lecturers.Restore(ctx, lecturer.ID), "nip")
+	mustDo(t, repos.Lecturers.Purge(ctx, lecturer.ID, time.Now().Add(time.Hour)))
+
+	course := newCourse("IF101", "Algorithms", 1)
+	mustDo(t, repos.Courses.Create(ctx, course))
+	mustDo(t, repos.Courses.Delete(ctx, course.ID, 1))
+	mustDo(t, repos.Courses.Restore(ctx, course.ID))
+
+	enrollment := newEnrollment(ani.ID, course.ID, 0)
+	mustDo(t, repos.Enrollments.Create(ctx, enrollment))
+	mustDo(t, repos.Enrollments.Delete(ctx, enrollment.ID, 1))
+	mustDo(t, repos.Enrollments.Create(ctx, newEnrollment(ani.ID, course.ID, 1)))
+	expectConflict(t, repos.Enrollments.Restore(ctx, enrollment.ID), "student_id_course_id_academic_year_semester")
+	if purged, err := repos.Enrollments.PurgeDeleted(ctx, time.Now().Add(time.Hour)); err != nil || purged != 1 {
+		t.Errorf("PurgeDeleted of enrollments = %d, %v; want 1", purged, err)
+	}
+}
+
+func testLecturers(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := newLecturer("198501012010011001", "Dr. Ani", "Computer Science", 0)
+	second := newLecturer("198702022012012002", "Dr. Budi", "Mathematics", 1)
+	mustDo(t, repos.Lecturers.Create(ctx, first))
+	mustDo(t, repos.Lecturers.Create(ctx, second))
+	if first.Status != "active" || first.Version != 1 {
+		t.Errorf("Create filled status=%q version=%d, want active and 1", first.Status, first.Version)
+	}
+
+	duplicate := newLecturer(first.NIP, "Dr. Citra", "Physics", 2)
+	duplicate.Email = "citra@staff.example.com"
+	expectConflict(t, repos.Lecturers.Create(ctx, duplicate), "nip")
+
+	got, total, err := repos.Lecturers.FindAll(ctx, 1, 10, filtered(where(query.Lecturers, "department", query.Like, "science")))
+	mustDo(t, err)
+	if total != 1 || len(got) != 1 || got[0].ID != first.ID {
+		t.Errorf("department filter returned %d of %d rows, want only %s", len(got), total, first.NIP)
+	}
+
+	got, _, err = repos.Lecturers.FindAll(ctx, 1, 10, query.Spec{})
+	mustDo(t, err)
+	if len(got) != 2 || got[0].ID != second.ID {
+		t.Errorf("FindAll is not ordered newest first")
+	}
+
+	mustDo(t, repos.Lecturers.Update(ctx, second.ID, 1, map[string]interface{}{"status": "retired"}))
+	found, err := repos.Lecturers.FindByID(ctx, second.ID)
+	mustDo(t, err)
+	if found.Status != "retired" || found.Version != 2 {
+		t.Errorf("after Update got status=%q version=%d", found.Status, found.Version)
+	}
+
+	mustDo(t, repos.Lecturers.Delete(ctx, second.ID, 2))
+	_, err = repos.Lecturers.FindByID(ctx, second.ID)
+	expectNotFound(t, err)
+}
+
+func testCourses(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	lecturer := newLecturer("198501012010011001", "Dr. Ani", "Computer Science", 0)
+	mustDo(t, repos.Lecturers.Create(ctx, lecturer))
+
+	algorithms := newCourse("IF201", "Algorithms", 3)
+	algorithms.LecturerID = &lecturer.ID
+	databases := newCourse("IF101", "Databases", 1)
+	calculus := newCourse("MA101", "Calculus", 1)
+	for _, c := range []*entity.Course{algorithms, databases, calculus} {
+		mustDo(t, repos.Courses.Create(ctx, c))
+	}
+	if databases.MaxStudents != 40 || databases.Status != "active" {
+		t.Errorf("Create filled max_students=%d status=%q, want 40 and active", databases.MaxStudents, databases.Status)
+	}
+
+	expectConflict(t, repos.Courses.Create(ctx, newCourse("IF101", "Databases II", 2)), "code")
+
+	found, err := repos.Courses.FindByCode(ctx, "IF201")
+	mustDo(t, err)
+	if found.ID != algorithms.ID {
+		t.Errorf("FindByCode returned %s, want %s", found.ID, algorithms.ID)
+	}
+
+	expectCodes := func(spec query.Spec, want ...string) {
+		t.Helper()
+		got, total, err := repos.Courses.FindAll(ctx, 1, 10, spec)
+		mustDo(t, err)
+		codes := make([]string, len(got))
+		for i, c := range got {
+			codes[i] = c.Code
+		}
+		if total != int64(len(want)) || fmt.Sprint(codes) != fmt.Sprint(want) {
+			t.Errorf("FindAll(%+v) = %v (total %d), want %v", spec, codes, total, want)
+		}
+	}
+	byLecturer := filtered(where(query.Courses, "lecturer_id", query.Eq, lecturer.ID.String()))
+	expectCodes(query.Spec{}, "IF101", "IF201", "MA101")
+	expectCodes(filtered(where(query.Courses, "semester", query.Eq, "1")), "IF101", "MA101")
+	expectCodes(byLecturer, "IF201")
+	expectCodes(query.Spec{Search: "if"}, "IF101", "IF201")
+	expectCodes(query.Spec{Sort: []query.Sort{{Field: "semester", Desc: true}, {Field: "name"}}}, "IF201", "MA101", "IF101")
+
+	mustDo(t, repos.Courses.Update(ctx, calculus.ID, 1, map[string]interface{}{"lecturer_id": lecturer.ID}))
+	expectCodes(byLecturer, "IF201", "MA101")
+}
+
+func testEnrollments(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	ani := newStudent("2024001", "Ani", "Computer Science", 0)
+	budi := newStudent("2024002", "Budi", "Computer Science", 1)
+	course := newCourse("IF101", "Databases", 1)
+	mustDo(t, repos.Students.Create(ctx, ani))
+	mustDo(t, repos.Students.Create(ctx, budi))
+	mustDo(t, repos.Courses.Create(ctx, course))
+
+	first := newEnrollment(ani.ID, course.ID, 0)
+	second := newEnrollment(budi.ID, course.ID, 1)
+	mustDo(t, repos.Enrollments.Create(ctx, first))
+	mustDo(t, repos.Enrollments.Create(ctx, second))
+	if first.Status != "enrolled" || first.EnrollmentDate.IsZero() {
+		t.Errorf("Create filled status=%q enrollment_date=%v, want enrolled and a date", first.Status, first.EnrollmentDate)
+	}
+
+	expectConflict(t, repos.Enrollments.Create(ctx, newEnrollment(ani.ID, course.ID, 2)),
+		"student_id_course_id_academic_year_semester")
+
+	found, err := repos.Enrollments.FindByStudentCourse(ctx, ani.ID, course.ID, "2024/2025", 1)
+	mustDo(t, err)
+	if found.ID != first.ID {
+		t.Errorf("FindByStudentCourse returned %s, want %s", found.ID, first.ID)
+	}
+	_, err = repos.Enrollments.FindByStudentCourse(ctx, ani.ID, course.ID, "2024/2025", 2)
+	expectNotFound(t, err)
+
+	expectCount := func(want int64) {
+		t.Helper()
+		taken, err := repos.Enrollments.CountActiveByCourse(ctx, course.ID, "2024/2025", 1, nil)
+		mustDo(t, err)
+		if taken != want {
+			t.Errorf("CountActiveByCourse = %d, want %d", taken, want)
+		}
+	}
+	expectCount(2)
+
+	mustDo(t, repos.Enrollments.Update(ctx, second.ID, 1, map[string]interface{}{"status": "dropped", "score": 55.5}))
+	found, err = repos.Enrollments.FindByID(ctx, second.ID)
+	mustDo(t, err)
+	if found.Status != "dropped" || found.Score == nil || *found.Score != 55.5 || found.Version != 2 {
+		t.Errorf("after Update got status=%q score=%v version=%d", found.Status, found.Score, found.Version)
+	}
+	expectCount(1)
+
+	active, err := repos.Enrollments.FindActiveByStudent(ctx, ani.ID, "2024/2025", 1)
+	mustDo(t, err)
+	if len(active) != 1 || active[0].ID != first.ID {
+		t.Errorf("FindActiveByStudent(ani) returned %d enrollments, want %s", len(active), first.ID)
+	}
+	active, err = repos.Enrollments.FindActiveByStudent(ctx, budi.ID, "2024/2025", 1)
+	mustDo(t, err)
+	if len(active) != 0 {
+		t.Errorf("FindActiveByStudent(budi) returned %d enrollments, want none: the only one was dropped", len(active))
+	}
+
+	mustDo(t, repos.Enrollments.Delete(ctx, first.ID, 1))
+	expectCount(0)
+
+	got, total, err := repos.Enrollments.FindAll(ctx, 1, 10, filtered(where(query.Enrollments, "course_id", query.Eq, course.ID.String())))
+	mustDo(t, err)
+	if total != 1 || len(got) != 1 || got[0].ID != second.ID {
+		t.Errorf("FindAll by course returned %d of %d rows, want only the dropped enrollment", len(got), total)
+	}
+}
+
+// where builds a filter the way the list handlers do, from its operand as
+// it appears in a query string.
+func where(resource *query.Resource, field string, op query.Op, raw string) query.Filter {
+	filter, err := resource.NewFilter("filter", field, op, raw)
+	if err != nil {
+		panic(err)
+	}
+	return filter
+}
+
+func filtered(filters ...query.Filter) query.Spec {
+	return query.Spec{Filters: filters}
+}
+
+// testSearch covers what every backend agrees on. Misspelt terms are left
+// out: SQLite does not find them.
+func testSearch(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	muhammad := newStudent("2024001", "Muhammad Rizki", "Informatics", 0)
+	budi := newStudent("2024002", "Budi Santoso", "Informatics", 1)
+	budiman := newStudent("2024003", "Budiman Hakim", "Mathematics", 2)
+	gone := newStudent("2024004", "Budi Gone", "Physics", 3)
+	for _, s := range []*entity.Student{muhammad, budi, budiman, gone} {
+		mustDo(t, repos.Students.Create(ctx, s))
+	}
+	mustDo(t, repos.Students.Delete(ctx, gone.ID, 1))
+	soekarno := newLecturer("198501012010011001", "Dr. Soekarno Putra", "Informatics", 0)
+	mustDo(t, repos.Lecturers.Create(ctx, soekarno))
+	algorithms := newCourse("IF201", "Algoritma Dasar", 3)
+	mustDo(t, repos.Courses.Create(ctx, algorithms))
+
+	all := []string{repository.SearchStudent, repository.SearchLecturer, repository.SearchCourse}
+	cases := []struct {
+		name  string
+		q     string
+		kinds []string
+		limit int
+		want  []uuid.UUID
+	}{
+		{"exact word ranks above prefix", "budi", all, 10, []uuid.UUID{budi.ID, budiman.ID}},
+		{"every term must match", "budi santoso", all, 10, []uuid.UUID{budi.ID}},
+		{"no match", "budi rizki", all, 10, nil},
+		{"repeated letters fold", "Muhamad", all, 10, []uuid.UUID{muhammad.ID}},
+		{"old spelling folds", "sukarno", all, 10, []uuid.UUID{soekarno.ID}},
+		{"number", "2024001", all, 10, []uuid.UUID{muhammad.ID}},
+		{"code", "if201", all, 10, []uuid.UUID{algorithms.ID}},
+		{"kinds", "budi", []string{repository.SearchCourse}, 10, nil},
+		{"limit", "budi", all, 1, []uuid.UUID{budi.ID}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, err := repos.Search.Search(ctx, textsearch.Terms(tc.q), tc.kinds, tc.limit)
+			mustDo(t, err)
+			got := make([]uuid.UUID, len(hits))
+			for i, hit := range hits {
+				got[i] = hit.ID
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("Search(%q) = %v, want %v", tc.q, hits, tc.want)
+			}
+		})
+	}
+
+	hits, err := repos.Search.Search(ctx, []string{"algoritma"}, all, 10)
+	mustDo(t, err)
+	if len(hits) != 1 || hits[0].Type != repository.SearchCourse || hits[0].Title != "Algoritma Dasar" ||
+		hits[0].Subtitle != "IF201" || hits[0].Detail != "Informatics" {
+		t.Errorf("course hit = %+v", hits)
+	}
+}
+
+func testAudit(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	hash, err := repos.Audit.LatestHash(ctx)
+	mustDo(t, err)
+	if hash != "" {
+		t.Errorf("LatestHash of an empty log = %q, want \"\"", hash)
+	}
+
+	student, course := uuid.New(), uuid.New()
+	admin, staff := uuid.New(), uuid.New()
+	score := 3.75
+	entries := []*entity.AuditLog{
+		{Action: entity.AuditCreate, EntityType: entity.AuditStudent, EntityID: student, ActorID: &admin, ActorRole: "admin",
+			Changes: entity.AuditChanges{"name": {New: "Ani"}, "gpa": {New: 0.0}, "user_id": {}}, Hash: "h1"},
+		{Action: entity.AuditUpdate, EntityType: entity.AuditStudent, EntityID: student, ActorID: &staff, ActorRole: "staff",
+			IP: "203.0.113.7", RequestID: "req-2", Changes: entity.AuditChanges{"gpa": {Old: 0.0, New: score}}, PrevHash: "h1", Hash: "h2"},
+		{Action: entity.AuditDelete, EntityType: entity.AuditCourse, EntityID: course, ActorID: &admin, ActorRole: "admin",
+			Changes: entity.AuditChanges{"code": {Old: "IF201"}}, PrevHash: "h2", Hash: "h3"},
+	}
+	for i, entry := range entries {
+		entry.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		mustDo(t, repos.Audit.Create(ctx, entry))
+		if i > 0 && entry.ID <= entries[i-1].ID {
+			t.Fatalf("entry %d got ID %d after %d, want increasing IDs", i, entry.ID, entries[i-1].ID)
+		}
+	}
+	hash, err = repos.Audit.LatestHash(ctx)
+	mustDo(t, err)
+	if hash != "h3" {
+		t.Errorf("LatestHash = %q, want h3", hash)
+	}
+
+	ids := func(entries []*entity.AuditLog) string {
+		s := make([]int64, len(entries))
+		for i, entry := range entries {
+			s[i] = entry.ID
+		}
+		return fmt.Sprint(s)
+	}
+	first, second, third := entries[0], entries[1], entries[2]
+	cases := []struct {
+		name      string
+		filter    repository.AuditFilter
+		page      int
+		pageSize  int
+		want      []*entity.AuditLog
+		wantTotal int64
+	}{
+		{"all, newest first", repository.AuditFilter{}, 1, 10, []*entity.AuditLog{third, second, first}, 3},
+		{"second page", repository.AuditFilter{}, 2, 2, []*entity.AuditLog{first}, 3},
+		{"entity", repository.AuditFilter{EntityType: entity.AuditStudent, EntityID: &student}, 1, 10, []*entity.AuditLog{second, first}, 2},
+		{"entity type", repository.AuditFilter{EntityType: entity.AuditCourse}, 1, 10, []*entity.AuditLog{third}, 1},
+		{"actor", repository.AuditFilter{ActorID: &admin}, 1, 10, []*entity.AuditLog{third, first}, 2},
+		{"time range", repository.AuditFilter{From: base.Add(time.Hour), To: base.Add(2 * time.Hour)}, 1, 10, []*entity.AuditLog{second}, 1},
+		{"from only", repository.AuditFilter{From: base.Add(90 * time.Minute)}, 1, 10, []*entity.AuditLog{third}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, total, err := repos.Audit.FindAll(ctx, tc.filter, tc.page, tc.pageSize)
+			mustDo(t, err)
+			if ids(got) != ids(tc.want) || total != tc.wantTotal {
+				t.Errorf("FindAll = %s (total %d), want %s (total %d)", ids(got), total, ids(tc.want), tc.wantTotal)
+			}
+		})
+	}
+
+	got, err := repos.Audit.FindAfter(ctx, 0, 2)
+	mustDo(t, err)
+	if ids(got) != ids(entries[:2]) {
+		t.Errorf("FindAfter(0, 2) = %s, want %s", ids(got), ids(entries[:2]))
+	}
+	got, err = repos.Audit.FindAfter(ctx, second.ID, 10)
+	mustDo(t, err)
+	if ids(got) != ids(entries[2:]) {
+		t.Fatalf("FindAfter(%d, 10) = %s, want %s", second.ID, ids(got), ids(entries[2:]))
+	}
+
+	// Entries must read back exactly as written, or their hashes break.
+	got, _, err = repos.Audit.FindAll(ctx, repository.AuditFilter{}, 1, 10)
+	mustDo(t, err)
+	for i, stored := range got {
+		want := entries[len(entries)-1-i]
+		wantChanges, _ := json.Marshal(want.Changes)
+		gotChanges, _ := json.Marshal(stored.Changes)
+		if !stored.CreatedAt.Equal(want.CreatedAt) || !bytes.Equal(gotChanges, wantChanges) ||
+			*stored.ActorID != *want.ActorID || stored.IP != want.IP || stored.RequestID != want.RequestID ||
+			stored.PrevHash != want.PrevHash || stored.Hash != want.Hash {
+			t.Errorf("entry %d read back as %+v (changes %s), want %+v (changes %s)", want.ID, stored, gotChanges, want, wantChanges)
+		}
+	}
+}
+
+func testOutbox(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	student, other := uuid.New(), uuid.New()
+	events := make([]*entity.OutboxEvent, 4)
+	for i := range events {
+		key := student
+		if i == 3 {
+			key = other
+		}
+		events[i] = &entity.OutboxEvent{
+			EventID:       uuid.New(),
+			Type:          entity.EventStudentStatusChanged,
+			AggregateType: entity.AuditStudent,
+			AggregateID:   key,
+			OrderingKey:   key.String(),
+			Payload:       fmt.Sprintf(`{"n": %d}`, i),
+			RequestID:     "req-1",
+			OccurredAt:    base.Add(time.Duration(i) * time.Hour),
+		}
+		mustDo(t, repos.Outbox.Create(ctx, events[i]))
+		if i > 0 && events[i].ID <= events[i-1].ID {
+			t.Fatalf("event %d got ID %d after %d, want increasing IDs", i, events[i].ID, events[i-1].ID)
+		}
+	}
+	first, second, third, unrelated := events[0], events[1], events[2], events[3]
+
+	ids := func(events []*entity.OutboxEvent) string {
+		s := make([]int64, len(events))
+		for i, event := range events {
+			s[i] = event.ID
+		}
+		return fmt.Sprint(s)
+	}
+	expectDue := func(now time.Time, limit int, want ...*entity.OutboxEvent) []*entity.OutboxEvent {
+		t.Helper()
+		due, err := repos.Outbox.FindDue(ctx, now, limit)
+		mustDo(t, err)
+		if ids(due) != ids(want) {
+			t.Fatalf("FindDue(%s, %d) = %s, want %s", now.Sub(base), limit, ids(due), ids(want))
+		}
+		return due
+	}
+
+	// Only the oldest pending event of each key is due.
+	expectDue(base, 1, first)
+	due := expectDue(base, 10, first, unrelated)
+	var payload map[string]int
+	if err := json.Unmarshal([]byte(due[0].Payload), &payload); err != nil || payload["n"] != 0 {
+		t.Errorf("payload read back as %q, want n = 0", due[0].Payload)
+	}
+	if !due[0].OccurredAt.Equal(first.OccurredAt) || due[0].EventID != first.EventID || due[0].RequestID != "req-1" {
+		t.Errorf("event read back as %+v, want %+v", due[0], first)
+	}
+
+	// A failed event holds back its key until its retry is due.
+	retry := base.Add(5 * time.Hour)
+	mustDo(t, repos.Outbox.MarkPublished(ctx, first.ID, base.Add(3*time.Hour)))
+	mustDo(t, repos.Outbox.MarkFailed(ctx, second.ID, "sink unavailable", retry))
+	mustDo(t, repos.Outbox.MarkFailed(ctx, second.ID, "sink unavailable", retry))
+	expectDue(base.Add(4*time.Hour), 10, unrelated)
+	due = expectDue(retry, 10, second, unrelated)
+	if got := due[0]; got.Attempts != 2 || got.LastError != "sink unavailable" || got.NextAttemptAt == nil || !got.NextAttemptAt.Equal(retry) {
+		t.Errorf("failed event = %d attempts, error %q, next attempt %v; want 2, sink unavailable, %v",
+			got.Attempts, got.LastError, got.NextAttemptAt, retry)
+	}
+
+	// A dead event releases its key.
+	mustDo(t, repos.Outbox.MarkDead(ctx, second.ID, "rejected", base.Add(6*time.Hour)))
+	expectDue(base, 10, third, unrelated)
+
+	deleted, err := repos.Outbox.DeletePublished(ctx, base.Add(4*time.Hour))
+	mustDo(t, err)
+	if deleted != 1 {
+		t.Errorf("DeletePublished = %d, want 1", deleted)
+	}
+	expectDue(base, 10, third, unrelated)
+
+	release, ok, err := repos.Outbox.TryLock(ctx)
+	mustDo(t, err)
+	if !ok {
+		t.Fatal("TryLock on a free lock failed")
+	}
+	release()
+}
+
+func testWebhooks(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	grades := &entity.WebhookSubscription{
+		URL:        "https://hooks.example.com/grades",
+		EventTypes: entity.EventTypeList{entity.EventGradePosted},
+		Secret:     "whsec_grades",
+		Active:     true,
+		CreatedAt:  base,
+	}
+	students := &entity.WebhookSubscription{
+		URL:        "https://hooks.example.com/students",
+		EventTypes: entity.EventTypeList{entity.EventStudentCreated, entity.EventStudentStatusChanged},
+		Secret:     "whsec_students",
+		Active:     true,
+		CreatedAt:  base.Add(time.Hour),
+	}
+	mustDo(t, repos.Webhooks.Create(ctx, grades))
+	mustDo(t, repos.Webhooks.Create(ctx, students))
+	if grades.ID == uuid.Nil || grades.Version != 1 {
+		t.Fatalf("Create left ID %s, version %d; want an ID and version 1", grades.ID, grades.Version)
+	}
+
+	found, err := repos.Webhooks.FindByID(ctx, students.ID)
+	mustDo(t, err)
+	if found.Secret != "whsec_students" || !slices.Equal(found.EventTypes, students.EventTypes) || !found.Wants(entity.EventStudentCreated) {
+		t.Errorf("subscription read back as %+v, want %+v", found, students)
+	}
+
+	listed, total, err := repos.Webhooks.FindAll(ctx, 1, 10)
+	mustDo(t, err)
+	if total != 2 || len(listed) != 2 || listed[0].ID != students.ID {
+		t.Errorf("FindAll = %d of %d, want 2 with the newest first", len(listed), total)
+	}
+
+	mustDo(t, repos.Webhooks.Update(ctx, grades.ID, 1, map[string]interface{}{
+		"active":      false,
+		"event_types": entity.EventTypeList{entity.EventGradePosted, entity.EventEnrollmentCreated},
+	}))
+	expectVersionConflict(t, repos.Webhooks.Update(ctx, grades.ID, 1, map[string]interface{}{"active": true}))
+	found, err = repos.Webhooks.FindByID(ctx, grades.ID)
+	mustDo(t, err)
+	if found.Active || found.Version != 2 || !found.Wants(entity.EventEnrollmentCreated) {
+		t.Errorf("updated subscription = active %v, version %d, events %v; want inactive, 2, with EnrollmentCreated",
+			found.Active, found.Version, found.EventTypes)
+	}
+
+	active, err := repos.Webhooks.FindActive(ctx)
+	mustDo(t, err)
+	if len(active) != 1 || active[0].ID != students.ID {
+		t.Errorf("FindActive = %d subscriptions, want only %s", len(active), students.ID)
+	}
+
+	expectVersionConflict(t, repos.Webhooks.Delete(ctx, students.ID, 2))
+	mustDo(t, repos.Webhooks.Delete(ctx, students.ID, 1))
+	_, err = repos.Webhooks.FindByID(ctx, students.ID)
+	expectNotFound(t, err)
+	active, err = repos.Webhooks.FindActive(ctx)
+	mustDo(t, err)
+	if len(active) != 0 {
+		t.Errorf("FindActive after deleting the only active subscription = %d, want 0", len(active))
+	}
+}
+
+func testWebhookDeliveries(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	subscription := &entity.WebhookSubscription{
+		URL:        "https://hooks.example.com",
+		EventTypes: entity.EventTypeList{entity.EventGradePosted, entity.EventEnrollmentCreated},
+		Secret:     "whsec",
+		Active:     true,
+	}
+	mustDo(t, repos.Webhooks.Create(ctx, subscription))
+
+	newDelivery := func(eventType string, due time.Time) *entity.WebhookDelivery {
+		return &entity.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        uuid.New(),
+			EventType:      eventType,
+			Payload:        `{"type": "` + eventType + `"}`,
+			Status:         entity.DeliveryPending,
+			NextAttemptAt:  &due,
+		}
+	}
+	late := newDelivery(entity.EventGradePosted, base.Add(2*time.Hour))
+	early := newDelivery(entity.EventEnrollmentCreated, base)
+	mustDo(t, repos.Deliveries.CreateMany(ctx, []*entity.WebhookDelivery{late, early}))
+	if late.ID == uuid.Nil {
+		t.Fatal("CreateMany did not assign IDs")
+	}
+	// Fanning the same event out again adds nothing.
+	again := newDelivery(entity.EventGradePosted, base)
+	again.EventID = late.EventID
+	mustDo(t, repos.Deliveries.CreateMany(ctx, []*entity.WebhookDelivery{again}))
+	mustDo(t, repos.Deliveries.CreateMany(ctx, nil))
+
+	ids := func(deliveries []*entity.WebhookDelivery) []uuid.UUID {
+		s := make([]uuid.UUID, len(deliveries))
+		for i, d := range deliveries {
+			s[i] = d.ID
+		}
+		return s
+	}
+	due, err := repos.Deliveries.FindDue(ctx, base.Add(time.Hour), 10)
+	mustDo(t, err)
+	if !slices.Equal(ids(due), []uuid.UUID{early.ID}) {
+		t.Fatalf("FindDue(+1h) = %v, want [%s]", ids(due), early.ID)
+	}
+	due, err = repos.Deliveries.FindDue(ctx, base.Add(3*time.Hour), 10)
+	mustDo(t, err)
+	if !slices.Equal(ids(due), []uuid.UUID{early.ID, late.ID}) {
+		t.Fatalf("FindDue(+3h) = %v, want [%s %s]", ids(due), early.ID, late.ID)
+	}
+
+	retry := base.Add(4 * time.Hour)
+	mustDo(t, repos.Deliveries.Update(ctx, early.ID, map[string]interface{}{
+		"attempts":         1,
+		"last_attempt_at":  base,
+		"last_status_code": 503,
+		"last_error":       "endpoint responded 503 Service Unavailable",
+		"next_attempt_at":  retry,
+	}))
+	mustDo(t, repos.Deliveries.Update(ctx, late.ID, map[string]interface{}{
+		"status":           entity.DeliverySucceeded,
+		"attempts":         1,
+		"last_status_code": 200,
+		"next_attempt_at":  nil,
+		"delivered_at":     base.Add(2 * time.Hour),
+	}))
+	found, err := repos.Deliveries.FindByID(ctx, early.ID)
+	mustDo(t, err)
+	if found.Attempts != 1 || found.LastStatusCode != 503 || found.NextAttemptAt == nil || !found.NextAttemptAt.Equal(retry) ||
+		found.Payload != early.Payload {
+		t.Errorf("failed delivery read back as %+v", found)
+	}
+	found, err = repos.Deliveries.FindByID(ctx, late.ID)
+	mustDo(t, err)
+	if found.Status != entity.DeliverySucceeded || found.NextAttemptAt != nil || found.DeliveredAt == nil {
+		t.Errorf("succeeded delivery read back as %+v", found)
+	}
+	_, err = repos.Deliveries.FindByID(ctx, uuid.New())
+	expectNotFound(t, err)
+
+	due, err = repos.Deliveries.FindDue(ctx, base.Add(3*time.Hour), 10)
+	mustDo(t, err)
+	if len(due) != 0 {
+		t.Errorf("FindDue(+3h) after the attempts = %v, want none", ids(due))
+	}
+
+	listed, total, err := repos.Deliveries.FindAll(ctx, repository.DeliveryFilter{SubscriptionID: &subscription.ID}, 1, 10)
+	mustDo(t, err)
+	if total != 2 || len(listed) != 2 {
+		t.Errorf("FindAll by subscription = %d of %d, want 2", len(listed), total)
+	}
+	listed, total, err = repos.Deliveries.FindAll(ctx, repository.DeliveryFilter{Status: entity.DeliverySucceeded}, 1, 10)
+	mustDo(t, err)
+	if total != 1 || !slices.Equal(ids(listed), []uuid.UUID{late.ID}) {
+		t.Errorf("FindAll(succeeded) = %v of %d, want [%s]", ids(listed), total, late.ID)
+	}
+	listed, total, err = repos.Deliveries.FindAll(ctx, repository.DeliveryFilter{EventType: entity.EventEnrollmentCreated}, 1, 10)
+	mustDo(t, err)
+	if total != 1 || !slices.Equal(ids(listed), []uuid.UUID{early.ID}) {
+		t.Errorf("FindAll(EnrollmentCreated) = %v of %d, want [%s]", ids(listed), total, early.ID)
+	}
+
+	// Only the finished delivery goes, however old the pending one is.
+	deleted, err := repos.Deliveries.DeleteFinished(ctx, time.Now().Add(time.Hour))
+	mustDo(t, err)
+	if deleted != 1 {
+		t.Errorf("DeleteFinished = %d, want 1", deleted)
+	}
+	_, err = repos.Deliveries.FindByID(ctx, late.ID)
+	expectNotFound(t, err)
+
+	release, ok, err := repos.Deliveries.TryLock(ctx)
+	mustDo(t, err)
+	if !ok {
+		t.Fatal("TryLock on a free lock failed")
+	}
+	release()
+}
+
+func testSeatChangeBus(t *testing.T, repos Repositories) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	heard := make(chan repository.SeatChange, 10)
+	listening := make(chan error, 1)
+	go func() {
+		listening <- repos.SeatBus.Listen(ctx, func(change repository.SeatChange) {
+			select {
+			case heard <- change:
+			default:
+			}
+		})
+	}()
+
+	// The listener may take a moment to start; announce until it hears.
+	want := repository.SeatChange{CourseID: uuid.New(), AcademicYear: "2024/2025", Semester: 1}
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	for received := false; !received; {
+		mustDo(t, repos.SeatBus.Announce(ctx, want))
+		select {
+		case got := <-heard:
+			if got != want {
+				t.Fatalf("heard %+v, want %+v", got, want)
+			}
+			received = true
+		case err := <-listening:
+			t.Fatalf("Listen returned early: %v", err)
+		case <-ctx.Done():
+			t.Fatal("no change heard")
+		case <-ticker.C:
+		}
+	}
+
+	cancel()
+	select {
+	case <-listening:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Listen did not return once its context was done")
+	}
+}
+
+func testWaitlists(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	ani := newStudent("2024001", "Ani", "Computer Science", 0)
+	budi := newStudent("2024002", "Budi", "Computer Science", 1)
+	citra := newStudent("2024003", "Citra", "Computer Science", 2)
+	course := newCourse("IF101", "Databases", 1)
+	for _, s := range []*entity.Student{ani, budi, citra} {
+		mustDo(t, repos.Students.Create(ctx, s))
+	}
+	mustDo(t, repos.Courses.Create(ctx, course))
+
+	// Positions decide the order, ties go to the earlier entry.
+	first := newWaitlistEntry(budi.ID, course.ID, 1, 0)
+	second := newWaitlistEntry(ani.ID, course.ID, 2, 1)
+	tied := newWaitlistEntry(citra.ID, course.ID, 1, 2)
+	for _, e := range []*entity.WaitlistEntry{first, second, tied} {
+		mustDo(t, repos.Waitlists.Create(ctx, e))
+	}
+	if first.ID == uuid.Nil || first.Status != entity.WaitlistWaiting || first.Version != 1 {
+		t.Errorf("Create filled id=%s status=%q version=%d", first.ID, first.Status, first.Version)
+	}
+
+	expectWaiting := func(want ...*entity.WaitlistEntry) {
+		t.Helper()
+		waiting, err := repos.Waitlists.FindWaiting(ctx, course.ID, "2024/2025", 1)
+		mustDo(t, err)
+		if len(waiting) != len(want) {
+			t.Fatalf("FindWaiting returned %d entries, want %d", len(waiting), len(want))
+		}
+		for i := range want {
+			if waiting[i].ID != want[i].ID {
+				t.Errorf("FindWaiting[%d] = %s, want %s", i, waiting[i].ID, want[i].ID)
+			}
+		}
+	}
+	expectWaiting(first, tied, second)
+
+	expectConflict(t, repos.Waitlists.Create(ctx, newWaitlistEntry(ani.ID, course.ID, 3, 3)),
+		"student_id_course_id_academic_year_semester")
+
+	enrollmentID := uuid.New()
+	promotedAt := base.Add(5 * time.Hour)
+	mustDo(t, repos.Waitlists.Update(ctx, first.ID, 1, map[string]interface{}{
+		"status":        entity.WaitlistPromoted,
+		"enrollment_id": enrollmentID,
+		"promoted_at":   promotedAt,
+	}))
+	expectVersionConflict(t, repos.Waitlists.Update(ctx, first.ID, 1, map[string]interface{}{"position": 9}))
+
+	found, err := repos.Waitlists.FindByID(ctx, first.ID)
+	mustDo(t, err)
+	if found.Status != entity.WaitlistPromoted || found.EnrollmentID == nil || *found.EnrollmentID != enrollmentID ||
+		found.PromotedAt == nil || !found.PromotedAt.Equal(promotedAt) || found.Version != 2 {
+		t.Errorf("after Update got status=%q enrollment=%v promoted_at=%v version=%d",
+			found.Status, found.EnrollmentID, found.PromotedAt, found.Version)
+	}
+	expectWaiting(tied, second)
+
+	// Only waiting entries are unique: a promoted student may wait again.
+	again := newWaitlistEntry(budi.ID, course.ID, 3, 4)
+	mustDo(t, repos.Waitlists.Create(ctx, again))
+	expectWaiting(tied, second, again)
+
+	_, err = repos.Waitlists.FindByID(ctx, uuid.New())
+	expectNotFound(t, err)
+}
+
+func testAdvisors(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := &entity.User{Username: "dewi", Email: "dewi@example.com", Password: "hash", Role: "staff", IsActive: true}
+	mustDo(t, repos.Users.Create(ctx, user))
+	ani := newStudent("2024001", "Ani", "Computer Science", 0)
+	dewi := newLecturer("198001", "Dewi", "Informatics", 0)
+	dewi.UserID = &user.ID
+	eko := newLecturer("198002", "Eko", "Informatics", 1)
+	mustDo(t, repos.Students.Create(ctx, ani))
+	mustDo(t, repos.Lecturers.Create(ctx, dewi))
+	mustDo(t, repos.Lecturers.Create(ctx, eko))
+
+	found, err := repos.Lecturers.FindByUserID(ctx, user.ID)
+	mustDo(t, err)
+	if found.ID != dewi.ID {
+		t.Errorf("FindByUserID returned %s, want %s", found.ID, dewi.ID)
+	}
+	_, err = repos.Lecturers.FindByUserID(ctx, uuid.New())
+	expectNotFound(t, err)
+
+	_, err = repos.Advisors.FindCurrent(ctx, ani.ID)
+	expectNotFound(t, err)
+	expectNotFound(t, repos.Advisors.End(ctx, ani.ID, base))
+
+	first := &entity.AdvisorAssignment{StudentID: ani.ID, LecturerID: dewi.ID, StartedAt: base}
+	mustDo(t, repos.Advisors.Create(ctx, first))
+	if first.ID == uuid.Nil {
+		t.Fatal("Create did not assign an ID")
+	}
+	expectConflict(t, repos.Advisors.Create(ctx, &entity.AdvisorAssignment{StudentID: ani.ID, LecturerID: eko.ID, StartedAt: base}),
+		"student_id")
+
+	// Ending the current assignment makes room for the next one.
+	mustDo(t, repos.Advisors.End(ctx, ani.ID, base.Add(time.Hour)))
+	second := &entity.AdvisorAssignment{StudentID: ani.ID, LecturerID: eko.ID, StartedAt: base.Add(time.Hour)}
+	mustDo(t, repos.Advisors.Create(ctx, second))
+
+	current, err := repos.Advisors.FindCurrent(ctx, ani.ID)
+	mustDo(t, err)
+	if current.ID != second.ID || current.LecturerID != eko.ID || current.EndedAt != nil {
+		t.Errorf("FindCurrent = %+v, want assignment %s to Eko", current, second.ID)
+	}
+
+	history, err := repos.Advisors.FindByStudent(ctx, ani.ID)
+	mustDo(t, err)
+	if len(history) != 2 || history[0].ID != second.ID || history[1].ID != first.ID {
+		t.Fatalf("FindByStudent returned %d assignments, want the second before the first", len(history))
+	}
+	if history[1].EndedAt == nil || !history[1].EndedAt.Equal(base.Add(time.Hour)) {
+		t.Errorf("first assignment ended_at = %v, want %v", history[1].EndedAt, base.Add(time.Hour))
+	}
+}
+
+func testKRS(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	ani := newStudent("2024001", "Ani", "Computer Science", 0)
+	budi := newStudent("2024002", "Budi", "Computer Science", 1)
+	citra := newStudent("2024003", "Citra", "Computer Science", 2)
+	for _, s := range []*entity.Student{ani, budi, citra} {
+		mustDo(t, repos.Students.Create(ctx, s))
+	}
+	dewi := newLecturer("198001", "Dewi", "Informatics", 0)
+	mustDo(t, repos.Lecturers.Create(ctx, dewi))
+	course := newCourse("IF101", "Databases", 1)
+	mustDo(t, repos.Courses.Create(ctx, course))
+
+	older := &entity.KRSSubmission{StudentID: ani.ID, AcademicYear: "2023/2024", Semester: 2}
+	draft := &entity.KRSSubmission{StudentID: ani.ID, AcademicYear: "2024/2025", Semester: 1}
+	mustDo(t, repos.KRS.Create(ctx, older))
+	mustDo(t, repos.KRS.Create(ctx, draft))
+	if draft.ID == uuid.Nil || draft.Status != entity.KRSDraft || draft.Version != 1 {
+		t.Errorf("Create filled id=%s status=%q version=%d", draft.ID, draft.Status, draft.Version)
+	}
+	expectConflict(t, repos.KRS.Create(ctx, &entity.KRSSubmission{StudentID: ani.ID, AcademicYear: "2024/2025", Semester: 1}),
+		"student_id_academic_year_semester")
+
+	found, err := repos.KRS.FindByStudentTerm(ctx, ani.ID, "2024/2025", 1)
+	mustDo(t, err)
+	if found.ID != draft.ID {
+		t.Errorf("FindByStudentTerm returned %s, want %s", found.ID, draft.ID)
+	}
+	_, err = repos.KRS.FindByStudentTerm(ctx, ani.ID, "2024/2025", 2)
+	expectNotFound(t, err)
+
+	terms, err := repos.KRS.FindByStudent(ctx, ani.ID)
+	mustDo(t, err)
+	if len(terms) != 2 || terms[0].ID != draft.ID || terms[1].ID != older.ID {
+		t.Errorf("FindByStudent returned %d submissions, want the latest term first", len(terms))
+	}
+
+	// Enrollments hold a seat once their KRS is in one of the given states.
+	for i, s := range []*entity.Student{ani, budi, citra} {
+		mustDo(t, repos.Enrollments.Create(ctx, newEnrollment(s.ID, course.ID, i)))
+	}
+	submit := func(k *entity.KRSSubmission, hour int) {
+		t.Helper()
+		mustDo(t, repos.KRS.Update(ctx, k.ID, k.Version, map[string]interface{}{
+			"status":       entity.KRSSubmitted,
+			"advisor_id":   dewi.ID,
+			"submitted_at": base.Add(time.Duration(hour) * time.Hour),
+		}))
+		k.Version++
+	}
+	expectVersionConflict(t, repos.KRS.Update(ctx, draft.ID, 2, map[string]interface{}{"status": entity.KRSSubmitted}))
+	submit(draft, 3)
+	budiKRS := &entity.KRSSubmission{StudentID: budi.ID, AcademicYear: "2024/2025", Semester: 1}
+	mustDo(t, repos.KRS.Create(ctx, budiKRS))
+	submit(budiKRS, 2)
+	mustDo(t, repos.KRS.Update(ctx, budiKRS.ID, budiKRS.Version, map[string]interface{}{"status": entity.KRSApproved}))
+
+	expectTaken := func(krs []string, want int64) {
+		t.Helper()
+		taken, err := repos.Enrollments.CountActiveByCourse(ctx, course.ID, "2024/2025", 1, krs)
+		mustDo(t, err)
+		if taken != want {
+			t.Errorf("CountActiveByCourse(%v) = %d, want %d", krs, taken, want)
+		}
+	}
+	expectTaken(nil, 3)
+	expectTaken(entity.KRSSeatStatuses(entity.KRSApproved), 1)
+	expectTaken(entity.KRSSeatStatuses(entity.KRSSubmitted), 2)
+
+	// An enrollment promoted from the waitlist holds its seat whatever its
+	// KRS; one merely waiting does not.
+	eko := newStudent("2024004", "Eko", "Computer Science", 3)
+	mustDo(t, repos.Students.Create(ctx, eko))
+	promoted := newEnrollment(eko.ID, course.ID, 3)
+	mustDo(t, repos.Enrollments.Create(ctx, promoted))
+	entry := newWaitlistEntry(eko.ID, course.ID, 1, 3)
+	mustDo(t, repos.Waitlists.Create(ctx, entry))
+	expectTaken(entity.KRSSeatStatuses(entity.KRSApproved), 1)
+	mustDo(t, repos.Waitlists.Update(ctx, entry.ID, entry.Version, map[string]interface{}{
+		"status":        entity.WaitlistPromoted,
+		"enrollment_id": promoted.ID,
+		"promoted_at":   base.Add(4 * time.Hour),
+	}))
+	expectTaken(nil, 4)
+	expectTaken(entity.KRSSeatStatuses(entity.KRSApproved), 2)
+	expectTaken(entity.KRSSeatStatuses(entity.KRSSubmitted), 3)
+
+	citraKRS := &entity.KRSSubmission{StudentID: citra.ID, AcademicYear: "2024/2025", Semester: 1}
+	mustDo(t, repos.KRS.Create(ctx, citraKRS))
+	submit(citraKRS, 1)
+
+	inbox, total, err := repos.KRS.FindSubmitted(ctx, dewi.ID, 1, 10)
+	mustDo(t, err)
+	if total != 2 || len(inbox) != 2 || inbox[0].ID != citraKRS.ID || inbox[1].ID != draft.ID {
+		t.Errorf("FindSubmitted returned %d of %d, want Citra's then Ani's submission", len(inbox), total)
+	}
+	inbox, total, err = repos.KRS.FindSubmitted(ctx, dewi.ID, 2, 1)
+	mustDo(t, err)
+	if total != 2 || len(inbox) != 1 || inbox[0].ID != draft.ID {
+		t.Errorf("FindSubmitted page 2 returned %d of %d, want Ani's submission", len(inbox), total)
+	}
+
+	first := &entity.KRSReview{SubmissionID: draft.ID, Decision: entity.KRSRevisionRequested, Comment: "Take IF102 first", LecturerID: &dewi.ID, CreatedAt: base.Add(4 * time.Hour)}
+	second := &entity.KRSReview{SubmissionID: draft.ID, Decision: entity.KRSApproved, LecturerID: &dewi.ID, CreatedAt: base.Add(5 * time.Hour)}
+	mustDo(t, repos.KRS.AddReview(ctx, second))
+	mustDo(t, repos.KRS.AddReview(ctx, first))
+	reviews, err := repos.KRS.FindReviews(ctx, draft.ID)
+	mustDo(t, err)
+	if len(reviews) != 2 || reviews[0].ID != first.ID || reviews[1].ID != second.ID || reviews[0].Comment != "Take IF102 first" {
+		t.Errorf("FindReviews returned %d reviews, want the revision request before the approval", len(reviews))
+	}
+}
+
+func newStudent(nim, name, major string, hour int) *entity.Student {
+	return &entity.Student{
+		NIM:            nim,
+		Name:           name,
+		Email:          nim + "@students.example.com",
+		Gender:         "female",
+		Major:          major,
+		EnrollmentYear: 2024,
+		CreatedAt:      base.Add(time.Duration(hour) * time.Hour),
+	}
+}
+
+func newLecturer(nip, name, department string, hour int) *entity.Lecturer {
+	return &entity.Lecturer{
+		NIP:        nip,
+		Name:       name,
+		Email:      nip + "@staff.example.com",
+		Gender:     "male",
+		Department: department,
+		CreatedAt:  base.Add(time.Duration(hour) * time.Hour),
+	}
+}
+
+func newCourse(code, name string, semester int) *entity.Course {
+	return &entity.Course{
+		Code:       code,
+		Name:       name,
+		Credits:    3,
+		Semester:   semester,
+		Department: "Informatics",
+		CourseType: "mandatory",
+	}
+}
+
+// newEnrollment sets a grade because the grade CHECK constraint rejects
+// the empty string.
+func newEnrollment(studentID, courseID uuid.UUID, hour int) *entity.Enrollment {
+	return &entity.Enrollment{
+		StudentID:    studentID,
+		CourseID:     courseID,
+		AcademicYear: "2024/2025",
+		Semester:     1,
+		Grade:        "A",
+		CreatedAt:    base.Add(time.Duration(hour) * time.Hour),
+	}
+}
+
+func newWaitlistEntry(studentID, courseID uuid.UUID, position, hour int) *entity.WaitlistEntry {
+	return &entity.WaitlistEntry{
+		StudentID:    studentID,
+		CourseID:     courseID,
+		AcademicYear: "2024/2025",
+		Semester:     1,
+		Position:     position,
+		CreatedAt:    base.Add(time.Duration(hour) * time.Hour),
+	}
+}
+
+func mustDo(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func expectNotFound(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("got %v, want repository.ErrNotFound", err)
+	}
+}
+
+func expectVersionConflict(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, repository.ErrVersionConflict) {
+		t.Errorf("got %v, want ErrVersionConflict", err)
+	}
+}
+
+func expectConflict(t *testing.T, err error, field string) {
+	t.Helper()
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperror.KindConflict || appErr.Field != field {
+		t.Errorf("got %v, want a conflict on %q", err, field)
+	}
+}
+
+func expectNIMs(t *testing.T, op string, got, want []*entity.Student) {
+	t.Helper()
+	nims := func(students []*entity.Student) string {
+		s := make([]string, len(students))
+		for i, student := range students {
+			s[i] = student.NIM
+		}
+		return fmt.Sprint(s)
+	}
+	if nims(got) != nims(want) {
+		t.Errorf("%s returned %s, want %s", op, nims(got), nims(want))
+	}
+}
@@ -0,0 +1,118 @@
+// File: internal/repository/sqlite/migrate.go
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/haninhammoud01/go-academic-service/database"
+)
+
+// The layout golang-migrate uses, so MigrationCheck and the migrate CLI
+// both understand a database migrated here.
+const createMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (version uint64, dirty bool);
+CREATE UNIQUE INDEX IF NOT EXISTS version_unique ON schema_migrations (version);`
+
+// Migrate applies the up migrations newer than the recorded version, each
+// in its own transaction together with the version bump. SQLite DDL is
+// transactional, so a failed migration leaves the previous version intact.
+//
+// Migrations run on one connection with foreign keys off, as SQLite's
+// procedure for rebuilding a table requires: dropping a referenced table
+// would otherwise cascade to the rows referencing it. Each migration must
+// leave every foreign key satisfied before it commits.
+func Migrate(ctx context.Context, pool *sql.DB, migrations fs.FS) error {
+	db, err := pool.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer db.ExecContext(context.WithoutCancel(ctx), "PRAGMA foreign_keys = ON")
+
+	if _, err := db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var (
+		current uint
+		dirty   bool
+	)
+	err = db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to read migration version: %w", err)
+	case dirty:
+		return fmt.Errorf("migration %d is dirty; repair the schema and schema_migrations by hand", current)
+	}
+
+	files, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		version, err := database.Version(file)
+		if err != nil {
+			return err
+		}
+		if version <= current {
+			continue
+		}
+		script, err := fs.ReadFile(migrations, file)
+		if err != nil {
+			return err
+		}
+		if err := apply(ctx, db, version, string(script)); err != nil {
+			return fmt.Errorf("migration %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func apply(ctx context.Context, db *sql.Conn, version uint, script string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err := checkForeignKeys(ctx, tx); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// checkForeignKeys fails when a row references a missing one, which the
+// migrations could not be stopped from creating with foreign keys off.
+func checkForeignKeys(ctx context.Context, tx *sql.Tx) error {
+	var table, parent string
+	var rowid sql.NullInt64
+	var fkid int
+	err := tx.QueryRowContext(ctx, "PRAGMA foreign_key_check").Scan(&table, &rowid, &parent, &fkid)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	return fmt.Errorf("a row of %s references a missing row of %s", table, parent)
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/haninhammoud01/go-academic-service/database"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/postgres"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/repotest"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestRepositoryContract runs the GORM repositories against a freshly
+// migrated database file per subtest.
+func TestRepositoryContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db := openTestDatabase(t)
+		var timeouts postgres.QueryTimeouts
+		return repotest.Repositories{
+			Users:       postgres.NewUserRepository(db, timeouts),
+			Students:    postgres.NewStudentRepository(db, timeouts),
+			Lecturers:   postgres.NewLecturerRepository(db, timeouts),
+			Courses:     postgres.NewCourseRepository(db, timeouts),
+			Enrollments: postgres.NewEnrollmentRepository(db, timeouts),
+			Search:      postgres.NewSearchRepository(db, timeouts),
+			Audit:       postgres.NewAuditRepository(db, timeouts),
+			Outbox:      postgres.NewOutboxRepository(db, timeouts),
+			Webhooks:    postgres.NewWebhookSubscriptionRepository(db, timeouts),
+			Deliveries:  postgres.NewWebhookDeliveryRepository(db, timeouts),
+			SeatBus:     postgres.NewSeatChangeBus(db),
+			Waitlists:   postgres.NewWaitlistRepository(db, timeouts),
+			Advisors:    postgres.NewAdvisorRepository(db, timeouts),
+			KRS:         postgres.NewKRSRepository(db, timeouts),
+		}
+	})
+}
+
+func openTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrations, err := database.ForDriver("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Migrate(context.Background(), sqlDB, migrations); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,53 @@
+// File: internal/repository/sqlite/sqlite.go
+
+// Package sqlite runs the service on an embedded SQLite file for single
+// node and demo deployments. The repositories themselves are the GORM
+// ones in repository/postgres, whose queries stay portable; this package
+// opens the database and applies the SQLite migrations.
+package sqlite
+
+import (
+	"database/sql/driver"
+	"net/url"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/textsearch"
+	"gorm.io/gorm"
+)
+
+// search_fold is the SQL function Postgres defines in migration 000008;
+// here it is textsearch.Fold itself, available on every connection.
+func init() {
+	gosqlite.MustRegisterDeterministicScalarFunction("search_fold", 1, func(_ *gosqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch value := args[0].(type) {
+		case string:
+			return textsearch.Fold(value), nil
+		case []byte:
+			return textsearch.Fold(string(value)), nil
+		}
+		return args[0], nil
+	})
+}
+
+// DSN opens path with foreign keys enforced, WAL journaling so readers do
+// not block the writer, a busy timeout instead of immediate SQLITE_BUSY
+// errors, and write transactions that take the lock when they begin.
+// Times are written in SQLite's own format so its date functions work.
+func DSN(path string) string {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Set("_txlock", "immediate")
+	query.Set("_time_format", "sqlite")
+	return "file:" + path + "?" + query.Encode()
+}
+
+// Open opens the SQLite database at path. Timestamps are kept in UTC:
+// SQLite compares them as text, so mixed offsets would sort wrongly.
+func Open(path string, config *gorm.Config) (*gorm.DB, error) {
+	config.NowFunc = func() time.Time { return time.Now().UTC() }
+	return gorm.Open(sqlite.Open(DSN(path)), config)
+}
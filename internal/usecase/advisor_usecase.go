@@ -0,0 +1,181 @@
+// File: internal/usecase/advisor_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+// AdvisorUseCase assigns students their academic advisor (dosen wali),
+// the lecturer who reviews their KRS.
+type AdvisorUseCase interface {
+	// Assign makes a lecturer the advisor of a student, ending the
+	// current assignment. KRS waiting for review move to the new advisor.
+	Assign(ctx context.Context, studentID, lecturerID uuid.UUID) (*entity.AdvisorAssignment, error)
+	Current(ctx context.Context, studentID uuid.UUID) (*entity.AdvisorAssignment, error)
+	// History returns all assignments of a student, newest first.
+	History(ctx context.Context, studentID uuid.UUID) ([]*entity.AdvisorAssignment, error)
+	// Unassign ends the current assignment of a student.
+	Unassign(ctx context.Context, studentID uuid.UUID) error
+}
+
+type advisorUseCaseImpl struct {
+	repo         repository.AdvisorRepository
+	studentRepo  repository.StudentRepository
+	lecturerRepo repository.LecturerRepository
+	krsRepo      repository.KRSRepository
+	txManager    repository.TxManager
+	auditor      *Auditor
+}
+
+func NewAdvisorUseCase(
+	repo repository.AdvisorRepository,
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	krsRepo repository.KRSRepository,
+	txManager repository.TxManager,
+	auditor *Auditor,
+) AdvisorUseCase {
+	return &advisorUseCaseImpl{
+		repo:         repo,
+		studentRepo:  studentRepo,
+		lecturerRepo: lecturerRepo,
+		krsRepo:      krsRepo,
+		txManager:    txManager,
+		auditor:      auditor,
+	}
+}
+
+func (uc *advisorUseCaseImpl) Assign(ctx context.Context, studentID, lecturerID uuid.UUID) (*entity.AdvisorAssignment, error) {
+	var assignment *entity.AdvisorAssignment
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkStudent(ctx, studentID); err != nil {
+			return err
+		}
+		lecturer, err := uc.lecturerRepo.FindByID(ctx, lecturerID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return apperror.NotFound("lecturer not found")
+			}
+			return err
+		}
+		if lecturer.Status != "active" {
+			return apperror.Validation("lecturer is not active")
+		}
+
+		current, err := uc.repo.FindCurrent(ctx, studentID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if current != nil && current.LecturerID == lecturerID {
+			assignment = current
+			return nil
+		}
+
+		now := time.Now().UTC()
+		if current != nil {
+			if err := uc.end(ctx, current, now); err != nil {
+				return err
+			}
+		}
+		assignment = &entity.AdvisorAssignment{StudentID: studentID, LecturerID: lecturerID, StartedAt: now}
+		if err := uc.repo.Create(ctx, assignment); err != nil {
+			return err
+		}
+		if err := uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditAdvisor, assignment.ID, nil, assignment); err != nil {
+			return err
+		}
+		return uc.moveSubmitted(ctx, studentID, lecturerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// moveSubmitted hands the student's KRS waiting for review to the new
+// advisor.
+func (uc *advisorUseCaseImpl) moveSubmitted(ctx context.Context, studentID, lecturerID uuid.UUID) error {
+	submissions, err := uc.krsRepo.FindByStudent(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	for _, krs := range submissions {
+		if krs.Status != entity.KRSSubmitted {
+			continue
+		}
+		if err := uc.krsRepo.Update(ctx, krs.ID, krs.Version, map[string]interface{}{"advisor_id": lecturerID}); err != nil {
+			return err
+		}
+		moved, err := uc.krsRepo.FindByID(ctx, krs.ID)
+		if err != nil {
+			return err
+		}
+		if err := uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditKRS, krs.ID, krs, moved); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *advisorUseCaseImpl) Current(ctx context.Context, studentID uuid.UUID) (*entity.AdvisorAssignment, error) {
+	assignment, err := uc.repo.FindCurrent(ctx, studentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("student has no advisor")
+		}
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (uc *advisorUseCaseImpl) History(ctx context.Context, studentID uuid.UUID) ([]*entity.AdvisorAssignment, error) {
+	if err := uc.checkStudent(ctx, studentID); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindByStudent(ctx, studentID)
+}
+
+func (uc *advisorUseCaseImpl) Unassign(ctx context.Context, studentID uuid.UUID) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := uc.Current(ctx, studentID)
+		if err != nil {
+			return err
+		}
+		submissions, err := uc.krsRepo.FindByStudent(ctx, studentID)
+		if err != nil {
+			return err
+		}
+		for _, krs := range submissions {
+			if krs.Status == entity.KRSSubmitted {
+				return apperror.Conflict("student_id", "student has a KRS waiting for review, assign another advisor instead")
+			}
+		}
+		return uc.end(ctx, current, time.Now().UTC())
+	})
+}
+
+func (uc *advisorUseCaseImpl) end(ctx context.Context, current *entity.AdvisorAssignment, at time.Time) error {
+	if err := uc.repo.End(ctx, current.StudentID, at); err != nil {
+		return err
+	}
+	ended := *current
+	ended.EndedAt = &at
+	return uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditAdvisor, current.ID, current, &ended)
+}
+
+func (uc *advisorUseCaseImpl) checkStudent(ctx context.Context, studentID uuid.UUID) error {
+	if _, err := uc.studentRepo.FindByID(ctx, studentID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return apperror.NotFound("student not found")
+		}
+		return err
+	}
+	return nil
+}
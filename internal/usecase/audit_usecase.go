@@ -0,0 +1,248 @@
+// File: internal/usecase/audit_usecase.go
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/actor"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+)
+
+// verifyBatchSize is how many entries Verify reads per query.
+const verifyBatchSize = 500
+
+// Auditor appends an entry to the audit log for every change a use case
+// makes. Record must be called inside the unit of work that makes the
+// change, so the entry commits or rolls back with it.
+//
+// With hash chaining on, each entry is sealed with the hash of the one
+// before it. Two units of work extending the chain at once read the same
+// latest hash; the SERIALIZABLE isolation of the TxManager aborts one of
+// them, and it is retried against the new end of the chain.
+type Auditor struct {
+	repo      repository.AuditRepository
+	hashChain bool
+}
+
+func NewAuditor(repo repository.AuditRepository, hashChain bool) *Auditor {
+	return &Auditor{repo: repo, hashChain: hashChain}
+}
+
+// Record logs action on the entity with id. before and after are the
+// record as it was and as it is now; before is nil for a create and after
+// is nil for a delete or purge.
+func (a *Auditor) Record(ctx context.Context, action, entityType string, id uuid.UUID, before, after interface{}) error {
+	changes, err := diff(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s: %w", entityType, err)
+	}
+
+	caller := actor.From(ctx)
+	entry := &entity.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+		ActorRole:  caller.Role,
+		IP:         caller.IP,
+		RequestID:  logger.RequestID(ctx),
+		Changes:    changes,
+		// The database keeps microseconds; the hash must survive the
+		// round trip.
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if caller.UserID != uuid.Nil {
+		entry.ActorID = &caller.UserID
+	}
+
+	if a.hashChain {
+		if entry.PrevHash, err = a.repo.LatestHash(ctx); err != nil {
+			return fmt.Errorf("failed to read audit chain: %w", err)
+		}
+		if entry.Hash, err = chainHash(entry); err != nil {
+			return err
+		}
+	}
+	return a.repo.Create(ctx, entry)
+}
+
+// ignoredFields are bookkeeping that changes with every write.
+var ignoredFields = []string{"id", "version", "created_at", "updated_at", "deleted_at"}
+
+// diff compares two records through their JSON form, so changes carry the
+// API's field names and hidden fields such as password hashes stay out.
+// Either side may be nil, which lists every field of the other. Nested
+// objects are preloaded relations and are left out as well.
+func diff(before, after interface{}) (entity.AuditChanges, error) {
+	old, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	current, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := entity.AuditChanges{}
+	for _, fields := range []map[string]interface{}{old, current} {
+		for name := range fields {
+			if _, done := changes[name]; done || slices.Contains(ignoredFields, name) {
+				continue
+			}
+			o, n := old[name], current[name]
+			if isObject(o) || isObject(n) || reflect.DeepEqual(o, n) {
+				continue
+			}
+			changes[name] = entity.FieldChange{Old: o, New: n}
+		}
+	}
+	return changes, nil
+}
+
+func jsonFields(record interface{}) (map[string]interface{}, error) {
+	if v := reflect.ValueOf(record); record == nil || v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, nil
+	}
+	b, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	return fields, json.Unmarshal(b, &fields)
+}
+
+func isObject(v interface{}) bool {
+	_, ok := v.(map[string]interface{})
+	return ok
+}
+
+// chainHash seals entry: a SHA-256 over the previous hash and every field
+// except the ID and the hash itself. Changes are hashed in their JSON form
+// with sorted keys, which reads back the same from jsonb.
+func chainHash(entry *entity.AuditLog) (string, error) {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+	actorID := ""
+	if entry.ActorID != nil {
+		actorID = entry.ActorID.String()
+	}
+
+	h := sha256.New()
+	for _, part := range []string{
+		entry.PrevHash,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		entry.Action,
+		entry.EntityType,
+		entry.EntityID.String(),
+		actorID,
+		entry.ActorRole,
+		entry.IP,
+		entry.RequestID,
+		string(changes),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// AuditVerification is the outcome of walking the hash chain. When Valid
+// is false, BrokenAt is the first entry that does not check out. Head is
+// the hash of the last entry that did, the newest one for a valid log;
+// recording it elsewhere lets a later run notice entries removed from the
+// end of the log as well.
+type AuditVerification struct {
+	Valid    bool
+	Entries  int64
+	BrokenAt int64
+	Reason   string
+	Head     string
+}
+
+type AuditUseCase interface {
+	List(ctx context.Context, filter repository.AuditFilter, page, pageSize int) ([]*entity.AuditLog, int64, error)
+	// Verify checks every entry against its hash and its predecessor.
+	Verify(ctx context.Context) (*AuditVerification, error)
+}
+
+type auditUseCaseImpl struct {
+	repo      repository.AuditRepository
+	hashChain bool
+}
+
+func NewAuditUseCase(repo repository.AuditRepository, hashChain bool) AuditUseCase {
+	return &auditUseCaseImpl{repo: repo, hashChain: hashChain}
+}
+
+func (uc *auditUseCaseImpl) List(ctx context.Context, filter repository.AuditFilter, page, pageSize int) ([]*entity.AuditLog, int64, error) {
+	if filter.EntityType != "" && !slices.Contains(entity.AuditEntityTypes, filter.EntityType) {
+		return nil, 0, apperror.Validation(fmt.Sprintf("unknown entity type %q", filter.EntityType))
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, 0, apperror.Validation("from must be before to")
+	}
+	return uc.repo.FindAll(ctx, filter, page, pageSize)
+}
+
+// Verify fails on the first entry without a hash, so entries written
+// while hash chaining was off make the chain unverifiable from there on.
+func (uc *auditUseCaseImpl) Verify(ctx context.Context) (*AuditVerification, error) {
+	if !uc.hashChain {
+		return nil, apperror.Validation("audit hash chaining is disabled")
+	}
+
+	result := &AuditVerification{Valid: true}
+	var lastID int64
+	for {
+		entries, err := uc.repo.FindAfter(ctx, lastID, verifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			result.Entries++
+			reason, err := checkLink(entry, result.Head)
+			if err != nil {
+				return nil, err
+			}
+			if reason != "" {
+				result.Valid, result.BrokenAt, result.Reason = false, entry.ID, reason
+				return result, nil
+			}
+			result.Head, lastID = entry.Hash, entry.ID
+		}
+		if len(entries) < verifyBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// checkLink returns why entry does not follow an entry with hash prev, or
+// "" when it does.
+func checkLink(entry *entity.AuditLog, prev string) (string, error) {
+	if entry.Hash == "" {
+		return "entry is not sealed", nil
+	}
+	if entry.PrevHash != prev {
+		return "entry does not link to the one before it", nil
+	}
+	hash, err := chainHash(entry)
+	if err != nil {
+		return "", err
+	}
+	if hash != entry.Hash {
+		return "entry does not match its hash", nil
+	}
+	return "", nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/memory"
+)
+
+func TestAuditChainUnderConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	audit := memory.NewAuditRepository()
+	students := NewStudentUseCase(memory.NewStudentRepository(), memory.NewTxManager(), NewAuditor(audit, true), NewOutbox(memory.NewOutboxRepository()))
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- students.Create(ctx, &entity.Student{
+				NIM:   fmt.Sprintf("2021%03d", i),
+				Name:  "Student",
+				Email: fmt.Sprintf("s%d@example.com", i),
+				Major: "Informatics",
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Create() = %v", err)
+		}
+	}
+
+	result, err := NewAuditUseCase(audit, true).Verify(ctx)
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if !result.Valid || result.Entries != writers {
+		t.Fatalf("Verify() = %+v, want %d valid entries", result, writers)
+	}
+	if latest, _ := audit.LatestHash(ctx); result.Head != latest {
+		t.Errorf("head = %q, want the newest hash %q", result.Head, latest)
+	}
+}
+
+func TestCheckLink(t *testing.T) {
+	sealed := func(prev string) *entity.AuditLog {
+		entry := &entity.AuditLog{
+			Action:     entity.AuditUpdate,
+			EntityType: entity.AuditStudent,
+			EntityID:   uuid.MustParse("6f1c2a9e-3d4b-4c5d-8e7f-9a0b1c2d3e4f"),
+			Changes:    entity.AuditChanges{"name": {Old: "Ani", New: "Ani Lestari"}},
+			PrevHash:   prev,
+		}
+		hash, err := chainHash(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry.Hash = hash
+		return entry
+	}
+
+	tests := []struct {
+		name  string
+		entry func() *entity.AuditLog
+		prev  string
+		want  string
+	}{
+		{name: "intact", entry: func() *entity.AuditLog { return sealed("abc") }, prev: "abc"},
+		{name: "first entry", entry: func() *entity.AuditLog { return sealed("") }},
+		{
+			name:  "unsealed",
+			entry: func() *entity.AuditLog { e := sealed("abc"); e.Hash = ""; return e },
+			prev:  "abc",
+			want:  "entry is not sealed",
+		},
+		{
+			name:  "predecessor removed",
+			entry: func() *entity.AuditLog { return sealed("abc") },
+			prev:  "def",
+			want:  "entry does not link to the one before it",
+		},
+		{
+			name:  "edited after sealing",
+			entry: func() *entity.AuditLog { e := sealed("abc"); e.Action = entity.AuditDelete; return e },
+			prev:  "abc",
+			want:  "entry does not match its hash",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkLink(tt.entry(), tt.prev)
+			if err != nil || got != tt.want {
+				t.Errorf("checkLink() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -7,11 +7,12 @@ import (
 	"context"
 	"errors"
 
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
 	"github.com/haninhammoud01/go-academic-service/internal/pkg/jwt"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
 	"github.com/haninhammoud01/go-academic-service/internal/pkg/password"
-	"gorm.io/gorm"
 )
 
 type AuthUseCase interface {
@@ -22,32 +23,36 @@ type AuthUseCase interface {
 type authUseCaseImpl struct {
 	userRepo   repository.UserRepository
 	jwtService *jwt.JWTService
+	txManager  repository.TxManager
+	auditor    *Auditor
 }
 
-func NewAuthUseCase(userRepo repository.UserRepository, jwtService *jwt.JWTService) AuthUseCase {
+func NewAuthUseCase(userRepo repository.UserRepository, jwtService *jwt.JWTService, txManager repository.TxManager, auditor *Auditor) AuthUseCase {
 	return &authUseCaseImpl{
 		userRepo:   userRepo,
 		jwtService: jwtService,
+		txManager:  txManager,
+		auditor:    auditor,
 	}
 }
 
 func (uc *authUseCaseImpl) Register(ctx context.Context, user *entity.User, plainPassword string) error {
 	// Check if email exists
 	existing, err := uc.userRepo.FindByEmail(ctx, user.Email)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
 		return err
 	}
 	if existing != nil {
-		return errors.New("email already exists")
+		return apperror.Conflict("email", "email already exists")
 	}
 
 	// Check if username exists
 	existing, err = uc.userRepo.FindByUsername(ctx, user.Username)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
 		return err
 	}
 	if existing != nil {
-		return errors.New("username already exists")
+		return apperror.Conflict("username", "username already exists")
 	}
 
 	// Hash password
@@ -63,27 +68,38 @@ func (uc *authUseCaseImpl) Register(ctx context.Context, user *entity.User, plai
 	}
 	user.IsActive = true
 
-	return uc.userRepo.Create(ctx, user)
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditUser, user.ID, nil, user)
+	})
 }
 
 func (uc *authUseCaseImpl) Login(ctx context.Context, email, plainPassword string) (string, *entity.User, error) {
+	token, user, err := uc.login(ctx, email, plainPassword)
+	metrics.RecordLogin(err == nil)
+	return token, user, err
+}
+
+func (uc *authUseCaseImpl) login(ctx context.Context, email, plainPassword string) (string, *entity.User, error) {
 	// Find user by email
 	user, err := uc.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", nil, errors.New("invalid email or password")
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", nil, apperror.Unauthorized("invalid email or password")
 		}
 		return "", nil, err
 	}
 
 	// Check if user is active
 	if !user.IsActive {
-		return "", nil, errors.New("user account is inactive")
+		return "", nil, apperror.Forbidden("user account is inactive")
 	}
 
 	// Verify password
 	if !password.Verify(plainPassword, user.Password) {
-		return "", nil, errors.New("invalid email or password")
+		return "", nil, apperror.Unauthorized("invalid email or password")
 	}
 
 	// Generate token
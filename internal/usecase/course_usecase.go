@@ -0,0 +1,125 @@
+// File: internal/usecase/course_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+)
+
+type CourseUseCase interface {
+	Create(ctx context.Context, course *entity.Course) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Course, error)
+	GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Course, int64, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Course, error)
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+}
+
+type courseUseCaseImpl struct {
+	repo      repository.CourseRepository
+	txManager repository.TxManager
+	auditor   *Auditor
+}
+
+func NewCourseUseCase(repo repository.CourseRepository, txManager repository.TxManager, auditor *Auditor) CourseUseCase {
+	return &courseUseCaseImpl{repo: repo, txManager: txManager, auditor: auditor}
+}
+
+func (uc *courseUseCaseImpl) Create(ctx context.Context, course *entity.Course) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.create(ctx, course); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditCourse, course.ID, nil, course)
+	})
+}
+
+func (uc *courseUseCaseImpl) create(ctx context.Context, course *entity.Course) error {
+	if course.Code == "" || course.Name == "" || course.Department == "" {
+		return apperror.Validation("required fields are missing")
+	}
+
+	existing, err := uc.repo.FindByCode(ctx, course.Code)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to check existing course code: %w", err)
+	}
+	if existing != nil {
+		return apperror.Conflict("code", "course code already exists")
+	}
+
+	return uc.repo.Create(ctx, course)
+}
+
+func (uc *courseUseCaseImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.Course, error) {
+	course, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("course not found")
+		}
+		return nil, err
+	}
+	return course, nil
+}
+
+func (uc *courseUseCaseImpl) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Course, int64, error) {
+	return uc.repo.FindAll(ctx, page, pageSize, spec)
+}
+
+func (uc *courseUseCaseImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Course, error) {
+	var updated *entity.Course
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = uc.update(ctx, id, version, changes)
+		return err
+	})
+	return updated, err
+}
+
+func (uc *courseUseCaseImpl) update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Course, error) {
+	existing, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	version, err = checkVersion(existing.Version, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return existing, nil
+	}
+	if err := uc.repo.Update(ctx, id, version, changes); err != nil {
+		return nil, err
+	}
+	updated, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return updated, uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditCourse, id, existing, updated)
+}
+
+func (uc *courseUseCaseImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.delete(ctx, id, version)
+	})
+}
+
+func (uc *courseUseCaseImpl) delete(ctx context.Context, id uuid.UUID, version int) error {
+	existing, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	version, err = checkVersion(existing.Version, version)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.Delete(ctx, id, version); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, entity.AuditDelete, entity.AuditCourse, id, existing, nil)
+}
@@ -0,0 +1,563 @@
+// File: internal/usecase/enrollment_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
+)
+
+type EnrollmentUseCase interface {
+	Enroll(ctx context.Context, enrollment *entity.Enrollment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error)
+	GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Enrollment, int64, error)
+	GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Enrollment], error)
+	Count(ctx context.Context, spec query.Spec) (int64, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Enrollment, error)
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	// PromoteWaitlist fills the seats of a course term that another use
+	// case freed from its waitlist, and returns the enrollments it made.
+	// It must run in the unit of work that freed the seats.
+	PromoteWaitlist(ctx context.Context, change repository.SeatChange) ([]*entity.Enrollment, error)
+}
+
+type enrollmentUseCaseImpl struct {
+	repo        repository.EnrollmentRepository
+	studentRepo repository.StudentRepository
+	courseRepo  repository.CourseRepository
+	waitlists   repository.WaitlistRepository
+	krsRepo     repository.KRSRepository
+	txManager   repository.TxManager
+	auditor     *Auditor
+	outbox      *Outbox
+	seats       *SeatFeed
+	maxCredits  int
+	seatKRS     []string
+}
+
+// NewEnrollmentUseCase limits students to maxCredits credits per term,
+// unless it is 0. Enrollments take a seat of their course once their KRS
+// is in one of the states seatKRS.
+func NewEnrollmentUseCase(
+	repo repository.EnrollmentRepository,
+	studentRepo repository.StudentRepository,
+	courseRepo repository.CourseRepository,
+	waitlists repository.WaitlistRepository,
+	krsRepo repository.KRSRepository,
+	txManager repository.TxManager,
+	auditor *Auditor,
+	outbox *Outbox,
+	seats *SeatFeed,
+	maxCredits int,
+	seatKRS []string,
+) EnrollmentUseCase {
+	return &enrollmentUseCaseImpl{
+		repo:        repo,
+		studentRepo: studentRepo,
+		courseRepo:  courseRepo,
+		waitlists:   waitlists,
+		krsRepo:     krsRepo,
+		txManager:   txManager,
+		auditor:     auditor,
+		outbox:      outbox,
+		seats:       seats,
+		maxCredits:  maxCredits,
+		seatKRS:     seatKRS,
+	}
+}
+
+func (uc *enrollmentUseCaseImpl) Enroll(ctx context.Context, enrollment *entity.Enrollment) error {
+	if enrollment.AcademicYear == "" || enrollment.Semester < 1 {
+		return apperror.Validation("required fields are missing")
+	}
+
+	// The seat count and the insert share one transaction so concurrent
+	// enrollments cannot overbook the course.
+	if err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.enroll(ctx, enrollment); err != nil {
+			return err
+		}
+		return uc.leaveWaitlist(ctx, enrollment)
+	}); err != nil {
+		return err
+	}
+
+	metrics.RecordEnrollmentCreated()
+	uc.seats.Changed(ctx, seatChange(enrollment))
+	return nil
+}
+
+func (uc *enrollmentUseCaseImpl) enroll(ctx context.Context, enrollment *entity.Enrollment) error {
+	if err := uc.checkEligible(ctx, enrollment); err != nil {
+		return err
+	}
+
+	if enrollment.Status == "" {
+		enrollment.Status = "enrolled"
+	}
+	if err := uc.repo.Create(ctx, enrollment); err != nil {
+		return err
+	}
+	if err := uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditEnrollment, enrollment.ID, nil, enrollment); err != nil {
+		return err
+	}
+	if err := uc.startKRS(ctx, enrollment); err != nil {
+		return err
+	}
+	return uc.outbox.Add(ctx, EnrollmentCreated{
+		EnrollmentID: enrollment.ID,
+		StudentID:    enrollment.StudentID,
+		CourseID:     enrollment.CourseID,
+		AcademicYear: enrollment.AcademicYear,
+		Semester:     enrollment.Semester,
+		Status:       enrollment.Status,
+	})
+}
+
+// checkEligible applies the rules a new enrollment must pass, whether it
+// is made directly, by promotion from a waitlist or by an update that
+// makes a stored enrollment take a seat again. A stored enrollment is not
+// counted against itself. A failed rule is a validation, conflict or
+// not-found error; it writes nothing. Prerequisites and schedule conflicts
+// are out of scope: courses record neither.
+func (uc *enrollmentUseCaseImpl) checkEligible(ctx context.Context, enrollment *entity.Enrollment) error {
+	// Check student and course
+	student, err := uc.studentRepo.FindByID(ctx, enrollment.StudentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return apperror.NotFound("student not found")
+		}
+		return err
+	}
+	if student.Status != "active" {
+		return apperror.Validation("student is not active")
+	}
+
+	course, err := uc.courseRepo.FindByID(ctx, enrollment.CourseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return apperror.NotFound("course not found")
+		}
+		return err
+	}
+	if course.Status != "active" {
+		return apperror.Validation("course is not active")
+	}
+
+	// Check the KRS of the term is open for changes
+	krs, err := uc.krsRepo.FindByStudentTerm(ctx, enrollment.StudentID, enrollment.AcademicYear, enrollment.Semester)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to find KRS of the term: %w", err)
+	}
+	if krs != nil && !krsEditable(krs.Status) {
+		return apperror.Conflict("krs", fmt.Sprintf("the student's KRS for this term is %s, courses can no longer be added", krs.Status))
+	}
+
+	// Check duplicate enrollment in the same term
+	existing, err := uc.repo.FindByStudentCourse(ctx, enrollment.StudentID, enrollment.CourseID, enrollment.AcademicYear, enrollment.Semester)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to check existing enrollment: %w", err)
+	}
+	if existing != nil && existing.ID != enrollment.ID {
+		return apperror.Conflict("course_id", "student is already enrolled in this course")
+	}
+
+	// Check capacity
+	taken, err := uc.repo.CountActiveByCourse(ctx, enrollment.CourseID, enrollment.AcademicYear, enrollment.Semester, uc.seatKRS)
+	if err != nil {
+		return fmt.Errorf("failed to count course seats: %w", err)
+	}
+	if course.MaxStudents > 0 && taken >= int64(course.MaxStudents) {
+		return apperror.Conflict("course_id", "course is full")
+	}
+
+	// Check the credit limit of the term
+	if uc.maxCredits > 0 {
+		credits, err := uc.creditsTaken(ctx, enrollment)
+		if err != nil {
+			return err
+		}
+		if credits+course.Credits > uc.maxCredits {
+			return apperror.Validation(fmt.Sprintf("student would take %d credits this term, the limit is %d", credits+course.Credits, uc.maxCredits))
+		}
+	}
+	return nil
+}
+
+// creditsTaken sums the credits of the other enrollments of the student
+// of enrollment in its term that were not dropped.
+func (uc *enrollmentUseCaseImpl) creditsTaken(ctx context.Context, enrollment *entity.Enrollment) (int, error) {
+	enrollments, err := uc.repo.FindActiveByStudent(ctx, enrollment.StudentID, enrollment.AcademicYear, enrollment.Semester)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find enrollments of the term: %w", err)
+	}
+	credits := 0
+	for _, e := range enrollments {
+		if e.ID == enrollment.ID {
+			continue
+		}
+		course, err := uc.courseRepo.FindByID(ctx, e.CourseID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return 0, err
+		}
+		credits += course.Credits
+	}
+	return credits, nil
+}
+
+// startKRS opens the KRS of the term of enrollment as a draft, when it is
+// the student's first course in that term.
+func (uc *enrollmentUseCaseImpl) startKRS(ctx context.Context, enrollment *entity.Enrollment) error {
+	_, err := uc.krsRepo.FindByStudentTerm(ctx, enrollment.StudentID, enrollment.AcademicYear, enrollment.Semester)
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	krs := &entity.KRSSubmission{
+		StudentID:    enrollment.StudentID,
+		AcademicYear: enrollment.AcademicYear,
+		Semester:     enrollment.Semester,
+		Status:       entity.KRSDraft,
+	}
+	if err := uc.krsRepo.Create(ctx, krs); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditKRS, krs.ID, nil, krs)
+}
+
+// leaveWaitlist takes a student who enrolled directly off the waitlist of
+// the course term, should they be on it.
+func (uc *enrollmentUseCaseImpl) leaveWaitlist(ctx context.Context, enrollment *entity.Enrollment) error {
+	waiting, err := uc.waitlists.FindWaiting(ctx, enrollment.CourseID, enrollment.AcademicYear, enrollment.Semester)
+	if err != nil {
+		return err
+	}
+	for _, entry := range waiting {
+		if entry.StudentID == enrollment.StudentID {
+			return uc.closeWaitlistEntry(ctx, entry, map[string]interface{}{"status": entity.WaitlistRemoved})
+		}
+	}
+	return nil
+}
+
+// promote fills the free seats of a course term from its waitlist, in
+// order. Students who fail checkEligible now, for example because they
+// reached the credit limit or their KRS is already submitted, keep their
+// place. A promoted enrollment holds its seat whatever the state of its
+// KRS until it is dropped. It must run in the unit of work that freed the
+// seat, so nobody can take the seat in between.
+func (uc *enrollmentUseCaseImpl) promote(ctx context.Context, change repository.SeatChange) ([]*entity.Enrollment, error) {
+	waiting, err := uc.waitlists.FindWaiting(ctx, change.CourseID, change.AcademicYear, change.Semester)
+	if err != nil || len(waiting) == 0 {
+		return nil, err
+	}
+	course, err := uc.courseRepo.FindByID(ctx, change.CourseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	taken, err := uc.repo.CountActiveByCourse(ctx, change.CourseID, change.AcademicYear, change.Semester, uc.seatKRS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count course seats: %w", err)
+	}
+
+	var promoted []*entity.Enrollment
+	for _, entry := range waiting {
+		if course.MaxStudents > 0 && taken >= int64(course.MaxStudents) {
+			break
+		}
+		enrollment := &entity.Enrollment{
+			StudentID:    entry.StudentID,
+			CourseID:     entry.CourseID,
+			AcademicYear: entry.AcademicYear,
+			Semester:     entry.Semester,
+		}
+		if err := uc.enroll(ctx, enrollment); err != nil {
+			switch apperror.KindOf(err) {
+			case apperror.KindValidation, apperror.KindConflict, apperror.KindNotFound:
+				logger.FromContext(ctx).InfoContext(ctx, "skipped waitlisted student",
+					"entry_id", entry.ID, "student_id", entry.StudentID, "reason", err.Error())
+				continue
+			}
+			return nil, err
+		}
+		err := uc.closeWaitlistEntry(ctx, entry, map[string]interface{}{
+			"status":        entity.WaitlistPromoted,
+			"enrollment_id": enrollment.ID,
+			"promoted_at":   time.Now().UTC(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = uc.outbox.Add(ctx, WaitlistPromoted{
+			EntryID:      entry.ID,
+			EnrollmentID: enrollment.ID,
+			StudentID:    entry.StudentID,
+			CourseID:     entry.CourseID,
+			AcademicYear: entry.AcademicYear,
+			Semester:     entry.Semester,
+		})
+		if err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, enrollment)
+		taken++
+	}
+	return promoted, nil
+}
+
+// closeWaitlistEntry takes entry off its waitlist with changes.
+func (uc *enrollmentUseCaseImpl) closeWaitlistEntry(ctx context.Context, entry *entity.WaitlistEntry, changes map[string]interface{}) error {
+	if err := uc.waitlists.Update(ctx, entry.ID, entry.Version, changes); err != nil {
+		return err
+	}
+	updated, err := uc.waitlists.FindByID(ctx, entry.ID)
+	if err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditWaitlist, entry.ID, entry, updated)
+}
+
+func (uc *enrollmentUseCaseImpl) PromoteWaitlist(ctx context.Context, change repository.SeatChange) ([]*entity.Enrollment, error) {
+	return uc.promote(ctx, change)
+}
+
+// recordPromotions reports the students promoted by a committed unit of
+// work.
+func recordPromotions(ctx context.Context, promoted []*entity.Enrollment) {
+	for _, enrollment := range promoted {
+		metrics.RecordEnrollmentCreated()
+		metrics.RecordWaitlistPromotion()
+		logger.FromContext(ctx).InfoContext(ctx, "promoted student from waitlist",
+			"enrollment_id", enrollment.ID, "student_id", enrollment.StudentID, "course_id", enrollment.CourseID)
+	}
+}
+
+func (uc *enrollmentUseCaseImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.Enrollment, error) {
+	enrollment, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("enrollment not found")
+		}
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+func (uc *enrollmentUseCaseImpl) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Enrollment, int64, error) {
+	return uc.repo.FindAll(ctx, page, pageSize, spec)
+}
+
+func (uc *enrollmentUseCaseImpl) GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Enrollment], error) {
+	return uc.repo.FindPage(ctx, page, spec)
+}
+
+func (uc *enrollmentUseCaseImpl) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	return uc.repo.Count(ctx, spec)
+}
+
+func (uc *enrollmentUseCaseImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Enrollment, error) {
+	var existing, updated *entity.Enrollment
+	var promoted []*entity.Enrollment
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		existing, updated, err = uc.update(ctx, id, version, changes)
+		if err != nil {
+			return err
+		}
+		promoted = nil
+		if releasesSeat(existing, updated) {
+			promoted, err = uc.promote(ctx, seatChange(existing))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordPromotions(ctx, promoted)
+
+	// Dropping an enrollment, taking it back or moving it to another
+	// course or term changes the seats on both sides.
+	if (existing.Status == "dropped") != (updated.Status == "dropped") || seatChange(existing) != seatChange(updated) {
+		uc.seats.Changed(ctx, seatChange(existing))
+		if seatChange(existing) != seatChange(updated) {
+			uc.seats.Changed(ctx, seatChange(updated))
+		}
+	}
+	if grade, ok := changes["grade"].(string); ok && grade != "" {
+		metrics.RecordGradeSubmitted()
+	}
+	return updated, nil
+}
+
+// update returns the enrollment as it was and as it is now.
+func (uc *enrollmentUseCaseImpl) update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Enrollment, *entity.Enrollment, error) {
+	existing, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err = checkVersion(existing.Version, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(changes) == 0 {
+		return existing, existing, nil
+	}
+
+	// Taking back a dropped enrollment or moving it to another student,
+	// course or term takes a seat like a new enrollment does.
+	resulting, err := withSeatChanges(existing, changes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if takesSeat(existing, resulting) {
+		if err := uc.checkEligible(ctx, resulting); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := uc.repo.Update(ctx, id, version, changes); err != nil {
+		return nil, nil, err
+	}
+	updated, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if seatChange(existing) != seatChange(updated) || existing.StudentID != updated.StudentID {
+		if err := uc.startKRS(ctx, updated); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditEnrollment, id, existing, updated); err != nil {
+		return nil, nil, err
+	}
+	if updated.Grade != "" && updated.Grade != existing.Grade {
+		err = uc.outbox.Add(ctx, GradePosted{
+			EnrollmentID: id,
+			StudentID:    updated.StudentID,
+			CourseID:     updated.CourseID,
+			AcademicYear: updated.AcademicYear,
+			Semester:     updated.Semester,
+			Grade:        updated.Grade,
+			Score:        updated.Score,
+		})
+	}
+	return existing, updated, err
+}
+
+func (uc *enrollmentUseCaseImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	var deleted *entity.Enrollment
+	var promoted []*entity.Enrollment
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		deleted, err = uc.delete(ctx, id, version)
+		if err != nil {
+			return err
+		}
+		promoted = nil
+		if deleted.Status != "dropped" {
+			promoted, err = uc.promote(ctx, seatChange(deleted))
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	recordPromotions(ctx, promoted)
+
+	if deleted.Status != "dropped" {
+		uc.seats.Changed(ctx, seatChange(deleted))
+	}
+	return nil
+}
+
+func (uc *enrollmentUseCaseImpl) delete(ctx context.Context, id uuid.UUID, version int) (*entity.Enrollment, error) {
+	existing, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	version, err = checkVersion(existing.Version, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Delete(ctx, id, version); err != nil {
+		return nil, err
+	}
+	return existing, uc.auditor.Record(ctx, entity.AuditDelete, entity.AuditEnrollment, id, existing, nil)
+}
+
+// seatChange names the course term whose seat enrollment takes.
+// withSeatChanges returns a copy of enrollment with the changes that decide
+// which seat it holds applied: its status, student, course and term.
+func withSeatChanges(enrollment *entity.Enrollment, changes map[string]interface{}) (*entity.Enrollment, error) {
+	resulting := *enrollment
+	for column, value := range changes {
+		ok := true
+		switch column {
+		case "status":
+			resulting.Status, ok = value.(string)
+		case "student_id":
+			resulting.StudentID, ok = toUUID(value)
+		case "course_id":
+			resulting.CourseID, ok = toUUID(value)
+		case "academic_year":
+			resulting.AcademicYear, ok = value.(string)
+		case "semester":
+			resulting.Semester, ok = value.(int)
+		}
+		if !ok {
+			return nil, apperror.Validation(fmt.Sprintf("invalid %s", column))
+		}
+	}
+	return &resulting, nil
+}
+
+func toUUID(value interface{}) (uuid.UUID, bool) {
+	switch v := value.(type) {
+	case uuid.UUID:
+		return v, true
+	case string:
+		id, err := uuid.Parse(v)
+		return id, err == nil
+	}
+	return uuid.Nil, false
+}
+
+// takesSeat reports whether an enrollment updated from before to after
+// holds a seat it did not hold before.
+func takesSeat(before, after *entity.Enrollment) bool {
+	if after.Status == "dropped" {
+		return false
+	}
+	return before.Status == "dropped" || before.StudentID != after.StudentID || seatChange(before) != seatChange(after)
+}
+
+// releasesSeat reports whether an enrollment updated from before to after
+// gave up the seat it held before.
+func releasesSeat(before, after *entity.Enrollment) bool {
+	if before.Status == "dropped" {
+		return false
+	}
+	return after.Status == "dropped" || seatChange(before) != seatChange(after)
+}
+
+func seatChange(enrollment *entity.Enrollment) repository.SeatChange {
+	return repository.SeatChange{
+		CourseID:     enrollment.CourseID,
+		AcademicYear: enrollment.AcademicYear,
+		Semester:     enrollment.Semester,
+	}
+}
@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/actor"
+)
+
+func TestEnroll(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup stores what is already there and returns the student and
+		// course to enroll.
+		setup    func(ctx context.Context, a *academic) (studentID, courseID uuid.UUID)
+		wantErr  string
+		wantKind apperror.Kind
+	}{
+		{
+			name: "a seat is free",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				return a.student("2024001").ID, a.course("IF101", 40).ID
+			},
+		},
+		{
+			name: "the same course twice in a term",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				student, course := a.student("2024001"), a.course("IF101", 40)
+				_, err := a.enroll(ctx, student.ID, course.ID)
+				a.must(err)
+				return student.ID, course.ID
+			},
+			wantErr:  "student is already enrolled in this course",
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "the same course in another term",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				student, course := a.student("2024001"), a.course("IF101", 40)
+				a.must(a.enrollments.Create(ctx, &entity.Enrollment{StudentID: student.ID, CourseID: course.ID, AcademicYear: "2023/2024", Semester: 1, Status: "completed"}))
+				return student.ID, course.ID
+			},
+		},
+		{
+			name: "a full course",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				other, course := a.student("2024002"), a.course("IF101", 1)
+				_, err := a.enroll(ctx, other.ID, course.ID)
+				a.must(err)
+				a.must(a.decide(ctx, other.ID, ""))
+				return a.student("2024001").ID, course.ID
+			},
+			wantErr:  "course is full",
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "a dropped enrollment frees its seat",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				other, course := a.student("2024002"), a.course("IF101", 1)
+				a.must(a.enrollments.Create(ctx, &entity.Enrollment{StudentID: other.ID, CourseID: course.ID, AcademicYear: testYear, Semester: testSemester, Status: "dropped"}))
+				return a.student("2024001").ID, course.ID
+			},
+		},
+		{
+			name: "an inactive student",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				student := a.student("2024001")
+				a.must(a.students.Update(ctx, student.ID, student.Version, map[string]interface{}{"status": "graduated"}))
+				return student.ID, a.course("IF101", 40).ID
+			},
+			wantErr:  "student is not active",
+			wantKind: apperror.KindValidation,
+		},
+		{
+			name: "an inactive course",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				course := a.course("IF101", 40)
+				a.must(a.courses.Update(ctx, course.ID, course.Version, map[string]interface{}{"status": "inactive"}))
+				return a.student("2024001").ID, course.ID
+			},
+			wantErr:  "course is not active",
+			wantKind: apperror.KindValidation,
+		},
+		{
+			name: "an unknown course",
+			setup: func(ctx context.Context, a *academic) (uuid.UUID, uuid.UUID) {
+				return a.student("2024001").ID, uuid.New()
+			},
+			wantErr:  "course not found",
+			wantKind: apperror.KindNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			a := newAcademic(t, entity.KRSSubmitted)
+			studentID, courseID := tt.setup(ctx, a)
+			before, err := a.enrollments.FindActiveByStudent(ctx, studentID, testYear, testSemester)
+			a.must(err)
+
+			enrollment, err := a.enroll(ctx, studentID, courseID)
+			after, findErr := a.enrollments.FindActiveByStudent(ctx, studentID, testYear, testSemester)
+			a.must(findErr)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr || apperror.KindOf(err) != tt.wantKind {
+					t.Fatalf("Enroll() = %v, want %v %q", err, tt.wantKind, tt.wantErr)
+				}
+				if len(after) != len(before) {
+					t.Errorf("Enroll() stored the enrollment it refused")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Enroll() = %v", err)
+			}
+			if enrollment.Status != "enrolled" || len(after) != len(before)+1 {
+				t.Errorf("Enroll() stored %d enrollments with status %q, want one more, enrolled", len(after)-len(before), enrollment.Status)
+			}
+		})
+	}
+}
+
+func TestWaitlistPromotionHoldsSeat(t *testing.T) {
+	tests := []struct {
+		name      string
+		seatsFrom string
+	}{
+		{name: "seats counted from approval", seatsFrom: entity.KRSApproved},
+		{name: "seats counted from submission", seatsFrom: entity.KRSSubmitted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			a := newAcademic(t, tt.seatsFrom)
+			course := a.course("IF101", 1)
+			first, second, third := a.student("2024001"), a.student("2024002"), a.student("2024003")
+
+			enrollment, err := a.enroll(ctx, first.ID, course.ID)
+			a.must(err)
+			a.must(a.decide(ctx, first.ID, entity.KRSApproved))
+			entry := &entity.WaitlistEntry{StudentID: second.ID, CourseID: course.ID, AcademicYear: testYear, Semester: testSemester}
+			a.must(a.waitlist.Join(ctx, entry))
+
+			_, err = a.enrollment.Update(ctx, enrollment.ID, enrollment.Version, map[string]interface{}{"status": "dropped"})
+			a.must(err)
+			promoted, err := a.enrollments.FindByStudentCourse(ctx, second.ID, course.ID, testYear, testSemester)
+			if err != nil {
+				t.Fatalf("second student was not promoted: %v", err)
+			}
+			if entry, err = a.waitlists.FindByID(ctx, entry.ID); err != nil || entry.Status != entity.WaitlistPromoted {
+				t.Fatalf("waitlist entry = %+v, %v, want promoted", entry, err)
+			}
+			if entry.EnrollmentID == nil || *entry.EnrollmentID != promoted.ID {
+				t.Errorf("waitlist entry points at enrollment %v, want %v", entry.EnrollmentID, promoted.ID)
+			}
+
+			// The promoted enrollment holds the seat while its KRS is a draft.
+			if got := a.taken(course.ID, tt.seatsFrom); got != 1 {
+				t.Errorf("%d seats taken after promotion, want 1", got)
+			}
+			if _, err := a.enroll(ctx, third.ID, course.ID); apperror.KindOf(err) != apperror.KindConflict {
+				t.Errorf("enrolling in the full course = %v, want a conflict", err)
+			}
+
+			// Counting the promoted student's KRS does not count them twice.
+			if err := a.decide(ctx, second.ID, entity.KRSApproved); err != nil {
+				t.Fatalf("approving the promoted student's KRS: %v", err)
+			}
+			if got := a.taken(course.ID, tt.seatsFrom); got != 1 {
+				t.Errorf("%d seats taken after approval, want 1", got)
+			}
+		})
+	}
+}
+
+func TestPromoteStopsAtCapacity(t *testing.T) {
+	tests := []struct {
+		name         string
+		capacity     int
+		waiting      int
+		wantPromoted int
+	}{
+		{name: "one seat for two waiting", capacity: 2, waiting: 2, wantPromoted: 1},
+		{name: "no seat", capacity: 1, waiting: 1, wantPromoted: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			a := newAcademic(t, entity.KRSSubmitted)
+			course := a.course("IF102", tt.capacity)
+			for i := range tt.capacity {
+				student := a.student(fmt.Sprintf("20240%02d", i))
+				_, err := a.enroll(ctx, student.ID, course.ID)
+				a.must(err)
+				a.must(a.decide(ctx, student.ID, ""))
+			}
+			for i := range tt.waiting {
+				student := a.student(fmt.Sprintf("20241%02d", i))
+				a.must(a.waitlist.Join(ctx, &entity.WaitlistEntry{StudentID: student.ID, CourseID: course.ID, AcademicYear: testYear, Semester: testSemester}))
+			}
+			// Raising the capacity frees seats without promoting anyone.
+			if tt.wantPromoted > 0 {
+				a.must(a.courses.Update(ctx, course.ID, course.Version, map[string]interface{}{"max_students": tt.capacity + tt.wantPromoted}))
+			}
+
+			uc := a.enrollment.(*enrollmentUseCaseImpl)
+			promoted, err := uc.promote(ctx, repository.SeatChange{CourseID: course.ID, AcademicYear: testYear, Semester: testSemester})
+			a.must(err)
+			if len(promoted) != tt.wantPromoted {
+				t.Errorf("promoted %d students, want %d", len(promoted), tt.wantPromoted)
+			}
+		})
+	}
+}
+
+func TestUpdateRechecksEligibility(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup enrolls a student and returns the enrollment with the
+		// changes to make to it.
+		setup    func(ctx context.Context, a *academic) (*entity.Enrollment, map[string]interface{})
+		wantErr  string
+		wantKind apperror.Kind
+	}{
+		{
+			name: "taking back a drop with a seat free",
+			setup: func(ctx context.Context, a *academic) (*entity.Enrollment, map[string]interface{}) {
+				return a.dropped(ctx, a.student("2024001"), a.course("IF101", 1)), map[string]interface{}{"status": "enrolled"}
+			},
+		},
+		{
+			name: "taking back a drop into a full course",
+			setup: func(ctx context.Context, a *academic) (*entity.Enrollment, map[string]interface{}) {
+				course := a.course("IF101", 1)
+				enrollment := a.dropped(ctx, a.student("2024001"), course)
+				a.submitted(ctx, a.student("2024002"), course)
+				return enrollment, map[string]interface{}{"status": "enrolled"}
+			},
+			wantErr:  "course is full",
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "moving to a course with a seat free",
+			setup: func(ctx context.Context, a *academic) (*entity.Enrollment, map[string]interface{}) {
+				enrollment, err := a.enroll(ctx, a.student("2024001").ID, a.course("IF101", 1).ID)
+				a.must(err)
+				return enrollment, map[string]interface{}{"course_id": a.course("IF102", 1).ID}
+			},
+		},
+		{
+			name: "moving to a full course",
+			setup: func(ctx context.Context, a *academic) (*entity.Enrollment, map[string]interface{}) {
+				full := a.course("IF102", 1)
+				a.submitted(ctx, a.student("2024002"), full)
+				enrollment, err := a.enroll(ctx, a.student("2024001").ID, a.course("IF101", 1).ID)
+				a.must(err)
+				return enrollment, map[string]interface{}{"course_id": full.ID}
+			},
+			wantErr:  "course is full",
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "moving to a course the student already takes",
+			setup: func(ctx context.Context, a *academic) (*entity.Enrollment, map[string]interface{}) {
+				student, taken := a.student("2024001"), a.course("IF102", 40)
+				_, err := a.enroll(ctx, student.ID, taken.ID)
+				a.must(err)
+				enrollment, err := a.enroll(ctx, student.ID, a.course("IF101", 40).ID)
+				a.must(err)
+				return enrollment, map[string]interface{}{"course_id": taken.ID}
+			},
+			wantErr:  "student is already enrolled in this course",
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "moving to an inactive course",
+			setup: func(ctx context.Context, a *academic) (*entity.Enrollment, map[string]interface{}) {
+				retired := a.course("IF102", 40)
+				a.must(a.courses.Update(ctx, retired.ID, retired.Version, map[string]interface{}{"status": "inactive"}))
+				enrollment, err := a.enroll(ctx, a.student("2024001").ID, a.course("IF101", 40).ID)
+				a.must(err)
+				return enrollment, map[string]interface{}{"course_id": retired.ID}
+			},
+			wantErr:  "course is not active",
+			wantKind: apperror.KindValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			a := newAcademic(t, entity.KRSSubmitted)
+			enrollment, changes := tt.setup(ctx, a)
+
+			_, err := a.enrollment.Update(ctx, enrollment.ID, enrollment.Version, changes)
+			stored, findErr := a.enrollments.FindByID(ctx, enrollment.ID)
+			a.must(findErr)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr || apperror.KindOf(err) != tt.wantKind {
+					t.Fatalf("Update() = %v, want %v %q", err, tt.wantKind, tt.wantErr)
+				}
+				if stored.Version != enrollment.Version {
+					t.Errorf("Update() changed the enrollment it refused: %+v", stored)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() = %v", err)
+			}
+			if stored.Version != enrollment.Version+1 {
+				t.Errorf("version = %d, want %d", stored.Version, enrollment.Version+1)
+			}
+		})
+	}
+}
+
+func TestCourseMovePromotesWaitlist(t *testing.T) {
+	ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+	a := newAcademic(t, entity.KRSSubmitted)
+	course := a.course("IF101", 1)
+	holder, mover, waiting := a.student("2024001"), a.student("2024002"), a.student("2024003")
+	held, err := a.enroll(ctx, holder.ID, course.ID)
+	a.must(err)
+	a.must(a.decide(ctx, holder.ID, ""))
+	for _, student := range []*entity.Student{mover, waiting} {
+		a.must(a.waitlist.Join(ctx, &entity.WaitlistEntry{StudentID: student.ID, CourseID: course.ID, AcademicYear: testYear, Semester: testSemester}))
+	}
+
+	// The drop promotes the mover, whose enrollment then holds the seat
+	// while their KRS is a draft and can still be moved.
+	_, err = a.enrollment.Update(ctx, held.ID, held.Version, map[string]interface{}{"status": "dropped"})
+	a.must(err)
+	moved, err := a.enrollments.FindByStudentCourse(ctx, mover.ID, course.ID, testYear, testSemester)
+	a.must(err)
+
+	other := a.course("IF102", 40)
+	if _, err := a.enrollment.Update(ctx, moved.ID, moved.Version, map[string]interface{}{"course_id": other.ID}); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if _, err := a.enrollments.FindByStudentCourse(ctx, waiting.ID, course.ID, testYear, testSemester); err != nil {
+		t.Errorf("the waitlisted student was not promoted into the seat the move released: %v", err)
+	}
+	if got := a.taken(course.ID, entity.KRSSubmitted); got != 1 {
+		t.Errorf("%d seats taken after the move, want 1", got)
+	}
+}
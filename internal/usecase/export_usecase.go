@@ -0,0 +1,339 @@
+// File: internal/usecase/export_usecase.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/export"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+)
+
+const (
+	ExportResourceStudents    = "students"
+	ExportResourceLecturers   = "lecturers"
+	ExportResourceEnrollments = "enrollments"
+)
+
+const (
+	ExportJobPending   = "pending"
+	ExportJobRunning   = "running"
+	ExportJobCompleted = "completed"
+	ExportJobFailed    = "failed"
+)
+
+// exportJobRetention is how long finished job files stay downloadable.
+const exportJobRetention = 24 * time.Hour
+
+type ExportJob struct {
+	ID          uuid.UUID
+	Resource    string
+	Format      export.Format
+	Status      string
+	Rows        int64
+	Error       string
+	RequestedBy uuid.UUID
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	filePath    string
+}
+
+// FileName is the name offered to the client when downloading the export.
+func (j *ExportJob) FileName() string {
+	return fmt.Sprintf("%s-%s.%s", j.Resource, j.CreatedAt.Format("20060102-150405"), j.Format.Extension())
+}
+
+type ExportUseCase interface {
+	Export(ctx context.Context, resource string, format export.Format, spec query.Spec, w io.Writer) (int64, error)
+	StartJob(ctx context.Context, resource string, format export.Format, spec query.Spec, requestedBy uuid.UUID) (*ExportJob, error)
+	GetJob(id uuid.UUID) (*ExportJob, error)
+	OpenJobFile(id uuid.UUID) (*ExportJob, *os.File, error)
+	// Shutdown stops accepting jobs and waits for running ones. When ctx
+	// expires first, running jobs are cancelled and marked failed.
+	Shutdown(ctx context.Context) error
+}
+
+type exportUseCaseImpl struct {
+	studentRepo    repository.StudentRepository
+	lecturerRepo   repository.LecturerRepository
+	enrollmentRepo repository.EnrollmentRepository
+	dir            string
+
+	mu      sync.Mutex
+	jobs    map[uuid.UUID]*ExportJob
+	closing bool
+
+	// running tracks background jobs; cancel aborts them on shutdown.
+	running sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func NewExportUseCase(
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+) ExportUseCase {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &exportUseCaseImpl{
+		studentRepo:    studentRepo,
+		lecturerRepo:   lecturerRepo,
+		enrollmentRepo: enrollmentRepo,
+		dir:            filepath.Join(os.TempDir(), "academic-exports"),
+		jobs:           make(map[uuid.UUID]*ExportJob),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+func (uc *exportUseCaseImpl) Export(ctx context.Context, resource string, format export.Format, spec query.Spec, w io.Writer) (int64, error) {
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	var rows int64
+	switch resource {
+	case ExportResourceStudents:
+		if err := writer.WriteHeader(studentExportColumns); err != nil {
+			return 0, err
+		}
+		err = uc.studentRepo.Stream(ctx, spec, func(student *entity.Student) error {
+			rows++
+			return writer.WriteRow(studentExportRow(student))
+		})
+	case ExportResourceLecturers:
+		if err := writer.WriteHeader(lecturerExportColumns); err != nil {
+			return 0, err
+		}
+		err = uc.lecturerRepo.Stream(ctx, spec, func(lecturer *entity.Lecturer) error {
+			rows++
+			return writer.WriteRow(lecturerExportRow(lecturer))
+		})
+	case ExportResourceEnrollments:
+		if err := writer.WriteHeader(enrollmentExportColumns); err != nil {
+			return 0, err
+		}
+		err = uc.enrollmentRepo.Stream(ctx, spec, func(enrollment *entity.Enrollment) error {
+			rows++
+			return writer.WriteRow(enrollmentExportRow(enrollment))
+		})
+	default:
+		return 0, apperror.Validation("unsupported export resource")
+	}
+	if err != nil {
+		return rows, err
+	}
+
+	return rows, writer.Close()
+}
+
+func (uc *exportUseCaseImpl) StartJob(ctx context.Context, resource string, format export.Format, spec query.Spec, requestedBy uuid.UUID) (*ExportJob, error) {
+	if !IsExportResource(resource) {
+		return nil, apperror.Validation("unsupported export resource")
+	}
+	if err := os.MkdirAll(uc.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to prepare export directory: %w", err)
+	}
+
+	job := &ExportJob{
+		ID:          uuid.New(),
+		Resource:    resource,
+		Format:      format,
+		Status:      ExportJobPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now(),
+	}
+	job.filePath = filepath.Join(uc.dir, job.ID.String()+"."+format.Extension())
+
+	uc.mu.Lock()
+	if uc.closing {
+		uc.mu.Unlock()
+		return nil, apperror.Unavailable("export service is shutting down", nil)
+	}
+	uc.pruneLocked()
+	uc.jobs[job.ID] = job
+	snapshot := *job
+	uc.running.Add(1)
+	uc.mu.Unlock()
+
+	jobCtx, cancel := context.WithCancel(logger.Detach(ctx))
+	stop := context.AfterFunc(uc.ctx, cancel)
+	go func() {
+		defer uc.running.Done()
+		defer cancel()
+		defer stop()
+		uc.runJob(jobCtx, job.ID, spec)
+	}()
+
+	return &snapshot, nil
+}
+
+func (uc *exportUseCaseImpl) Shutdown(ctx context.Context) error {
+	uc.mu.Lock()
+	uc.closing = true
+	uc.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		uc.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		uc.cancel()
+		<-done
+		return fmt.Errorf("export jobs cancelled: %w", ctx.Err())
+	}
+}
+
+func (uc *exportUseCaseImpl) GetJob(id uuid.UUID) (*ExportJob, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	job, ok := uc.jobs[id]
+	if !ok {
+		return nil, apperror.NotFound("export job not found")
+	}
+	snapshot := *job
+	return &snapshot, nil
+}
+
+func (uc *exportUseCaseImpl) OpenJobFile(id uuid.UUID) (*ExportJob, *os.File, error) {
+	job, err := uc.GetJob(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != ExportJobCompleted {
+		return job, nil, apperror.Conflict("status", "export job is not completed")
+	}
+
+	file, err := os.Open(job.filePath)
+	if err != nil {
+		return job, nil, err
+	}
+	return job, file, nil
+}
+
+// runJob executes an export in the background. ctx is detached from the
+// request that scheduled it so the job outlives it, but keeps its request ID.
+func (uc *exportUseCaseImpl) runJob(ctx context.Context, id uuid.UUID, spec query.Spec) {
+	uc.mu.Lock()
+	job := uc.jobs[id]
+	job.Status = ExportJobRunning
+	resource, format, path := job.Resource, job.Format, job.filePath
+	uc.mu.Unlock()
+
+	rows, err := uc.writeJobFile(ctx, resource, format, spec, path)
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	now := time.Now()
+	job.Rows = rows
+	job.CompletedAt = &now
+	if err != nil {
+		logger.FromContext(ctx).ErrorContext(ctx, "export job failed", "job_id", id, "error", err)
+		job.Status = ExportJobFailed
+		job.Error = err.Error()
+		os.Remove(path)
+		return
+	}
+	job.Status = ExportJobCompleted
+	logger.FromContext(ctx).InfoContext(ctx, "export job completed", "job_id", id, "rows", rows)
+}
+
+func (uc *exportUseCaseImpl) writeJobFile(ctx context.Context, resource string, format export.Format, spec query.Spec, path string) (int64, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := uc.Export(ctx, resource, format, spec, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return rows, err
+}
+
+// pruneLocked drops finished jobs past their retention window together with
+// their files. Callers must hold uc.mu.
+func (uc *exportUseCaseImpl) pruneLocked() {
+	cutoff := time.Now().Add(-exportJobRetention)
+	for id, job := range uc.jobs {
+		if job.CompletedAt != nil && job.CompletedAt.Before(cutoff) {
+			os.Remove(job.filePath)
+			delete(uc.jobs, id)
+		}
+	}
+}
+
+func IsExportResource(resource string) bool {
+	switch resource {
+	case ExportResourceStudents, ExportResourceLecturers, ExportResourceEnrollments:
+		return true
+	}
+	return false
+}
+
+var studentExportColumns = []string{
+	"id", "nim", "name", "email", "phone", "address", "date_of_birth", "gender",
+	"major", "enrollment_year", "status", "gpa", "created_at", "updated_at",
+}
+
+func studentExportRow(s *entity.Student) []interface{} {
+	return []interface{}{
+		s.ID.String(), s.NIM, s.Name, s.Email, s.Phone, s.Address, optionalDate(s.DateOfBirth), s.Gender,
+		s.Major, s.EnrollmentYear, s.Status, s.GPA, s.CreatedAt, s.UpdatedAt,
+	}
+}
+
+var lecturerExportColumns = []string{
+	"id", "nip", "name", "email", "phone", "department", "position", "specialization",
+	"education_level", "gender", "status", "created_at", "updated_at",
+}
+
+func lecturerExportRow(l *entity.Lecturer) []interface{} {
+	return []interface{}{
+		l.ID.String(), l.NIP, l.Name, l.Email, l.Phone, l.Department, l.Position, l.Specialization,
+		l.EducationLevel, l.Gender, l.Status, l.CreatedAt, l.UpdatedAt,
+	}
+}
+
+var enrollmentExportColumns = []string{
+	"id", "student_id", "course_id", "academic_year", "semester", "enrollment_date",
+	"status", "grade", "score", "attendance_percentage", "created_at",
+}
+
+func enrollmentExportRow(e *entity.Enrollment) []interface{} {
+	return []interface{}{
+		e.ID.String(), e.StudentID.String(), e.CourseID.String(), e.AcademicYear, e.Semester, e.EnrollmentDate,
+		e.Status, e.Grade, optionalFloat(e.Score), optionalFloat(e.AttendancePercentage), e.CreatedAt,
+	}
+}
+
+func optionalDate(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format("2006-01-02")
+}
+
+func optionalFloat(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
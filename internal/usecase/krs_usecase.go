@@ -0,0 +1,489 @@
+// File: internal/usecase/krs_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/actor"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
+)
+
+// KRSUseCase runs the approval of KRS: a student's enrollments in a term
+// are submitted to their advisor as a whole, and the advisor approves them
+// or sends them back for revision. The KRS of a term is started as a draft
+// by the student's first enrollment in it.
+type KRSUseCase interface {
+	// Get returns a KRS with its reviews. Only the student it belongs to,
+	// their current advisor and admins may read it.
+	Get(ctx context.Context, id uuid.UUID) (*entity.KRSSubmission, error)
+	// ListByStudent returns the KRS of a student, latest term first, to
+	// the same callers as Get.
+	ListByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.KRSSubmission, error)
+	// Submit sends a draft, or a KRS sent back for revision, to the
+	// student's current advisor. Only the student it belongs to, or an
+	// admin, may submit it.
+	Submit(ctx context.Context, id uuid.UUID, version int) (*entity.KRSSubmission, error)
+	// Review approves a submitted KRS or requests its revision. Only the
+	// advisor it was submitted to, or an admin, may review it.
+	Review(ctx context.Context, id uuid.UUID, version int, decision, comment string) (*entity.KRSSubmission, error)
+	// Inbox returns the KRS waiting for a decision of an advisor, the
+	// longest waiting first. uuid.Nil stands for the lecturer linked to the
+	// caller.
+	Inbox(ctx context.Context, lecturerID uuid.UUID, page, pageSize int) ([]*entity.KRSSubmission, int64, error)
+}
+
+type krsUseCaseImpl struct {
+	repo           repository.KRSRepository
+	studentRepo    repository.StudentRepository
+	enrollmentRepo repository.EnrollmentRepository
+	courseRepo     repository.CourseRepository
+	lecturerRepo   repository.LecturerRepository
+	advisorRepo    repository.AdvisorRepository
+	enrollments    EnrollmentUseCase
+	txManager      repository.TxManager
+	auditor        *Auditor
+	outbox         *Outbox
+	seats          *SeatFeed
+	seatKRS        []string
+}
+
+// NewKRSUseCase counts the enrollments whose KRS is in one of the states
+// seatKRS as taking a seat, as the enrollment use case does. Seats a KRS
+// decision releases are given to the waitlists through enrollments.
+func NewKRSUseCase(
+	repo repository.KRSRepository,
+	studentRepo repository.StudentRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	courseRepo repository.CourseRepository,
+	lecturerRepo repository.LecturerRepository,
+	advisorRepo repository.AdvisorRepository,
+	enrollments EnrollmentUseCase,
+	txManager repository.TxManager,
+	auditor *Auditor,
+	outbox *Outbox,
+	seats *SeatFeed,
+	seatKRS []string,
+) KRSUseCase {
+	return &krsUseCaseImpl{
+		repo:           repo,
+		studentRepo:    studentRepo,
+		enrollmentRepo: enrollmentRepo,
+		courseRepo:     courseRepo,
+		lecturerRepo:   lecturerRepo,
+		advisorRepo:    advisorRepo,
+		enrollments:    enrollments,
+		txManager:      txManager,
+		auditor:        auditor,
+		outbox:         outbox,
+		seats:          seats,
+		seatKRS:        seatKRS,
+	}
+}
+
+// krsEditable reports whether courses may still be added to a KRS in
+// status.
+func krsEditable(status string) bool {
+	return status == entity.KRSDraft || status == entity.KRSRevisionRequested
+}
+
+func (uc *krsUseCaseImpl) Get(ctx context.Context, id uuid.UUID) (*entity.KRSSubmission, error) {
+	krs, err := uc.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkReader(ctx, krs.StudentID); err != nil {
+		return nil, err
+	}
+	return krs, nil
+}
+
+// find returns a KRS with its reviews, whoever the caller is.
+func (uc *krsUseCaseImpl) find(ctx context.Context, id uuid.UUID) (*entity.KRSSubmission, error) {
+	krs, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("KRS not found")
+		}
+		return nil, err
+	}
+	return krs, uc.withReviews(ctx, krs)
+}
+
+func (uc *krsUseCaseImpl) withReviews(ctx context.Context, krs *entity.KRSSubmission) error {
+	reviews, err := uc.repo.FindReviews(ctx, krs.ID)
+	if err != nil {
+		return err
+	}
+	krs.Reviews = make([]entity.KRSReview, len(reviews))
+	for i, review := range reviews {
+		krs.Reviews[i] = *review
+	}
+	return nil
+}
+
+func (uc *krsUseCaseImpl) ListByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.KRSSubmission, error) {
+	if err := uc.checkReader(ctx, studentID); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindByStudent(ctx, studentID)
+}
+
+// checkReader lets the student with studentID, their current advisor and
+// admins read the student's KRS.
+func (uc *krsUseCaseImpl) checkReader(ctx context.Context, studentID uuid.UUID) error {
+	caller := actor.From(ctx)
+	if caller.Role == "admin" {
+		return nil
+	}
+	owner, err := uc.isStudent(ctx, caller, studentID)
+	if err != nil || owner {
+		return err
+	}
+	advisor, err := uc.isCurrentAdvisor(ctx, caller, studentID)
+	if err != nil {
+		return err
+	}
+	if !advisor {
+		return apperror.Forbidden("only the student, their academic advisor and admins can see this KRS")
+	}
+	return nil
+}
+
+// checkSubmitter lets the student a KRS belongs to submit it, and admins
+// on their behalf.
+func (uc *krsUseCaseImpl) checkSubmitter(ctx context.Context, krs *entity.KRSSubmission) error {
+	caller := actor.From(ctx)
+	if caller.Role == "admin" {
+		return nil
+	}
+	owner, err := uc.isStudent(ctx, caller, krs.StudentID)
+	if err != nil {
+		return err
+	}
+	if !owner {
+		return apperror.Forbidden("only the student can submit their KRS")
+	}
+	return nil
+}
+
+// isStudent reports whether caller is the student with studentID, going
+// by the user account linked to the student record.
+func (uc *krsUseCaseImpl) isStudent(ctx context.Context, caller actor.Actor, studentID uuid.UUID) (bool, error) {
+	if caller.UserID == uuid.Nil {
+		return false, nil
+	}
+	student, err := uc.studentRepo.FindByID(ctx, studentID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return false, err
+	}
+	return student != nil && student.UserID != nil && *student.UserID == caller.UserID, nil
+}
+
+// isCurrentAdvisor reports whether caller is the current advisor of the
+// student with studentID, going by the user account linked to the
+// lecturer record.
+func (uc *krsUseCaseImpl) isCurrentAdvisor(ctx context.Context, caller actor.Actor, studentID uuid.UUID) (bool, error) {
+	if caller.UserID == uuid.Nil {
+		return false, nil
+	}
+	lecturer, err := uc.lecturerRepo.FindByUserID(ctx, caller.UserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return false, err
+	}
+	if lecturer == nil {
+		return false, nil
+	}
+	advisor, err := uc.advisorRepo.FindCurrent(ctx, studentID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return false, err
+	}
+	return advisor != nil && advisor.LecturerID == lecturer.ID, nil
+}
+
+func (uc *krsUseCaseImpl) Submit(ctx context.Context, id uuid.UUID, version int) (*entity.KRSSubmission, error) {
+	var existing, submitted *entity.KRSSubmission
+	var courses []uuid.UUID
+	var promoted []*entity.Enrollment
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if existing, err = uc.find(ctx, id); err != nil {
+			return err
+		}
+		if err := uc.checkSubmitter(ctx, existing); err != nil {
+			return err
+		}
+		expected, err := checkVersion(existing.Version, version)
+		if err != nil {
+			return err
+		}
+		if !krsEditable(existing.Status) {
+			return apperror.Conflict("status", fmt.Sprintf("KRS is %s, only a draft or a KRS sent back for revision can be submitted", existing.Status))
+		}
+
+		advisor, err := uc.advisorRepo.FindCurrent(ctx, existing.StudentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return apperror.Validation("student has no academic advisor")
+			}
+			return err
+		}
+		if courses, err = uc.courses(ctx, existing); err != nil {
+			return err
+		}
+		if len(courses) == 0 {
+			return apperror.Validation("KRS has no courses")
+		}
+
+		changes := map[string]interface{}{
+			"status":       entity.KRSSubmitted,
+			"advisor_id":   advisor.LecturerID,
+			"submitted_at": time.Now().UTC(),
+		}
+		if submitted, err = uc.update(ctx, existing, expected, changes); err != nil {
+			return err
+		}
+		if err := uc.checkSeats(ctx, existing, submitted, courses); err != nil {
+			return err
+		}
+		if promoted, err = uc.promote(ctx, existing, submitted, courses); err != nil {
+			return err
+		}
+		return uc.outbox.Add(ctx, KRSSubmitted{
+			SubmissionID: id,
+			StudentID:    submitted.StudentID,
+			AdvisorID:    advisor.LecturerID,
+			AcademicYear: submitted.AcademicYear,
+			Semester:     submitted.Semester,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordPromotions(ctx, promoted)
+	uc.seatsChanged(ctx, existing, submitted, courses)
+	return submitted, nil
+}
+
+func (uc *krsUseCaseImpl) Review(ctx context.Context, id uuid.UUID, version int, decision, comment string) (*entity.KRSSubmission, error) {
+	comment = strings.TrimSpace(comment)
+	switch decision {
+	case entity.KRSApproved:
+	case entity.KRSRevisionRequested:
+		if comment == "" {
+			return nil, apperror.Validation("a comment is required when requesting a revision")
+		}
+	default:
+		return nil, apperror.Validation(fmt.Sprintf("decision must be %s or %s", entity.KRSApproved, entity.KRSRevisionRequested))
+	}
+
+	var existing, reviewed *entity.KRSSubmission
+	var courses []uuid.UUID
+	var promoted []*entity.Enrollment
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if existing, err = uc.find(ctx, id); err != nil {
+			return err
+		}
+		expected, err := checkVersion(existing.Version, version)
+		if err != nil {
+			return err
+		}
+		if existing.Status != entity.KRSSubmitted {
+			return apperror.Conflict("status", fmt.Sprintf("KRS is %s, only a submitted KRS can be reviewed", existing.Status))
+		}
+		if err := uc.checkAdvisor(ctx, existing); err != nil {
+			return err
+		}
+		if courses, err = uc.courses(ctx, existing); err != nil {
+			return err
+		}
+
+		review := &entity.KRSReview{
+			SubmissionID: id,
+			Decision:     decision,
+			Comment:      comment,
+			LecturerID:   existing.AdvisorID,
+		}
+		if caller := actor.From(ctx); caller.UserID != uuid.Nil {
+			review.ReviewedBy = &caller.UserID
+		}
+		if err := uc.repo.AddReview(ctx, review); err != nil {
+			return err
+		}
+		changes := map[string]interface{}{
+			"status":     decision,
+			"decided_at": time.Now().UTC(),
+		}
+		if reviewed, err = uc.update(ctx, existing, expected, changes); err != nil {
+			return err
+		}
+		if err := uc.checkSeats(ctx, existing, reviewed, courses); err != nil {
+			return err
+		}
+		if promoted, err = uc.promote(ctx, existing, reviewed, courses); err != nil {
+			return err
+		}
+		return uc.outbox.Add(ctx, KRSReviewed{
+			SubmissionID: id,
+			StudentID:    reviewed.StudentID,
+			AdvisorID:    reviewed.AdvisorID,
+			AcademicYear: reviewed.AcademicYear,
+			Semester:     reviewed.Semester,
+			Decision:     decision,
+			Comment:      comment,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordKRSReview(decision)
+	recordPromotions(ctx, promoted)
+	uc.seatsChanged(ctx, existing, reviewed, courses)
+	return reviewed, nil
+}
+
+// update applies changes to krs and returns it as it is now, with its
+// reviews.
+func (uc *krsUseCaseImpl) update(ctx context.Context, krs *entity.KRSSubmission, version int, changes map[string]interface{}) (*entity.KRSSubmission, error) {
+	if err := uc.repo.Update(ctx, krs.ID, version, changes); err != nil {
+		return nil, err
+	}
+	updated, err := uc.find(ctx, krs.ID)
+	if err != nil {
+		return nil, err
+	}
+	before, after := *krs, *updated
+	before.Reviews, after.Reviews = nil, nil
+	return updated, uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditKRS, krs.ID, &before, &after)
+}
+
+// checkAdvisor lets the advisor a KRS was submitted to review it, and
+// admins on their behalf. Advisors are recognised by the user account
+// linked to their lecturer record.
+func (uc *krsUseCaseImpl) checkAdvisor(ctx context.Context, krs *entity.KRSSubmission) error {
+	caller := actor.From(ctx)
+	if caller.Role == "admin" {
+		return nil
+	}
+	lecturer, err := uc.lecturerRepo.FindByUserID(ctx, caller.UserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if lecturer == nil || krs.AdvisorID == nil || lecturer.ID != *krs.AdvisorID {
+		return apperror.Forbidden("only the student's academic advisor can review this KRS")
+	}
+	return nil
+}
+
+// courses returns the courses of the enrollments in krs that were not
+// dropped.
+func (uc *krsUseCaseImpl) courses(ctx context.Context, krs *entity.KRSSubmission) ([]uuid.UUID, error) {
+	enrollments, err := uc.enrollmentRepo.FindActiveByStudent(ctx, krs.StudentID, krs.AcademicYear, krs.Semester)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find enrollments of the term: %w", err)
+	}
+	courses := make([]uuid.UUID, len(enrollments))
+	for i, e := range enrollments {
+		courses[i] = e.CourseID
+	}
+	return courses, nil
+}
+
+// checkSeats makes sure no course of a KRS is over capacity once moving
+// it from before to after made its enrollments take their seats. It runs
+// after the update, so enrollments that already held a seat, such as
+// those promoted from the waitlist, are counted once.
+func (uc *krsUseCaseImpl) checkSeats(ctx context.Context, before, after *entity.KRSSubmission, courses []uuid.UUID) error {
+	if slices.Contains(uc.seatKRS, before.Status) || !slices.Contains(uc.seatKRS, after.Status) {
+		return nil
+	}
+	for _, courseID := range courses {
+		course, err := uc.courseRepo.FindByID(ctx, courseID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if course.MaxStudents == 0 {
+			continue
+		}
+		taken, err := uc.enrollmentRepo.CountActiveByCourse(ctx, courseID, after.AcademicYear, after.Semester, uc.seatKRS)
+		if err != nil {
+			return fmt.Errorf("failed to count course seats: %w", err)
+		}
+		if taken > int64(course.MaxStudents) {
+			return apperror.Conflict("course_id", fmt.Sprintf("course %s is full", course.Code))
+		}
+	}
+	return nil
+}
+
+// promote gives the seats the enrollments of a KRS released, when moving
+// it from before to after stopped counting them, to the waitlists of its
+// courses.
+func (uc *krsUseCaseImpl) promote(ctx context.Context, before, after *entity.KRSSubmission, courses []uuid.UUID) ([]*entity.Enrollment, error) {
+	if !slices.Contains(uc.seatKRS, before.Status) || slices.Contains(uc.seatKRS, after.Status) {
+		return nil, nil
+	}
+	var promoted []*entity.Enrollment
+	for _, courseID := range courses {
+		enrollments, err := uc.enrollments.PromoteWaitlist(ctx, repository.SeatChange{
+			CourseID:     courseID,
+			AcademicYear: after.AcademicYear,
+			Semester:     after.Semester,
+		})
+		if err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, enrollments...)
+	}
+	return promoted, nil
+}
+
+// seatsChanged announces the seats of the courses of a committed KRS
+// change when its enrollments started or stopped taking them.
+func (uc *krsUseCaseImpl) seatsChanged(ctx context.Context, before, after *entity.KRSSubmission, courses []uuid.UUID) {
+	if slices.Contains(uc.seatKRS, before.Status) == slices.Contains(uc.seatKRS, after.Status) {
+		return
+	}
+	for _, courseID := range courses {
+		uc.seats.Changed(ctx, repository.SeatChange{
+			CourseID:     courseID,
+			AcademicYear: after.AcademicYear,
+			Semester:     after.Semester,
+		})
+	}
+}
+
+func (uc *krsUseCaseImpl) Inbox(ctx context.Context, lecturerID uuid.UUID, page, pageSize int) ([]*entity.KRSSubmission, int64, error) {
+	caller := actor.From(ctx)
+	if lecturerID == uuid.Nil {
+		lecturer, err := uc.lecturerRepo.FindByUserID(ctx, caller.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, 0, apperror.NotFound("no lecturer is linked to this account")
+			}
+			return nil, 0, err
+		}
+		lecturerID = lecturer.ID
+	} else if caller.Role != "admin" {
+		lecturer, err := uc.lecturerRepo.FindByUserID(ctx, caller.UserID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, 0, err
+		}
+		if lecturer == nil || lecturer.ID != lecturerID {
+			return nil, 0, apperror.Forbidden("only admins can see the inbox of another advisor")
+		}
+	}
+	return uc.repo.FindSubmitted(ctx, lecturerID, page, pageSize)
+}
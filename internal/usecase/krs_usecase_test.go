@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/actor"
+)
+
+func TestKRSRevisionPromotesWaitlist(t *testing.T) {
+	tests := []struct {
+		name         string
+		seatsFrom    string
+		wantPromoted bool
+	}{
+		{name: "revision releases seats counted from submission", seatsFrom: entity.KRSSubmitted, wantPromoted: true},
+		{name: "a submitted KRS holds no seat counted from approval", seatsFrom: entity.KRSApproved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			a := newAcademic(t, tt.seatsFrom)
+			course := a.course("IF101", 1)
+			first, second := a.student("2024001"), a.student("2024002")
+
+			_, err := a.enroll(ctx, first.ID, course.ID)
+			a.must(err)
+			if tt.seatsFrom == entity.KRSApproved {
+				// Another student's approved KRS fills the course, which
+				// the first student's submitted one does not.
+				holder := a.student("2024003")
+				_, err := a.enroll(ctx, holder.ID, course.ID)
+				a.must(err)
+				a.must(a.decide(ctx, holder.ID, entity.KRSApproved))
+			}
+			a.must(a.decide(ctx, first.ID, ""))
+			a.must(a.waitlist.Join(ctx, &entity.WaitlistEntry{StudentID: second.ID, CourseID: course.ID, AcademicYear: testYear, Semester: testSemester}))
+
+			krs := a.termKRS(first.ID)
+			if _, err := a.krsUseCase.Review(ctx, krs.ID, krs.Version, entity.KRSRevisionRequested, "drop a course"); err != nil {
+				t.Fatalf("Review() = %v", err)
+			}
+
+			_, err = a.enrollments.FindByStudentCourse(ctx, second.ID, course.ID, testYear, testSemester)
+			if promoted := err == nil; promoted != tt.wantPromoted {
+				t.Fatalf("second student promoted = %v, want %v", promoted, tt.wantPromoted)
+			}
+			if !tt.wantPromoted {
+				return
+			}
+
+			// The seat went to the waitlist, so resubmitting finds the
+			// course full and changes nothing.
+			krs = a.termKRS(first.ID)
+			if _, err := a.krsUseCase.Submit(ctx, krs.ID, krs.Version); apperror.KindOf(err) != apperror.KindConflict {
+				t.Fatalf("resubmitting = %v, want a conflict", err)
+			}
+			if got := a.termKRS(first.ID).Status; got != entity.KRSRevisionRequested {
+				t.Errorf("KRS is %s after the failed submission, want %s", got, entity.KRSRevisionRequested)
+			}
+			if got := a.taken(course.ID, tt.seatsFrom); got != 1 {
+				t.Errorf("%d seats taken, want 1", got)
+			}
+		})
+	}
+}
+
+func TestKRSRoles(t *testing.T) {
+	ownerUser, otherUser, advisorUser, lecturerUser := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name   string
+		caller actor.Actor
+		// read, submit and review are whether the caller may read,
+		// submit and review the owner's KRS.
+		read, submit, review bool
+	}{
+		{name: "admin", caller: actor.Actor{UserID: uuid.New(), Role: "admin"}, read: true, submit: true, review: true},
+		{name: "staff", caller: actor.Actor{UserID: uuid.New(), Role: "staff"}},
+		{name: "owning student", caller: actor.Actor{UserID: ownerUser, Role: "student"}, read: true, submit: true},
+		{name: "current advisor", caller: actor.Actor{UserID: advisorUser, Role: "staff"}, read: true, review: true},
+		{name: "another student", caller: actor.Actor{UserID: otherUser, Role: "student"}},
+		{name: "another student's advisor", caller: actor.Actor{UserID: lecturerUser, Role: "staff"}},
+		{name: "anonymous", caller: actor.Actor{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAcademic(t, entity.KRSApproved)
+			admin := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			course := a.course("IF101", 10)
+			owner, other := a.student("2024001"), a.student("2024002")
+			_, err := a.enroll(admin, owner.ID, course.ID)
+			a.must(err)
+			a.linkUser(owner, ownerUser)
+			a.linkUser(other, otherUser)
+			a.linkAdvisor(owner, advisorUser)
+			a.linkAdvisor(other, lecturerUser)
+			ctx := actor.With(context.Background(), tt.caller)
+
+			krs := a.termKRS(owner.ID)
+			_, getErr := a.krsUseCase.Get(ctx, krs.ID)
+			_, listErr := a.krsUseCase.ListByStudent(ctx, owner.ID)
+			_, submitErr := a.krsUseCase.Submit(ctx, krs.ID, krs.Version)
+			if !tt.submit {
+				// Review a KRS an admin submitted instead.
+				a.must(a.decide(admin, owner.ID, ""))
+			}
+			krs = a.termKRS(owner.ID)
+			_, reviewErr := a.krsUseCase.Review(ctx, krs.ID, krs.Version, entity.KRSApproved, "")
+
+			for _, c := range []struct {
+				method  string
+				err     error
+				allowed bool
+			}{
+				{"Get", getErr, tt.read},
+				{"ListByStudent", listErr, tt.read},
+				{"Submit", submitErr, tt.submit},
+				{"Review", reviewErr, tt.review},
+			} {
+				switch {
+				case c.allowed && c.err != nil:
+					t.Errorf("%s() = %v, want nil", c.method, c.err)
+				case !c.allowed && apperror.KindOf(c.err) != apperror.KindForbidden:
+					t.Errorf("%s() = %v, want forbidden", c.method, c.err)
+				}
+			}
+		})
+	}
+}
+
+func TestKRSTransitions(t *testing.T) {
+	admin := actor.With(context.Background(), actor.Actor{Role: "admin"})
+	tests := []struct {
+		name string
+		// run moves the KRS of a student enrolled in one course and
+		// returns the error of the transition under test.
+		run      func(a *academic, krs *entity.KRSSubmission) error
+		wantKind apperror.Kind
+		wantErr  error
+	}{
+		{
+			name: "reviewing a draft",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				_, err := a.krsUseCase.Review(admin, krs.ID, krs.Version, entity.KRSApproved, "")
+				return err
+			},
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "submitting an approved KRS",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				a.must(a.decide(admin, krs.StudentID, entity.KRSApproved))
+				krs = a.termKRS(krs.StudentID)
+				_, err := a.krsUseCase.Submit(admin, krs.ID, krs.Version)
+				return err
+			},
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "reviewing an approved KRS",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				a.must(a.decide(admin, krs.StudentID, entity.KRSApproved))
+				krs = a.termKRS(krs.StudentID)
+				_, err := a.krsUseCase.Review(admin, krs.ID, krs.Version, entity.KRSRevisionRequested, "too late")
+				return err
+			},
+			wantKind: apperror.KindConflict,
+		},
+		{
+			name: "requesting a revision without a comment",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				a.must(a.decide(admin, krs.StudentID, ""))
+				krs = a.termKRS(krs.StudentID)
+				_, err := a.krsUseCase.Review(admin, krs.ID, krs.Version, entity.KRSRevisionRequested, "  ")
+				return err
+			},
+			wantKind: apperror.KindValidation,
+		},
+		{
+			name: "an unknown decision",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				a.must(a.decide(admin, krs.StudentID, ""))
+				krs = a.termKRS(krs.StudentID)
+				_, err := a.krsUseCase.Review(admin, krs.ID, krs.Version, entity.KRSDraft, "")
+				return err
+			},
+			wantKind: apperror.KindValidation,
+		},
+		{
+			name: "a reviewer who is not the advisor",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				a.must(a.decide(admin, krs.StudentID, ""))
+				krs = a.termKRS(krs.StudentID)
+				ctx := actor.With(context.Background(), actor.Actor{UserID: uuid.New(), Role: "staff"})
+				_, err := a.krsUseCase.Review(ctx, krs.ID, krs.Version, entity.KRSApproved, "")
+				return err
+			},
+			wantKind: apperror.KindForbidden,
+		},
+		{
+			name: "submitting a stale version",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				_, err := a.krsUseCase.Submit(admin, krs.ID, krs.Version+1)
+				return err
+			},
+			wantErr: repository.ErrVersionConflict,
+		},
+		{
+			name: "submitting a KRS without courses",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				empty := &entity.KRSSubmission{StudentID: a.student("2024002").ID, AcademicYear: testYear, Semester: testSemester, Status: entity.KRSDraft}
+				a.must(a.krs.Create(admin, empty))
+				_, err := a.krsUseCase.Submit(admin, empty.ID, empty.Version)
+				return err
+			},
+			wantKind: apperror.KindValidation,
+		},
+		{
+			name: "submitting without an advisor",
+			run: func(a *academic, krs *entity.KRSSubmission) error {
+				student := &entity.Student{NIM: "2024003", Name: "Unadvised", Email: "2024003@students.example.com", Major: "Informatics", EnrollmentYear: 2024, Status: "active"}
+				a.must(a.students.Create(admin, student))
+				_, err := a.enroll(admin, student.ID, a.course("IF102", 10).ID)
+				a.must(err)
+				unadvised := a.termKRS(student.ID)
+				_, err = a.krsUseCase.Submit(admin, unadvised.ID, unadvised.Version)
+				return err
+			},
+			wantKind: apperror.KindValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAcademic(t, entity.KRSApproved)
+			student := a.student("2024001")
+			_, err := a.enroll(admin, student.ID, a.course("IF101", 10).ID)
+			a.must(err)
+			krs := a.termKRS(student.ID)
+			before := krs.Status
+
+			err = tt.run(a, krs)
+			switch {
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			case tt.wantErr == nil && apperror.KindOf(err) != tt.wantKind:
+				t.Fatalf("got %v, want kind %v", err, tt.wantKind)
+			}
+			if got := a.termKRS(student.ID); tt.wantErr != nil && (got.Status != before || got.Version != krs.Version) {
+				t.Errorf("KRS moved to %s v%d on a stale version", got.Status, got.Version)
+			}
+		})
+	}
+}
+
+func TestKRSSeatModes(t *testing.T) {
+	tests := []struct {
+		name      string
+		seatsFrom string
+		// decision is the review that claims a seat, or empty when
+		// submitting claims it.
+		decision string
+	}{
+		{name: "seats counted from submission fill on submit", seatsFrom: entity.KRSSubmitted},
+		{name: "seats counted from approval fill on approval", seatsFrom: entity.KRSApproved, decision: entity.KRSApproved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			a := newAcademic(t, tt.seatsFrom)
+			course := a.course("IF101", 1)
+			first, second := a.student("2024001"), a.student("2024002")
+
+			// Drafts hold no seat, so both students get in.
+			for _, s := range []*entity.Student{first, second} {
+				_, err := a.enroll(ctx, s.ID, course.ID)
+				a.must(err)
+			}
+			if got := a.taken(course.ID, tt.seatsFrom); got != 0 {
+				t.Fatalf("%d seats taken by drafts, want 0", got)
+			}
+
+			a.must(a.decide(ctx, first.ID, tt.decision))
+			if tt.decision != "" {
+				// Submitting is not yet a claim on the seat.
+				a.must(a.decide(ctx, second.ID, ""))
+				krs := a.termKRS(second.ID)
+				_, err := a.krsUseCase.Review(ctx, krs.ID, krs.Version, tt.decision, "")
+				if apperror.KindOf(err) != apperror.KindConflict {
+					t.Fatalf("approving into a full course = %v, want a conflict", err)
+				}
+				if got := a.termKRS(second.ID).Status; got != entity.KRSSubmitted {
+					t.Errorf("KRS is %s after the failed approval, want %s", got, entity.KRSSubmitted)
+				}
+			} else {
+				err := a.decide(ctx, second.ID, "")
+				if apperror.KindOf(err) != apperror.KindConflict {
+					t.Fatalf("submitting into a full course = %v, want a conflict", err)
+				}
+				if got := a.termKRS(second.ID).Status; got != entity.KRSDraft {
+					t.Errorf("KRS is %s after the failed submission, want %s", got, entity.KRSDraft)
+				}
+			}
+			if got := a.taken(course.ID, tt.seatsFrom); got != 1 {
+				t.Errorf("%d seats taken, want 1", got)
+			}
+		})
+	}
+}
+
+func TestApprovalPastCapacity(t *testing.T) {
+	ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+	a := newAcademic(t, entity.KRSApproved)
+	course := a.course("IF101", 1)
+	first, second := a.student("2024001"), a.student("2024002")
+	// Neither draft nor submission holds a seat, so both get this far.
+	for _, s := range []*entity.Student{first, second} {
+		_, err := a.enroll(ctx, s.ID, course.ID)
+		a.must(err)
+	}
+	a.must(a.decide(ctx, first.ID, entity.KRSApproved))
+	a.must(a.decide(ctx, second.ID, ""))
+
+	advisorUser := uuid.New()
+	a.linkAdvisor(second, advisorUser)
+	advisor := actor.With(context.Background(), actor.Actor{UserID: advisorUser, Role: "staff"})
+	krs := a.termKRS(second.ID)
+	_, err := a.krsUseCase.Review(advisor, krs.ID, krs.Version, entity.KRSApproved, "")
+	if err == nil || err.Error() != "course IF101 is full" || apperror.KindOf(err) != apperror.KindConflict || apperror.FieldOf(err) != "course_id" {
+		t.Fatalf("approving past the capacity = %v, want a conflict on course_id naming the course", err)
+	}
+	inbox, _, err := a.krsUseCase.Inbox(advisor, uuid.Nil, 1, 10)
+	if err != nil || len(inbox) != 1 || inbox[0].ID != krs.ID || inbox[0].Status != entity.KRSSubmitted {
+		t.Fatalf("Inbox() = %v, %v, want the submitted KRS that could not be approved", inbox, err)
+	}
+
+	// Sending it back takes it off the inbox.
+	if _, err := a.krsUseCase.Review(advisor, krs.ID, krs.Version, entity.KRSRevisionRequested, "IF101 is full, pick another course"); err != nil {
+		t.Fatalf("requesting a revision = %v", err)
+	}
+	if got := a.taken(course.ID, entity.KRSApproved); got != 1 {
+		t.Errorf("%d seats taken, want 1", got)
+	}
+}
@@ -6,30 +6,44 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
-	"gorm.io/gorm"
 )
 
 type LecturerUseCase interface {
 	Create(ctx context.Context, lecturer *entity.Lecturer) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Lecturer, error)
-	GetAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Lecturer, int64, error)
-	Update(ctx context.Context, id uuid.UUID, lecturer *entity.Lecturer) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Lecturer, int64, error)
+	GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Lecturer], error)
+	Count(ctx context.Context, spec query.Spec) (int64, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Lecturer, error)
+	Delete(ctx context.Context, id uuid.UUID, version int) error
 }
 
 type lecturerUseCaseImpl struct {
-	repo repository.LecturerRepository
+	repo      repository.LecturerRepository
+	txManager repository.TxManager
+	auditor   *Auditor
 }
 
-func NewLecturerUseCase(repo repository.LecturerRepository) LecturerUseCase {
-	return &lecturerUseCaseImpl{repo: repo}
+func NewLecturerUseCase(repo repository.LecturerRepository, txManager repository.TxManager, auditor *Auditor) LecturerUseCase {
+	return &lecturerUseCaseImpl{repo: repo, txManager: txManager, auditor: auditor}
 }
 
 func (uc *lecturerUseCaseImpl) Create(ctx context.Context, lecturer *entity.Lecturer) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.create(ctx, lecturer); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditLecturer, lecturer.ID, nil, lecturer)
+	})
+}
+
+func (uc *lecturerUseCaseImpl) create(ctx context.Context, lecturer *entity.Lecturer) error {
 	if lecturer.NIP == "" || lecturer.Name == "" || lecturer.Email == "" || lecturer.Department == "" {
-		return errors.New("required fields are missing")
+		return apperror.Validation("required fields are missing")
 	}
 	return uc.repo.Create(ctx, lecturer)
 }
@@ -37,43 +51,75 @@ func (uc *lecturerUseCaseImpl) Create(ctx context.Context, lecturer *entity.Lect
 func (uc *lecturerUseCaseImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.Lecturer, error) {
 	lecturer, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("lecturer not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("lecturer not found")
 		}
 		return nil, err
 	}
 	return lecturer, nil
 }
 
-func (uc *lecturerUseCaseImpl) GetAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Lecturer, int64, error) {
-	if page < 1 {
-		page = 1
+func (uc *lecturerUseCaseImpl) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Lecturer, int64, error) {
+	return uc.repo.FindAll(ctx, page, pageSize, spec)
+}
+
+func (uc *lecturerUseCaseImpl) GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Lecturer], error) {
+	return uc.repo.FindPage(ctx, page, spec)
+}
+
+func (uc *lecturerUseCaseImpl) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	return uc.repo.Count(ctx, spec)
+}
+
+func (uc *lecturerUseCaseImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Lecturer, error) {
+	var updated *entity.Lecturer
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = uc.update(ctx, id, version, changes)
+		return err
+	})
+	return updated, err
+}
+
+func (uc *lecturerUseCaseImpl) update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Lecturer, error) {
+	existing, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	version, err = checkVersion(existing.Version, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return existing, nil
+	}
+	if err := uc.repo.Update(ctx, id, version, changes); err != nil {
+		return nil, err
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+	updated, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	return uc.repo.FindAll(ctx, page, pageSize, filters)
+	return updated, uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditLecturer, id, existing, updated)
 }
 
-func (uc *lecturerUseCaseImpl) Update(ctx context.Context, id uuid.UUID, lecturer *entity.Lecturer) error {
-	existing, err := uc.repo.FindByID(ctx, id)
+func (uc *lecturerUseCaseImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.delete(ctx, id, version)
+	})
+}
+
+func (uc *lecturerUseCaseImpl) delete(ctx context.Context, id uuid.UUID, version int) error {
+	existing, err := uc.GetByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("lecturer not found")
-		}
 		return err
 	}
-	lecturer.ID = existing.ID
-	return uc.repo.Update(ctx, lecturer)
-}
-
-func (uc *lecturerUseCaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := uc.repo.FindByID(ctx, id)
+	version, err = checkVersion(existing.Version, version)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("lecturer not found")
-		}
 		return err
 	}
-	return uc.repo.Delete(ctx, id)
+	if err := uc.repo.Delete(ctx, id, version); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, entity.AuditDelete, entity.AuditLecturer, id, existing, nil)
 }
@@ -0,0 +1,328 @@
+// File: internal/usecase/outbox_usecase.go
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/eventsink"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
+)
+
+const (
+	// outboxRetryBackoff is the wait after the first failed attempt to
+	// publish an event; it doubles with every further one, up to
+	// outboxMaxBackoff.
+	outboxRetryBackoff = time.Second
+	outboxMaxBackoff   = 5 * time.Minute
+
+	// outboxCleanupInterval is how often the relay deletes events older
+	// than the retention.
+	outboxCleanupInterval = time.Hour
+)
+
+// DomainEvent is the payload of an event other systems react to. Every
+// event of a student, including those of their enrollments, shares the
+// student's ID as ordering key, so consumers see them in order.
+type DomainEvent interface {
+	EventType() string
+	Aggregate() (aggregateType string, id uuid.UUID)
+	OrderingKey() string
+}
+
+type StudentCreated struct {
+	StudentID      uuid.UUID `json:"student_id"`
+	NIM            string    `json:"nim"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	Major          string    `json:"major"`
+	EnrollmentYear int       `json:"enrollment_year"`
+	Status         string    `json:"status"`
+}
+
+func (StudentCreated) EventType() string { return entity.EventStudentCreated }
+func (e StudentCreated) Aggregate() (string, uuid.UUID) {
+	return entity.AuditStudent, e.StudentID
+}
+func (e StudentCreated) OrderingKey() string { return e.StudentID.String() }
+
+type StudentStatusChanged struct {
+	StudentID uuid.UUID `json:"student_id"`
+	NIM       string    `json:"nim"`
+	OldStatus string    `json:"old_status"`
+	NewStatus string    `json:"new_status"`
+}
+
+func (StudentStatusChanged) EventType() string { return entity.EventStudentStatusChanged }
+func (e StudentStatusChanged) Aggregate() (string, uuid.UUID) {
+	return entity.AuditStudent, e.StudentID
+}
+func (e StudentStatusChanged) OrderingKey() string { return e.StudentID.String() }
+
+type EnrollmentCreated struct {
+	EnrollmentID uuid.UUID `json:"enrollment_id"`
+	StudentID    uuid.UUID `json:"student_id"`
+	CourseID     uuid.UUID `json:"course_id"`
+	AcademicYear string    `json:"academic_year"`
+	Semester     int       `json:"semester"`
+	Status       string    `json:"status"`
+}
+
+func (EnrollmentCreated) EventType() string { return entity.EventEnrollmentCreated }
+func (e EnrollmentCreated) Aggregate() (string, uuid.UUID) {
+	return entity.AuditEnrollment, e.EnrollmentID
+}
+func (e EnrollmentCreated) OrderingKey() string { return e.StudentID.String() }
+
+type GradePosted struct {
+	EnrollmentID uuid.UUID `json:"enrollment_id"`
+	StudentID    uuid.UUID `json:"student_id"`
+	CourseID     uuid.UUID `json:"course_id"`
+	AcademicYear string    `json:"academic_year"`
+	Semester     int       `json:"semester"`
+	Grade        string    `json:"grade"`
+	Score        *float64  `json:"score"`
+}
+
+func (GradePosted) EventType() string { return entity.EventGradePosted }
+func (e GradePosted) Aggregate() (string, uuid.UUID) {
+	return entity.AuditEnrollment, e.EnrollmentID
+}
+func (e GradePosted) OrderingKey() string { return e.StudentID.String() }
+
+// WaitlistPromoted tells a student they got the seat they were waiting
+// for. The enrollment also raises its own EnrollmentCreated.
+type WaitlistPromoted struct {
+	EntryID      uuid.UUID `json:"entry_id"`
+	EnrollmentID uuid.UUID `json:"enrollment_id"`
+	StudentID    uuid.UUID `json:"student_id"`
+	CourseID     uuid.UUID `json:"course_id"`
+	AcademicYear string    `json:"academic_year"`
+	Semester     int       `json:"semester"`
+}
+
+func (WaitlistPromoted) EventType() string { return entity.EventWaitlistPromoted }
+func (e WaitlistPromoted) Aggregate() (string, uuid.UUID) {
+	return entity.AuditWaitlist, e.EntryID
+}
+func (e WaitlistPromoted) OrderingKey() string { return e.StudentID.String() }
+
+// KRSSubmitted asks an advisor to review the KRS of a student.
+type KRSSubmitted struct {
+	SubmissionID uuid.UUID `json:"submission_id"`
+	StudentID    uuid.UUID `json:"student_id"`
+	AdvisorID    uuid.UUID `json:"advisor_id"`
+	AcademicYear string    `json:"academic_year"`
+	Semester     int       `json:"semester"`
+}
+
+func (KRSSubmitted) EventType() string { return entity.EventKRSSubmitted }
+func (e KRSSubmitted) Aggregate() (string, uuid.UUID) {
+	return entity.AuditKRS, e.SubmissionID
+}
+func (e KRSSubmitted) OrderingKey() string { return e.StudentID.String() }
+
+// KRSReviewed tells a student their advisor approved their KRS or sent it
+// back for revision, with the advisor's comment.
+type KRSReviewed struct {
+	SubmissionID uuid.UUID  `json:"submission_id"`
+	StudentID    uuid.UUID  `json:"student_id"`
+	AdvisorID    *uuid.UUID `json:"advisor_id,omitempty"`
+	AcademicYear string     `json:"academic_year"`
+	Semester     int        `json:"semester"`
+	Decision     string     `json:"decision"`
+	Comment      string     `json:"comment,omitempty"`
+}
+
+func (KRSReviewed) EventType() string { return entity.EventKRSReviewed }
+func (e KRSReviewed) Aggregate() (string, uuid.UUID) {
+	return entity.AuditKRS, e.SubmissionID
+}
+func (e KRSReviewed) OrderingKey() string { return e.StudentID.String() }
+
+// Outbox records domain events for the relay to publish. Like
+// Auditor.Record, Add must be called inside the unit of work that makes
+// the change, so the event exists exactly when the change does.
+type Outbox struct {
+	repo repository.OutboxRepository
+}
+
+func NewOutbox(repo repository.OutboxRepository) *Outbox {
+	return &Outbox{repo: repo}
+}
+
+func (o *Outbox) Add(ctx context.Context, event DomainEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", event.EventType(), err)
+	}
+	aggregateType, aggregateID := event.Aggregate()
+	return o.repo.Create(ctx, &entity.OutboxEvent{
+		EventID:       uuid.New(),
+		Type:          event.EventType(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		OrderingKey:   event.OrderingKey(),
+		Payload:       string(payload),
+		RequestID:     logger.RequestID(ctx),
+		OccurredAt:    time.Now().UTC(),
+	})
+}
+
+// OutboxRelay publishes the events in the outbox to a sink. Delivery is at
+// least once: an event published just before the relay stops is published
+// again by the next one. Events with the same ordering key are published
+// in the order they were recorded; one that fails holds back the later
+// ones until it gets through or is given up on, while other keys go on.
+type OutboxRelay struct {
+	repo        repository.OutboxRepository
+	sink        eventsink.Sink
+	batchSize   int
+	maxAttempts int
+	retention   time.Duration
+}
+
+// NewOutboxRelay returns a relay publishing up to batchSize events per
+// query. An event failing maxAttempts times is marked dead. Published
+// events are deleted once retention has passed, unless it is 0.
+func NewOutboxRelay(repo repository.OutboxRepository, sink eventsink.Sink, batchSize, maxAttempts int, retention time.Duration) *OutboxRelay {
+	return &OutboxRelay{repo: repo, sink: sink, batchSize: batchSize, maxAttempts: maxAttempts, retention: retention}
+}
+
+// Run relays every interval until ctx is done. Instances sharing a
+// database take turns through TryLock, so only one publishes at a time
+// and ordering holds across replicas.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log := logger.FromContext(ctx)
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		release, ok, err := r.repo.TryLock(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.ErrorContext(ctx, "outbox relay failed to take its lock", "error", err)
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := r.drain(ctx); err != nil && ctx.Err() == nil {
+			log.ErrorContext(ctx, "outbox relay failed", "error", err)
+		}
+		if r.retention > 0 && time.Since(lastCleanup) >= outboxCleanupInterval {
+			deleted, err := r.repo.DeletePublished(ctx, time.Now().Add(-r.retention))
+			if err != nil && ctx.Err() == nil {
+				log.ErrorContext(ctx, "outbox cleanup failed", "error", err)
+			} else {
+				lastCleanup = time.Now()
+				if deleted > 0 {
+					log.InfoContext(ctx, "outbox cleaned up", "events", deleted)
+				}
+			}
+		}
+		release()
+	}
+}
+
+// drain relays batches until one publishes nothing. Publishing an event
+// makes the next one on its key due, so a batch that got anything out may
+// be followed by more.
+func (r *OutboxRelay) drain(ctx context.Context) error {
+	for {
+		published, err := r.RelayOnce(ctx)
+		if err != nil || published == 0 {
+			return err
+		}
+	}
+}
+
+// RelayOnce publishes a batch of due events, at most one per ordering key,
+// and returns how many went out. A failed event is scheduled for a retry
+// with exponential backoff, holding back the later events of its key,
+// until it has failed maxAttempts times and is marked dead.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	events, err := r.repo.FindDue(ctx, time.Now(), r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, event := range events {
+		if err := r.sink.Publish(ctx, message(event)); err != nil {
+			if ctx.Err() != nil {
+				return published, ctx.Err()
+			}
+			if err := r.fail(ctx, event, err); err != nil {
+				return published, err
+			}
+			continue
+		}
+		if err := r.repo.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+			return published, err
+		}
+		metrics.RecordEventPublished(event.Type)
+		published++
+	}
+	return published, nil
+}
+
+// fail records a failed attempt to publish event.
+func (r *OutboxRelay) fail(ctx context.Context, event *entity.OutboxEvent, publishErr error) error {
+	log := logger.FromContext(ctx)
+	attempts := event.Attempts + 1
+	metrics.RecordEventPublishFailed(event.Type)
+	if attempts >= r.maxAttempts {
+		metrics.RecordEventDead(event.Type)
+		log.ErrorContext(ctx, "giving up on event",
+			"event_id", event.EventID, "type", event.Type, "attempts", attempts, "error", publishErr)
+		return r.repo.MarkDead(ctx, event.ID, publishErr.Error(), time.Now())
+	}
+	log.WarnContext(ctx, "failed to publish event",
+		"event_id", event.EventID, "type", event.Type, "attempts", attempts, "error", publishErr)
+	return r.repo.MarkFailed(ctx, event.ID, publishErr.Error(), time.Now().Add(retryBackoff(attempts)))
+}
+
+// retryBackoff is the wait before the attempt after the given number of
+// failed ones.
+func retryBackoff(attempts int) time.Duration {
+	return exponentialBackoff(outboxRetryBackoff, outboxMaxBackoff, attempts)
+}
+
+// exponentialBackoff waits initial after the first failed attempt and
+// twice as long after every further one, up to max.
+func exponentialBackoff(initial, max time.Duration, attempts int) time.Duration {
+	backoff := initial
+	for i := 1; i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+	return min(backoff, max)
+}
+
+func message(event *entity.OutboxEvent) eventsink.Message {
+	return eventsink.Message{
+		ID:            event.EventID,
+		Sequence:      event.ID,
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Key:           event.OrderingKey,
+		OccurredAt:    event.OccurredAt,
+		RequestID:     event.RequestID,
+		Payload:       json.RawMessage(event.Payload),
+	}
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/eventsink"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/memory"
+)
+
+// rejectingSink records the messages it accepts and rejects those in
+// poisoned.
+type rejectingSink struct {
+	poisoned  map[uuid.UUID]bool
+	published []uuid.UUID
+	rejected  int
+}
+
+func (s *rejectingSink) Publish(ctx context.Context, msg eventsink.Message) error {
+	if s.poisoned[msg.ID] {
+		s.rejected++
+		return errors.New("rejected by the sink")
+	}
+	s.published = append(s.published, msg.ID)
+	return nil
+}
+
+func (s *rejectingSink) Close() error { return nil }
+
+func TestOutboxRelayPoisonedKey(t *testing.T) {
+	// The poisoned key has more events than fit in a batch, ahead of the
+	// healthy keys, and its first event is rejected by the sink.
+	names := []string{"p1", "p2", "p3", "a1", "b1", "a2"}
+
+	tests := []struct {
+		name          string
+		maxAttempts   int
+		wantPublished []string
+	}{
+		{
+			name:          "healthy keys go on while the poisoned key waits for a retry",
+			maxAttempts:   3,
+			wantPublished: []string{"a1", "b1", "a2"},
+		},
+		{
+			name:          "the poisoned key goes on once its event is dead",
+			maxAttempts:   1,
+			wantPublished: []string{"a1", "p2", "b1", "p3", "a2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewOutboxRepository()
+			ids := make(map[uuid.UUID]string)
+			var poisoned uuid.UUID
+			for _, name := range names {
+				key := name[:1]
+				event := &entity.OutboxEvent{
+					EventID:       uuid.New(),
+					Type:          entity.EventStudentStatusChanged,
+					AggregateType: entity.AuditStudent,
+					AggregateID:   uuid.New(),
+					OrderingKey:   key,
+					Payload:       `{}`,
+					OccurredAt:    time.Now().UTC(),
+				}
+				if err := repo.Create(ctx, event); err != nil {
+					t.Fatal(err)
+				}
+				ids[event.EventID] = name
+				if name == "p1" {
+					poisoned = event.EventID
+				}
+			}
+
+			sink := &rejectingSink{poisoned: map[uuid.UUID]bool{poisoned: true}}
+			relay := NewOutboxRelay(repo, sink, 2, tt.maxAttempts, 0)
+			for range 2 {
+				if err := relay.drain(ctx); err != nil {
+					t.Fatalf("drain: %v", err)
+				}
+			}
+
+			published := make([]string, len(sink.published))
+			for i, id := range sink.published {
+				published[i] = ids[id]
+			}
+			if !slices.Equal(published, tt.wantPublished) {
+				t.Errorf("published %v, want %v", published, tt.wantPublished)
+			}
+			if sink.rejected != 1 {
+				t.Errorf("poisoned event attempted %d times before its retry is due, want 1", sink.rejected)
+			}
+		})
+	}
+}
+
+// newOutboxEvents stores an event for each name, keyed by its first
+// letter, and returns the names by event ID.
+func newOutboxEvents(t *testing.T, repo repository.OutboxRepository, names []string) (map[uuid.UUID]string, map[string]*entity.OutboxEvent) {
+	t.Helper()
+	ids := make(map[uuid.UUID]string)
+	events := make(map[string]*entity.OutboxEvent)
+	for _, name := range names {
+		event := &entity.OutboxEvent{
+			EventID:       uuid.New(),
+			Type:          entity.EventStudentStatusChanged,
+			AggregateType: entity.AuditStudent,
+			AggregateID:   uuid.New(),
+			OrderingKey:   name[:1],
+			Payload:       `{}`,
+			OccurredAt:    time.Now().UTC(),
+		}
+		if err := repo.Create(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+		ids[event.EventID] = name
+		events[name] = event
+	}
+	return ids, events
+}
+
+// byKey groups published names by their key.
+func byKey(ids map[uuid.UUID]string, published []uuid.UUID) map[string][]string {
+	keys := make(map[string][]string)
+	for _, id := range published {
+		name := ids[id]
+		keys[name[:1]] = append(keys[name[:1]], name)
+	}
+	return keys
+}
+
+func TestOutboxRelayKeyOrder(t *testing.T) {
+	names := []string{"a1", "b1", "a2", "c1", "b2", "a3", "b3"}
+	want := map[string][]string{"a": {"a1", "a2", "a3"}, "b": {"b1", "b2", "b3"}, "c": {"c1"}}
+
+	for _, batchSize := range []int{1, 2, 10} {
+		t.Run(fmt.Sprintf("batch of %d", batchSize), func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewOutboxRepository()
+			ids, _ := newOutboxEvents(t, repo, names)
+
+			sink := &rejectingSink{}
+			if err := NewOutboxRelay(repo, sink, batchSize, 3, 0).drain(ctx); err != nil {
+				t.Fatalf("drain: %v", err)
+			}
+			if got := byKey(ids, sink.published); !maps.EqualFunc(got, want, slices.Equal) {
+				t.Errorf("published %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestOutboxRelayRetryKeepsKeyOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewOutboxRepository()
+	ids, events := newOutboxEvents(t, repo, []string{"p1", "p2", "a1"})
+
+	sink := &rejectingSink{poisoned: map[uuid.UUID]bool{events["p1"].EventID: true}}
+	relay := NewOutboxRelay(repo, sink, 10, 5, 0)
+	if err := relay.drain(ctx); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+
+	// The sink recovers and the retry falls due.
+	delete(sink.poisoned, events["p1"].EventID)
+	if err := repo.MarkFailed(ctx, events["p1"].ID, "rejected by the sink", time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := relay.drain(ctx); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+
+	want := map[string][]string{"a": {"a1"}, "p": {"p1", "p2"}}
+	if got := byKey(ids, sink.published); !maps.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("published %v, want %v", got, want)
+	}
+	if due, err := repo.FindDue(ctx, time.Now(), 10); err != nil || len(due) != 0 {
+		t.Errorf("FindDue() = %d events, %v, want none left", len(due), err)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 5, want: 16 * time.Second},
+		{attempts: 9, want: 256 * time.Second},
+		{attempts: 10, want: 5 * time.Minute},
+		{attempts: 100, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := retryBackoff(tt.attempts); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// File: internal/usecase/search_usecase.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/textsearch"
+)
+
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 50
+	maxSearchQueryLen  = 100
+)
+
+// searchScopes lists the kinds of record each role may search. Students
+// can look up lecturers and courses but not other students.
+var searchScopes = map[string][]string{
+	"admin":   {repository.SearchStudent, repository.SearchLecturer, repository.SearchCourse},
+	"staff":   {repository.SearchStudent, repository.SearchLecturer, repository.SearchCourse},
+	"student": {repository.SearchLecturer, repository.SearchCourse},
+}
+
+// SearchResult is a hit with its title and subtitle HTML-escaped and the
+// matching words wrapped in <mark>.
+type SearchResult struct {
+	repository.SearchHit
+	Highlights map[string]string
+}
+
+type SearchUseCase interface {
+	// Search finds records matching q among kinds, or among every kind role
+	// may search when kinds is empty. Asking for a kind outside the role's
+	// scope is forbidden.
+	Search(ctx context.Context, q string, kinds []string, role string, limit int) ([]SearchResult, error)
+}
+
+type searchUseCaseImpl struct {
+	repo repository.SearchRepository
+}
+
+func NewSearchUseCase(repo repository.SearchRepository) SearchUseCase {
+	return &searchUseCaseImpl{repo: repo}
+}
+
+func (uc *searchUseCaseImpl) Search(ctx context.Context, q string, kinds []string, role string, limit int) ([]SearchResult, error) {
+	if utf8.RuneCountInString(q) > maxSearchQueryLen {
+		return nil, apperror.Validation(fmt.Sprintf("q must be at most %d characters", maxSearchQueryLen))
+	}
+	terms := textsearch.Terms(q)
+	if len(terms) == 0 {
+		return nil, apperror.Validation("q must contain a letter or digit")
+	}
+
+	scope := searchScopes[role]
+	if len(kinds) == 0 {
+		kinds = scope
+	}
+	for _, kind := range kinds {
+		if !slices.Contains(searchScopes["admin"], kind) {
+			return nil, apperror.Validation(fmt.Sprintf("unknown search type %q", kind))
+		}
+		if !slices.Contains(scope, kind) {
+			return nil, apperror.Forbidden(fmt.Sprintf("not allowed to search %ss", kind))
+		}
+	}
+	if len(kinds) == 0 {
+		return nil, apperror.Forbidden("not allowed to search")
+	}
+
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	hits, err := uc.repo.Search(ctx, terms, kinds, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = SearchResult{
+			SearchHit: hit,
+			Highlights: map[string]string{
+				"title":    textsearch.Highlight(hit.Title, terms),
+				"subtitle": textsearch.Highlight(hit.Subtitle, terms),
+			},
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,195 @@
+// File: internal/usecase/seat_feed.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/seatfeed"
+)
+
+const (
+	// seatRefreshDelay batches the changes to a course during the KRS rush,
+	// so its seats are counted once per delay rather than once per
+	// enrollment.
+	seatRefreshDelay = 250 * time.Millisecond
+
+	// seatResyncInterval is how often every watched course is counted
+	// anyway, to catch up on changes missed while the listener was down.
+	seatResyncInterval = 30 * time.Second
+
+	// seatListenBackoff is the wait before listening again after the
+	// connection failed.
+	seatListenBackoff = 5 * time.Second
+)
+
+// SeatFeed pushes the remaining seats of courses to the clients watching
+// them. Enrollment changes are announced on the bus after they commit;
+// every instance hears them, counts the seats of the courses its own
+// clients watch and publishes them to its hub.
+type SeatFeed struct {
+	bus         repository.SeatChangeBus
+	courses     repository.CourseRepository
+	enrollments repository.EnrollmentRepository
+	hub         *seatfeed.Hub
+	krs         []string
+
+	mu    sync.Mutex
+	dirty map[seatfeed.Key]struct{}
+}
+
+// NewSeatFeed counts the enrollments whose KRS is in one of the states
+// krs as taking a seat.
+func NewSeatFeed(
+	bus repository.SeatChangeBus,
+	courses repository.CourseRepository,
+	enrollments repository.EnrollmentRepository,
+	hub *seatfeed.Hub,
+	krs []string,
+) *SeatFeed {
+	return &SeatFeed{
+		bus:         bus,
+		courses:     courses,
+		enrollments: enrollments,
+		hub:         hub,
+		krs:         krs,
+		dirty:       make(map[seatfeed.Key]struct{}),
+	}
+}
+
+// Changed announces that an enrollment in the course and term was added,
+// dropped or removed, or that its KRS started or stopped holding a seat.
+// It must be called after the change has committed.
+// A failure only delays the update until the next resync, so it is logged
+// rather than returned.
+func (f *SeatFeed) Changed(ctx context.Context, change repository.SeatChange) {
+	if err := f.bus.Announce(ctx, change); err != nil {
+		logger.FromContext(ctx).WarnContext(ctx, "failed to announce seat change",
+			"course_id", change.CourseID, "academic_year", change.AcademicYear, "semester", change.Semester, "error", err)
+	}
+}
+
+// Seats counts the seats of a course in one term.
+func (f *SeatFeed) Seats(ctx context.Context, key seatfeed.Key) (seatfeed.Seats, error) {
+	course, err := f.courses.FindByID(ctx, key.CourseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return seatfeed.Seats{}, apperror.NotFound("course not found")
+		}
+		return seatfeed.Seats{}, err
+	}
+	taken, err := f.enrollments.CountActiveByCourse(ctx, key.CourseID, key.AcademicYear, key.Semester, f.krs)
+	if err != nil {
+		return seatfeed.Seats{}, err
+	}
+
+	seats := seatfeed.Seats{
+		CourseID:     key.CourseID,
+		AcademicYear: key.AcademicYear,
+		Semester:     key.Semester,
+		Capacity:     course.MaxStudents,
+		Enrolled:     taken,
+		UpdatedAt:    time.Now().UTC(),
+	}
+	if course.MaxStudents > 0 {
+		remaining := max(int64(course.MaxStudents)-taken, 0)
+		seats.Remaining = &remaining
+	}
+	return seats, nil
+}
+
+// Subscribe starts watching a course term for client and returns its
+// current seats. The caller must close the subscription.
+func (f *SeatFeed) Subscribe(ctx context.Context, key seatfeed.Key, client string) (*seatfeed.Subscription, seatfeed.Seats, error) {
+	// Subscribe before counting, so a change committed in between is
+	// published rather than lost.
+	sub, err := f.hub.Subscribe(key, client)
+	if errors.Is(err, seatfeed.ErrClientLimit) {
+		return nil, seatfeed.Seats{}, apperror.New(apperror.KindRateLimited, "too many seat streams are open from this address")
+	}
+	if err != nil {
+		return nil, seatfeed.Seats{}, apperror.Unavailable("seats cannot be watched right now, retry later", err)
+	}
+	seats, err := f.Seats(ctx, key)
+	if err != nil {
+		sub.Close()
+		return nil, seatfeed.Seats{}, err
+	}
+	return sub, seats, nil
+}
+
+// Run listens for seat changes and publishes the new counts until ctx is
+// done.
+func (f *SeatFeed) Run(ctx context.Context) {
+	go f.listen(ctx)
+
+	refresh := time.NewTicker(seatRefreshDelay)
+	defer refresh.Stop()
+	resync := time.NewTicker(seatResyncInterval)
+	defer resync.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-resync.C:
+			f.mu.Lock()
+			for _, key := range f.hub.Keys() {
+				f.dirty[key] = struct{}{}
+			}
+			f.mu.Unlock()
+		case <-refresh.C:
+			f.refresh(ctx)
+		}
+	}
+}
+
+func (f *SeatFeed) listen(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	for {
+		err := f.bus.Listen(ctx, func(change repository.SeatChange) {
+			f.mu.Lock()
+			f.dirty[seatfeed.Key(change)] = struct{}{}
+			f.mu.Unlock()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.ErrorContext(ctx, "seat change listener failed", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(seatListenBackoff):
+		}
+	}
+}
+
+// refresh counts and publishes the seats of the changed courses somebody
+// on this instance is watching.
+func (f *SeatFeed) refresh(ctx context.Context) {
+	f.mu.Lock()
+	dirty := f.dirty
+	f.dirty = make(map[seatfeed.Key]struct{})
+	f.mu.Unlock()
+
+	log := logger.FromContext(ctx)
+	for key := range dirty {
+		if !f.hub.Watched(key) {
+			continue
+		}
+		seats, err := f.Seats(ctx, key)
+		if err != nil {
+			if ctx.Err() == nil && apperror.KindOf(err) != apperror.KindNotFound {
+				log.WarnContext(ctx, "failed to count seats", "course_id", key.CourseID, "error", err)
+			}
+			continue
+		}
+		f.hub.Publish(seats)
+	}
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/seatfeed"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/memory"
+)
+
+func TestSeatFeedSubscribeLimits(t *testing.T) {
+	ctx := context.Background()
+	a := newAcademic(t, entity.KRSApproved)
+	key := seatfeed.Key{CourseID: a.course("IF101", 2).ID, AcademicYear: testYear, Semester: testSemester}
+	feed := NewSeatFeed(memory.NewSeatChangeBus(), a.courses, a.enrollments, seatfeed.NewHub(1, 2, 1), entity.KRSSeatStatuses(entity.KRSApproved))
+
+	tests := []struct {
+		name     string
+		client   string
+		wantKind apperror.Kind
+		wantErr  bool
+	}{
+		{name: "first stream", client: "192.0.2.1"},
+		{name: "same client again", client: "192.0.2.1", wantErr: true, wantKind: apperror.KindRateLimited},
+		{name: "another client", client: "192.0.2.2"},
+		{name: "instance full", client: "192.0.2.3", wantErr: true, wantKind: apperror.KindUnavailable},
+	}
+	// Streams stay open until the whole test ends, so they count against
+	// the later cases.
+	var open []*seatfeed.Subscription
+	defer func() {
+		for _, sub := range open {
+			sub.Close()
+		}
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, seats, err := feed.Subscribe(ctx, key, tt.client)
+			if tt.wantErr {
+				if err == nil || apperror.KindOf(err) != tt.wantKind {
+					t.Errorf("Subscribe() = %v, want %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Subscribe() = %v", err)
+			}
+			open = append(open, sub)
+			if seats.Capacity != 2 {
+				t.Errorf("seats = %+v, want the course's", seats)
+			}
+		})
+	}
+}
@@ -9,40 +9,66 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
 	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
-	"gorm.io/gorm"
 )
 
 type StudentUseCase interface {
 	Create(ctx context.Context, student *entity.Student) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Student, error)
-	GetAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Student, int64, error)
-	Update(ctx context.Context, id uuid.UUID, student *entity.Student) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Student, int64, error)
+	GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Student], error)
+	Count(ctx context.Context, spec query.Spec) (int64, error)
+	Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Student, error)
+	Delete(ctx context.Context, id uuid.UUID, version int) error
 }
 
 type studentUseCaseImpl struct {
-	repo repository.StudentRepository
+	repo      repository.StudentRepository
+	txManager repository.TxManager
+	auditor   *Auditor
+	outbox    *Outbox
 }
 
-func NewStudentUseCase(repo repository.StudentRepository) StudentUseCase {
-	return &studentUseCaseImpl{repo: repo}
+func NewStudentUseCase(repo repository.StudentRepository, txManager repository.TxManager, auditor *Auditor, outbox *Outbox) StudentUseCase {
+	return &studentUseCaseImpl{repo: repo, txManager: txManager, auditor: auditor, outbox: outbox}
 }
 
 func (uc *studentUseCaseImpl) Create(ctx context.Context, student *entity.Student) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.create(ctx, student); err != nil {
+			return err
+		}
+		if err := uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditStudent, student.ID, nil, student); err != nil {
+			return err
+		}
+		return uc.outbox.Add(ctx, StudentCreated{
+			StudentID:      student.ID,
+			NIM:            student.NIM,
+			Name:           student.Name,
+			Email:          student.Email,
+			Major:          student.Major,
+			EnrollmentYear: student.EnrollmentYear,
+			Status:         student.Status,
+		})
+	})
+}
+
+func (uc *studentUseCaseImpl) create(ctx context.Context, student *entity.Student) error {
 	// Check if NIM already exists
 	existing, err := uc.repo.FindByNIM(ctx, student.NIM)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
 		return fmt.Errorf("failed to check existing NIM: %w", err)
 	}
 	if existing != nil {
-		return errors.New("NIM already exists")
+		return apperror.Conflict("nim", "NIM already exists")
 	}
 
 	// Validate required fields
 	if student.NIM == "" || student.Name == "" || student.Email == "" || student.Major == "" {
-		return errors.New("required fields are missing")
+		return apperror.Validation("required fields are missing")
 	}
 
 	return uc.repo.Create(ctx, student)
@@ -51,48 +77,91 @@ func (uc *studentUseCaseImpl) Create(ctx context.Context, student *entity.Studen
 func (uc *studentUseCaseImpl) GetByID(ctx context.Context, id uuid.UUID) (*entity.Student, error) {
 	student, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("student not found")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("student not found")
 		}
 		return nil, err
 	}
 	return student, nil
 }
 
-func (uc *studentUseCaseImpl) GetAll(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*entity.Student, int64, error) {
-	if page < 1 {
-		page = 1
+func (uc *studentUseCaseImpl) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) ([]*entity.Student, int64, error) {
+	return uc.repo.FindAll(ctx, page, pageSize, spec)
+}
+
+func (uc *studentUseCaseImpl) GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (repository.Window[entity.Student], error) {
+	return uc.repo.FindPage(ctx, page, spec)
+}
+
+func (uc *studentUseCaseImpl) Count(ctx context.Context, spec query.Spec) (int64, error) {
+	return uc.repo.Count(ctx, spec)
+}
+
+func (uc *studentUseCaseImpl) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Student, error) {
+	var updated *entity.Student
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = uc.update(ctx, id, version, changes)
+		return err
+	})
+	return updated, err
+}
+
+func (uc *studentUseCaseImpl) update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.Student, error) {
+	// Check if student exists and is still at the version the caller read
+	existing, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+	version, err = checkVersion(existing.Version, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return existing, nil
 	}
-	return uc.repo.FindAll(ctx, page, pageSize, filters)
-}
 
-func (uc *studentUseCaseImpl) Update(ctx context.Context, id uuid.UUID, student *entity.Student) error {
-	// Check if student exists
-	existing, err := uc.repo.FindByID(ctx, id)
+	// Update only the provided fields, then return the persisted record
+	if err := uc.repo.Update(ctx, id, version, changes); err != nil {
+		return nil, err
+	}
+	updated, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("student not found")
-		}
-		return err
+		return nil, err
+	}
+	if err := uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditStudent, id, existing, updated); err != nil {
+		return nil, err
 	}
+	if updated.Status != existing.Status {
+		err = uc.outbox.Add(ctx, StudentStatusChanged{
+			StudentID: id,
+			NIM:       updated.NIM,
+			OldStatus: existing.Status,
+			NewStatus: updated.Status,
+		})
+	}
+	return updated, err
+}
 
-	// Update fields
-	student.ID = existing.ID
-	return uc.repo.Update(ctx, student)
+func (uc *studentUseCaseImpl) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.delete(ctx, id, version)
+	})
 }
 
-func (uc *studentUseCaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
+func (uc *studentUseCaseImpl) delete(ctx context.Context, id uuid.UUID, version int) error {
 	// Check if student exists
-	_, err := uc.repo.FindByID(ctx, id)
+	existing, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	version, err = checkVersion(existing.Version, version)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("student not found")
-		}
 		return err
 	}
 
-	return uc.repo.Delete(ctx, id)
+	if err := uc.repo.Delete(ctx, id, version); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, entity.AuditDelete, entity.AuditStudent, id, existing, nil)
 }
@@ -0,0 +1,563 @@
+// File: internal/usecase/tracing.go
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/query"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/export"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The traced* decorators wrap each use case interface in a span named
+// "<UseCase>.<Method>". Expected domain errors (not found, conflict,
+// validation, ...) are recorded on the span but only internal and
+// unavailable errors mark it as failed.
+
+var tracer = otel.Tracer("github.com/haninhammoud01/go-academic-service/internal/usecase")
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		switch apperror.KindOf(err) {
+		case apperror.KindInternal, apperror.KindUnavailable:
+			span.SetStatus(codes.Error, err.Error())
+		default:
+			span.SetAttributes(attribute.String("app.error_kind", apperror.KindOf(err).String()))
+		}
+	}
+	span.End()
+}
+
+func idAttr(id uuid.UUID) attribute.KeyValue {
+	return attribute.String("app.id", id.String())
+}
+
+func pageAttrs(page, pageSize int) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.Int("app.page", page), attribute.Int("app.page_size", pageSize)}
+}
+
+func keysetAttrs(page repository.KeysetPage) []attribute.KeyValue {
+	direction := "first"
+	switch {
+	case page.Before != nil:
+		direction = "before"
+	case page.After != nil:
+		direction = "after"
+	}
+	return []attribute.KeyValue{attribute.String("app.cursor", direction), attribute.Int("app.page_size", page.Limit)}
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedAuthUseCase struct{ next AuthUseCase }
+
+func NewTracedAuthUseCase(next AuthUseCase) AuthUseCase {
+	return &tracedAuthUseCase{next: next}
+}
+
+func (t *tracedAuthUseCase) Register(ctx context.Context, user *entity.User, plainPassword string) (err error) {
+	ctx, span := startSpan(ctx, "AuthUseCase.Register")
+	defer func() { endSpan(span, err) }()
+	return t.next.Register(ctx, user, plainPassword)
+}
+
+func (t *tracedAuthUseCase) Login(ctx context.Context, email, plainPassword string) (token string, user *entity.User, err error) {
+	ctx, span := startSpan(ctx, "AuthUseCase.Login")
+	defer func() { endSpan(span, err) }()
+	return t.next.Login(ctx, email, plainPassword)
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedStudentUseCase struct{ next StudentUseCase }
+
+func NewTracedStudentUseCase(next StudentUseCase) StudentUseCase {
+	return &tracedStudentUseCase{next: next}
+}
+
+func (t *tracedStudentUseCase) Create(ctx context.Context, student *entity.Student) (err error) {
+	ctx, span := startSpan(ctx, "StudentUseCase.Create")
+	defer func() { endSpan(span, err) }()
+	return t.next.Create(ctx, student)
+}
+
+func (t *tracedStudentUseCase) GetByID(ctx context.Context, id uuid.UUID) (student *entity.Student, err error) {
+	ctx, span := startSpan(ctx, "StudentUseCase.GetByID", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetByID(ctx, id)
+}
+
+func (t *tracedStudentUseCase) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) (students []*entity.Student, total int64, err error) {
+	ctx, span := startSpan(ctx, "StudentUseCase.GetAll", pageAttrs(page, pageSize)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.GetAll(ctx, page, pageSize, spec)
+}
+
+func (t *tracedStudentUseCase) GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (window repository.Window[entity.Student], err error) {
+	ctx, span := startSpan(ctx, "StudentUseCase.GetPage", keysetAttrs(page)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.GetPage(ctx, page, spec)
+}
+
+func (t *tracedStudentUseCase) Count(ctx context.Context, spec query.Spec) (total int64, err error) {
+	ctx, span := startSpan(ctx, "StudentUseCase.Count")
+	defer func() { endSpan(span, err) }()
+	return t.next.Count(ctx, spec)
+}
+
+func (t *tracedStudentUseCase) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (student *entity.Student, err error) {
+	ctx, span := startSpan(ctx, "StudentUseCase.Update", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Update(ctx, id, version, changes)
+}
+
+func (t *tracedStudentUseCase) Delete(ctx context.Context, id uuid.UUID, version int) (err error) {
+	ctx, span := startSpan(ctx, "StudentUseCase.Delete", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Delete(ctx, id, version)
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedLecturerUseCase struct{ next LecturerUseCase }
+
+func NewTracedLecturerUseCase(next LecturerUseCase) LecturerUseCase {
+	return &tracedLecturerUseCase{next: next}
+}
+
+func (t *tracedLecturerUseCase) Create(ctx context.Context, lecturer *entity.Lecturer) (err error) {
+	ctx, span := startSpan(ctx, "LecturerUseCase.Create")
+	defer func() { endSpan(span, err) }()
+	return t.next.Create(ctx, lecturer)
+}
+
+func (t *tracedLecturerUseCase) GetByID(ctx context.Context, id uuid.UUID) (lecturer *entity.Lecturer, err error) {
+	ctx, span := startSpan(ctx, "LecturerUseCase.GetByID", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetByID(ctx, id)
+}
+
+func (t *tracedLecturerUseCase) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) (lecturers []*entity.Lecturer, total int64, err error) {
+	ctx, span := startSpan(ctx, "LecturerUseCase.GetAll", pageAttrs(page, pageSize)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.GetAll(ctx, page, pageSize, spec)
+}
+
+func (t *tracedLecturerUseCase) GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (window repository.Window[entity.Lecturer], err error) {
+	ctx, span := startSpan(ctx, "LecturerUseCase.GetPage", keysetAttrs(page)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.GetPage(ctx, page, spec)
+}
+
+func (t *tracedLecturerUseCase) Count(ctx context.Context, spec query.Spec) (total int64, err error) {
+	ctx, span := startSpan(ctx, "LecturerUseCase.Count")
+	defer func() { endSpan(span, err) }()
+	return t.next.Count(ctx, spec)
+}
+
+func (t *tracedLecturerUseCase) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (lecturer *entity.Lecturer, err error) {
+	ctx, span := startSpan(ctx, "LecturerUseCase.Update", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Update(ctx, id, version, changes)
+}
+
+func (t *tracedLecturerUseCase) Delete(ctx context.Context, id uuid.UUID, version int) (err error) {
+	ctx, span := startSpan(ctx, "LecturerUseCase.Delete", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Delete(ctx, id, version)
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedCourseUseCase struct{ next CourseUseCase }
+
+func NewTracedCourseUseCase(next CourseUseCase) CourseUseCase {
+	return &tracedCourseUseCase{next: next}
+}
+
+func (t *tracedCourseUseCase) Create(ctx context.Context, course *entity.Course) (err error) {
+	ctx, span := startSpan(ctx, "CourseUseCase.Create")
+	defer func() { endSpan(span, err) }()
+	return t.next.Create(ctx, course)
+}
+
+func (t *tracedCourseUseCase) GetByID(ctx context.Context, id uuid.UUID) (course *entity.Course, err error) {
+	ctx, span := startSpan(ctx, "CourseUseCase.GetByID", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetByID(ctx, id)
+}
+
+func (t *tracedCourseUseCase) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) (courses []*entity.Course, total int64, err error) {
+	ctx, span := startSpan(ctx, "CourseUseCase.GetAll", pageAttrs(page, pageSize)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.GetAll(ctx, page, pageSize, spec)
+}
+
+func (t *tracedCourseUseCase) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (course *entity.Course, err error) {
+	ctx, span := startSpan(ctx, "CourseUseCase.Update", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Update(ctx, id, version, changes)
+}
+
+func (t *tracedCourseUseCase) Delete(ctx context.Context, id uuid.UUID, version int) (err error) {
+	ctx, span := startSpan(ctx, "CourseUseCase.Delete", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Delete(ctx, id, version)
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedEnrollmentUseCase struct{ next EnrollmentUseCase }
+
+func NewTracedEnrollmentUseCase(next EnrollmentUseCase) EnrollmentUseCase {
+	return &tracedEnrollmentUseCase{next: next}
+}
+
+func (t *tracedEnrollmentUseCase) Enroll(ctx context.Context, enrollment *entity.Enrollment) (err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.Enroll",
+		attribute.String("app.student_id", enrollment.StudentID.String()),
+		attribute.String("app.course_id", enrollment.CourseID.String()),
+	)
+	defer func() { endSpan(span, err) }()
+	return t.next.Enroll(ctx, enrollment)
+}
+
+func (t *tracedEnrollmentUseCase) GetByID(ctx context.Context, id uuid.UUID) (enrollment *entity.Enrollment, err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.GetByID", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetByID(ctx, id)
+}
+
+func (t *tracedEnrollmentUseCase) GetAll(ctx context.Context, page, pageSize int, spec query.Spec) (enrollments []*entity.Enrollment, total int64, err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.GetAll", pageAttrs(page, pageSize)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.GetAll(ctx, page, pageSize, spec)
+}
+
+func (t *tracedEnrollmentUseCase) GetPage(ctx context.Context, page repository.KeysetPage, spec query.Spec) (window repository.Window[entity.Enrollment], err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.GetPage", keysetAttrs(page)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.GetPage(ctx, page, spec)
+}
+
+func (t *tracedEnrollmentUseCase) Count(ctx context.Context, spec query.Spec) (total int64, err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.Count")
+	defer func() { endSpan(span, err) }()
+	return t.next.Count(ctx, spec)
+}
+
+func (t *tracedEnrollmentUseCase) Update(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (enrollment *entity.Enrollment, err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.Update", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Update(ctx, id, version, changes)
+}
+
+func (t *tracedEnrollmentUseCase) Delete(ctx context.Context, id uuid.UUID, version int) (err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.Delete", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Delete(ctx, id, version)
+}
+
+func (t *tracedEnrollmentUseCase) PromoteWaitlist(ctx context.Context, change repository.SeatChange) (promoted []*entity.Enrollment, err error) {
+	ctx, span := startSpan(ctx, "EnrollmentUseCase.PromoteWaitlist", attribute.String("app.course_id", change.CourseID.String()))
+	defer func() { endSpan(span, err) }()
+	return t.next.PromoteWaitlist(ctx, change)
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedExportUseCase struct{ next ExportUseCase }
+
+func NewTracedExportUseCase(next ExportUseCase) ExportUseCase {
+	return &tracedExportUseCase{next: next}
+}
+
+func (t *tracedExportUseCase) Export(ctx context.Context, resource string, format export.Format, spec query.Spec, w io.Writer) (rows int64, err error) {
+	ctx, span := startSpan(ctx, "ExportUseCase.Export",
+		attribute.String("app.export.resource", resource),
+		attribute.String("app.export.format", string(format)),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int64("app.export.rows", rows))
+		endSpan(span, err)
+	}()
+	return t.next.Export(ctx, resource, format, spec, w)
+}
+
+func (t *tracedExportUseCase) StartJob(ctx context.Context, resource string, format export.Format, spec query.Spec, requestedBy uuid.UUID) (job *ExportJob, err error) {
+	ctx, span := startSpan(ctx, "ExportUseCase.StartJob",
+		attribute.String("app.export.resource", resource),
+		attribute.String("app.export.format", string(format)),
+	)
+	defer func() { endSpan(span, err) }()
+	return t.next.StartJob(ctx, resource, format, spec, requestedBy)
+}
+
+func (t *tracedExportUseCase) GetJob(id uuid.UUID) (*ExportJob, error) {
+	return t.next.GetJob(id)
+}
+
+func (t *tracedExportUseCase) OpenJobFile(id uuid.UUID) (*ExportJob, *os.File, error) {
+	return t.next.OpenJobFile(id)
+}
+
+func (t *tracedExportUseCase) Shutdown(ctx context.Context) error {
+	return t.next.Shutdown(ctx)
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedSearchUseCase struct{ next SearchUseCase }
+
+func NewTracedSearchUseCase(next SearchUseCase) SearchUseCase {
+	return &tracedSearchUseCase{next: next}
+}
+
+// Search leaves the query text off the span: it is often a person's name.
+func (t *tracedSearchUseCase) Search(ctx context.Context, q string, kinds []string, role string, limit int) (results []SearchResult, err error) {
+	ctx, span := startSpan(ctx, "SearchUseCase.Search",
+		attribute.StringSlice("app.search.types", kinds),
+		attribute.Int("app.search.limit", limit),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("app.search.results", len(results)))
+		endSpan(span, err)
+	}()
+	return t.next.Search(ctx, q, kinds, role, limit)
+}
+
+// ---------------------------------------------------------------------------
+
+type tracedTrashUseCase struct{ next TrashUseCase }
+
+func NewTracedTrashUseCase(next TrashUseCase) TrashUseCase {
+	return &tracedTrashUseCase{next: next}
+}
+
+func (t *tracedTrashUseCase) List(ctx context.Context, resource string, page, pageSize int) (_ []DeletedRecord, _ int64, err error) {
+	ctx, span := startSpan(ctx, "TrashUseCase.List", append(pageAttrs(page, pageSize), attribute.String("app.trash.resource", resource))...)
+	defer func() { endSpan(span, err) }()
+	return t.next.List(ctx, resource, page, pageSize)
+}
+
+func (t *tracedTrashUseCase) Restore(ctx context.Context, resource string, id uuid.UUID) (_ interface{}, err error) {
+	ctx, span := startSpan(ctx, "TrashUseCase.Restore", attribute.String("app.trash.resource", resource), idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Restore(ctx, resource, id)
+}
+
+func (t *tracedTrashUseCase) Purge(ctx context.Context, resource string, id uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "TrashUseCase.Purge", attribute.String("app.trash.resource", resource), idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Purge(ctx, resource, id)
+}
+
+func (t *tracedTrashUseCase) PurgeExpired(ctx context.Context) (purged map[string]int64, err error) {
+	ctx, span := startSpan(ctx, "TrashUseCase.PurgeExpired")
+	defer func() {
+		for resource, n := range purged {
+			span.SetAttributes(attribute.Int64("app.purged."+resource, n))
+		}
+		endSpan(span, err)
+	}()
+	return t.next.PurgeExpired(ctx)
+}
+
+type tracedAuditUseCase struct{ next AuditUseCase }
+
+func NewTracedAuditUseCase(next AuditUseCase) AuditUseCase {
+	return &tracedAuditUseCase{next: next}
+}
+
+func (t *tracedAuditUseCase) List(ctx context.Context, filter repository.AuditFilter, page, pageSize int) (_ []*entity.AuditLog, _ int64, err error) {
+	ctx, span := startSpan(ctx, "AuditUseCase.List", append(pageAttrs(page, pageSize), attribute.String("app.audit.entity_type", filter.EntityType))...)
+	defer func() { endSpan(span, err) }()
+	return t.next.List(ctx, filter, page, pageSize)
+}
+
+func (t *tracedAuditUseCase) Verify(ctx context.Context) (result *AuditVerification, err error) {
+	ctx, span := startSpan(ctx, "AuditUseCase.Verify")
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int64("app.audit.entries", result.Entries), attribute.Bool("app.audit.valid", result.Valid))
+		}
+		endSpan(span, err)
+	}()
+	return t.next.Verify(ctx)
+}
+
+type tracedWebhookUseCase struct{ next WebhookUseCase }
+
+func NewTracedWebhookUseCase(next WebhookUseCase) WebhookUseCase {
+	return &tracedWebhookUseCase{next: next}
+}
+
+func (t *tracedWebhookUseCase) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.CreateSubscription")
+	defer func() { endSpan(span, err) }()
+	return t.next.CreateSubscription(ctx, subscription)
+}
+
+func (t *tracedWebhookUseCase) GetSubscription(ctx context.Context, id uuid.UUID) (_ *entity.WebhookSubscription, err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.GetSubscription", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetSubscription(ctx, id)
+}
+
+func (t *tracedWebhookUseCase) ListSubscriptions(ctx context.Context, page, pageSize int) (_ []*entity.WebhookSubscription, _ int64, err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.ListSubscriptions", pageAttrs(page, pageSize)...)
+	defer func() { endSpan(span, err) }()
+	return t.next.ListSubscriptions(ctx, page, pageSize)
+}
+
+func (t *tracedWebhookUseCase) UpdateSubscription(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (_ *entity.WebhookSubscription, err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.UpdateSubscription", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdateSubscription(ctx, id, version, changes)
+}
+
+func (t *tracedWebhookUseCase) DeleteSubscription(ctx context.Context, id uuid.UUID, version int) (err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.DeleteSubscription", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.DeleteSubscription(ctx, id, version)
+}
+
+func (t *tracedWebhookUseCase) GetDelivery(ctx context.Context, id uuid.UUID) (_ *entity.WebhookDelivery, err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.GetDelivery", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetDelivery(ctx, id)
+}
+
+func (t *tracedWebhookUseCase) ListDeliveries(ctx context.Context, filter repository.DeliveryFilter, page, pageSize int) (_ []*entity.WebhookDelivery, _ int64, err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.ListDeliveries", append(pageAttrs(page, pageSize), attribute.String("app.webhook.status", filter.Status))...)
+	defer func() { endSpan(span, err) }()
+	return t.next.ListDeliveries(ctx, filter, page, pageSize)
+}
+
+func (t *tracedWebhookUseCase) Redeliver(ctx context.Context, id uuid.UUID) (_ *entity.WebhookDelivery, err error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.Redeliver", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Redeliver(ctx, id)
+}
+
+type tracedWaitlistUseCase struct{ next WaitlistUseCase }
+
+func NewTracedWaitlistUseCase(next WaitlistUseCase) WaitlistUseCase {
+	return &tracedWaitlistUseCase{next: next}
+}
+
+func (t *tracedWaitlistUseCase) Join(ctx context.Context, entry *entity.WaitlistEntry) (err error) {
+	ctx, span := startSpan(ctx, "WaitlistUseCase.Join",
+		attribute.String("app.student_id", entry.StudentID.String()),
+		attribute.String("app.course_id", entry.CourseID.String()),
+	)
+	defer func() { endSpan(span, err) }()
+	return t.next.Join(ctx, entry)
+}
+
+func (t *tracedWaitlistUseCase) Get(ctx context.Context, id uuid.UUID) (_ *entity.WaitlistEntry, err error) {
+	ctx, span := startSpan(ctx, "WaitlistUseCase.Get", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Get(ctx, id)
+}
+
+func (t *tracedWaitlistUseCase) List(ctx context.Context, courseID uuid.UUID, academicYear string, semester int) (_ []*entity.WaitlistEntry, err error) {
+	ctx, span := startSpan(ctx, "WaitlistUseCase.List", attribute.String("app.course_id", courseID.String()))
+	defer func() { endSpan(span, err) }()
+	return t.next.List(ctx, courseID, academicYear, semester)
+}
+
+func (t *tracedWaitlistUseCase) Move(ctx context.Context, id uuid.UUID, version int, position int) (_ *entity.WaitlistEntry, err error) {
+	ctx, span := startSpan(ctx, "WaitlistUseCase.Move", idAttr(id), attribute.Int("app.waitlist.position", position))
+	defer func() { endSpan(span, err) }()
+	return t.next.Move(ctx, id, version, position)
+}
+
+func (t *tracedWaitlistUseCase) Remove(ctx context.Context, id uuid.UUID, version int) (err error) {
+	ctx, span := startSpan(ctx, "WaitlistUseCase.Remove", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Remove(ctx, id, version)
+}
+
+type tracedAdvisorUseCase struct{ next AdvisorUseCase }
+
+func NewTracedAdvisorUseCase(next AdvisorUseCase) AdvisorUseCase {
+	return &tracedAdvisorUseCase{next: next}
+}
+
+func (t *tracedAdvisorUseCase) Assign(ctx context.Context, studentID, lecturerID uuid.UUID) (_ *entity.AdvisorAssignment, err error) {
+	ctx, span := startSpan(ctx, "AdvisorUseCase.Assign",
+		attribute.String("app.student_id", studentID.String()),
+		attribute.String("app.lecturer_id", lecturerID.String()),
+	)
+	defer func() { endSpan(span, err) }()
+	return t.next.Assign(ctx, studentID, lecturerID)
+}
+
+func (t *tracedAdvisorUseCase) Current(ctx context.Context, studentID uuid.UUID) (_ *entity.AdvisorAssignment, err error) {
+	ctx, span := startSpan(ctx, "AdvisorUseCase.Current", attribute.String("app.student_id", studentID.String()))
+	defer func() { endSpan(span, err) }()
+	return t.next.Current(ctx, studentID)
+}
+
+func (t *tracedAdvisorUseCase) History(ctx context.Context, studentID uuid.UUID) (_ []*entity.AdvisorAssignment, err error) {
+	ctx, span := startSpan(ctx, "AdvisorUseCase.History", attribute.String("app.student_id", studentID.String()))
+	defer func() { endSpan(span, err) }()
+	return t.next.History(ctx, studentID)
+}
+
+func (t *tracedAdvisorUseCase) Unassign(ctx context.Context, studentID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "AdvisorUseCase.Unassign", attribute.String("app.student_id", studentID.String()))
+	defer func() { endSpan(span, err) }()
+	return t.next.Unassign(ctx, studentID)
+}
+
+type tracedKRSUseCase struct{ next KRSUseCase }
+
+func NewTracedKRSUseCase(next KRSUseCase) KRSUseCase {
+	return &tracedKRSUseCase{next: next}
+}
+
+func (t *tracedKRSUseCase) Get(ctx context.Context, id uuid.UUID) (_ *entity.KRSSubmission, err error) {
+	ctx, span := startSpan(ctx, "KRSUseCase.Get", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Get(ctx, id)
+}
+
+func (t *tracedKRSUseCase) ListByStudent(ctx context.Context, studentID uuid.UUID) (_ []*entity.KRSSubmission, err error) {
+	ctx, span := startSpan(ctx, "KRSUseCase.ListByStudent", attribute.String("app.student_id", studentID.String()))
+	defer func() { endSpan(span, err) }()
+	return t.next.ListByStudent(ctx, studentID)
+}
+
+func (t *tracedKRSUseCase) Submit(ctx context.Context, id uuid.UUID, version int) (_ *entity.KRSSubmission, err error) {
+	ctx, span := startSpan(ctx, "KRSUseCase.Submit", idAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.Submit(ctx, id, version)
+}
+
+func (t *tracedKRSUseCase) Review(ctx context.Context, id uuid.UUID, version int, decision, comment string) (_ *entity.KRSSubmission, err error) {
+	ctx, span := startSpan(ctx, "KRSUseCase.Review", idAttr(id), attribute.String("app.krs.decision", decision))
+	defer func() { endSpan(span, err) }()
+	return t.next.Review(ctx, id, version, decision, comment)
+}
+
+func (t *tracedKRSUseCase) Inbox(ctx context.Context, lecturerID uuid.UUID, page, pageSize int) (_ []*entity.KRSSubmission, _ int64, err error) {
+	ctx, span := startSpan(ctx, "KRSUseCase.Inbox", append(pageAttrs(page, pageSize), attribute.String("app.lecturer_id", lecturerID.String()))...)
+	defer func() { endSpan(span, err) }()
+	return t.next.Inbox(ctx, lecturerID, page, pageSize)
+}
@@ -0,0 +1,268 @@
+// File: internal/usecase/trash_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+)
+
+const (
+	TrashStudents    = "students"
+	TrashLecturers   = "lecturers"
+	TrashCourses     = "courses"
+	TrashEnrollments = "enrollments"
+)
+
+// trashResources is the order PurgeExpired works in: enrollments go
+// before the students and courses they reference.
+var trashResources = []string{TrashEnrollments, TrashCourses, TrashStudents, TrashLecturers}
+
+// DeletedRecord is a soft-deleted record: an *entity.Student, Lecturer,
+// Course or Enrollment, with when it was deleted and from when it may be
+// purged.
+type DeletedRecord struct {
+	Record     interface{}
+	DeletedAt  time.Time
+	PurgeAfter time.Time
+}
+
+type TrashUseCase interface {
+	List(ctx context.Context, resource string, page, pageSize int) ([]DeletedRecord, int64, error)
+	// Restore brings a deleted record back and returns it. An enrollment
+	// can only come back while its student and course are live.
+	Restore(ctx context.Context, resource string, id uuid.UUID) (interface{}, error)
+	// Purge removes a deleted record for good once the retention period
+	// has passed. Purging a student or course also removes its
+	// enrollments; only the purged record itself is audited.
+	Purge(ctx context.Context, resource string, id uuid.UUID) error
+	// PurgeExpired purges every record deleted longer than the retention
+	// period ago and returns the counts per resource. These purges are not
+	// audited one by one; the deletes before them were.
+	PurgeExpired(ctx context.Context) (map[string]int64, error)
+}
+
+type trashUseCaseImpl struct {
+	bins      map[string]trashBin
+	txManager repository.TxManager
+	retention time.Duration
+}
+
+func NewTrashUseCase(
+	studentRepo repository.StudentRepository,
+	lecturerRepo repository.LecturerRepository,
+	courseRepo repository.CourseRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	txManager repository.TxManager,
+	auditor *Auditor,
+	retention time.Duration,
+) TrashUseCase {
+	return &trashUseCaseImpl{
+		bins: map[string]trashBin{
+			TrashStudents: &bin[entity.Student]{
+				noun: entity.AuditStudent, auditor: auditor, trash: studentRepo, find: studentRepo.FindByID,
+				deletedAt: func(s *entity.Student) time.Time { return s.DeletedAt.Time },
+			},
+			TrashLecturers: &bin[entity.Lecturer]{
+				noun: entity.AuditLecturer, auditor: auditor, trash: lecturerRepo, find: lecturerRepo.FindByID,
+				deletedAt: func(l *entity.Lecturer) time.Time { return l.DeletedAt.Time },
+			},
+			TrashCourses: &bin[entity.Course]{
+				noun: entity.AuditCourse, auditor: auditor, trash: courseRepo, find: courseRepo.FindByID,
+				deletedAt: func(c *entity.Course) time.Time { return c.DeletedAt.Time },
+			},
+			TrashEnrollments: &bin[entity.Enrollment]{
+				noun: entity.AuditEnrollment, auditor: auditor, trash: enrollmentRepo, find: enrollmentRepo.FindByID,
+				deletedAt: func(e *entity.Enrollment) time.Time { return e.DeletedAt.Time },
+				canRestore: func(ctx context.Context, e *entity.Enrollment) error {
+					if _, err := studentRepo.FindByID(ctx, e.StudentID); err != nil {
+						return parentMissing(err, "student")
+					}
+					if _, err := courseRepo.FindByID(ctx, e.CourseID); err != nil {
+						return parentMissing(err, "course")
+					}
+					return nil
+				},
+			},
+		},
+		txManager: txManager,
+		retention: retention,
+	}
+}
+
+func parentMissing(err error, noun string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.Conflict(noun+"_id", fmt.Sprintf("the %s is deleted; restore it first", noun))
+	}
+	return err
+}
+
+func (uc *trashUseCaseImpl) bin(resource string) (trashBin, error) {
+	b, ok := uc.bins[resource]
+	if !ok {
+		return nil, apperror.Validation(fmt.Sprintf("unknown resource %q: use students, lecturers, courses or enrollments", resource))
+	}
+	return b, nil
+}
+
+func (uc *trashUseCaseImpl) List(ctx context.Context, resource string, page, pageSize int) ([]DeletedRecord, int64, error) {
+	b, err := uc.bin(resource)
+	if err != nil {
+		return nil, 0, err
+	}
+	return b.list(ctx, page, pageSize, uc.retention)
+}
+
+func (uc *trashUseCaseImpl) Restore(ctx context.Context, resource string, id uuid.UUID) (interface{}, error) {
+	b, err := uc.bin(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	// The checks on referenced records and the restore share a
+	// transaction, so a student cannot be deleted in between.
+	var restored interface{}
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		restored, err = b.restore(ctx, id)
+		return err
+	})
+	return restored, err
+}
+
+func (uc *trashUseCaseImpl) Purge(ctx context.Context, resource string, id uuid.UUID) error {
+	b, err := uc.bin(resource)
+	if err != nil {
+		return err
+	}
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return b.purge(ctx, id, uc.retention)
+	})
+}
+
+func (uc *trashUseCaseImpl) PurgeExpired(ctx context.Context) (map[string]int64, error) {
+	cutoff := time.Now().Add(-uc.retention)
+	purged := make(map[string]int64, len(trashResources))
+	for _, resource := range trashResources {
+		n, err := uc.bins[resource].purgeDeleted(ctx, cutoff)
+		if err != nil {
+			return purged, fmt.Errorf("failed to purge %s: %w", resource, err)
+		}
+		purged[resource] = n
+	}
+	return purged, nil
+}
+
+// RunTrashPurger calls PurgeExpired every interval until ctx is done. A
+// failed purge is logged and retried on the next tick.
+func RunTrashPurger(ctx context.Context, uc TrashUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log := logger.FromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		purged, err := uc.PurgeExpired(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.ErrorContext(ctx, "trash purge failed", "error", err)
+			continue
+		}
+		var total int64
+		for _, n := range purged {
+			total += n
+		}
+		if total > 0 {
+			log.InfoContext(ctx, "trash purged", "records", purged)
+		}
+	}
+}
+
+// trashBin is the trash of one resource with the entity type erased.
+type trashBin interface {
+	list(ctx context.Context, page, pageSize int, retention time.Duration) ([]DeletedRecord, int64, error)
+	restore(ctx context.Context, id uuid.UUID) (interface{}, error)
+	purge(ctx context.Context, id uuid.UUID, retention time.Duration) error
+	purgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type bin[T any] struct {
+	// noun names the resource in messages and is its audit entity type.
+	noun      string
+	auditor   *Auditor
+	trash     repository.Trash[T]
+	find      func(ctx context.Context, id uuid.UUID) (*T, error)
+	deletedAt func(*T) time.Time
+	// canRestore, when set, vets a record before it is restored.
+	canRestore func(ctx context.Context, row *T) error
+}
+
+func (b *bin[T]) notFound(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return apperror.NotFound(fmt.Sprintf("deleted %s not found", b.noun))
+	}
+	return err
+}
+
+func (b *bin[T]) list(ctx context.Context, page, pageSize int, retention time.Duration) ([]DeletedRecord, int64, error) {
+	rows, total, err := b.trash.FindDeleted(ctx, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	records := make([]DeletedRecord, len(rows))
+	for i, row := range rows {
+		deletedAt := b.deletedAt(row)
+		records[i] = DeletedRecord{Record: row, DeletedAt: deletedAt, PurgeAfter: deletedAt.Add(retention)}
+	}
+	return records, total, nil
+}
+
+func (b *bin[T]) restore(ctx context.Context, id uuid.UUID) (interface{}, error) {
+	row, err := b.trash.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, b.notFound(err)
+	}
+	if b.canRestore != nil {
+		if err := b.canRestore(ctx, row); err != nil {
+			return nil, err
+		}
+	}
+	if err := b.trash.Restore(ctx, id); err != nil {
+		return nil, b.notFound(err)
+	}
+	restored, err := b.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return restored, b.auditor.Record(ctx, entity.AuditRestore, b.noun, id, row, restored)
+}
+
+func (b *bin[T]) purge(ctx context.Context, id uuid.UUID, retention time.Duration) error {
+	row, err := b.trash.FindDeletedByID(ctx, id)
+	if err != nil {
+		return b.notFound(err)
+	}
+	cutoff := time.Now().Add(-retention)
+	if deletedAt := b.deletedAt(row); !deletedAt.Before(cutoff) {
+		return apperror.New(apperror.KindConflict, fmt.Sprintf("%s is within its retention period until %s",
+			b.noun, deletedAt.Add(retention).UTC().Format(time.RFC3339)))
+	}
+	if err := b.trash.Purge(ctx, id, cutoff); err != nil {
+		return b.notFound(err)
+	}
+	return b.auditor.Record(ctx, entity.AuditPurge, b.noun, id, row, nil)
+}
+
+func (b *bin[T]) purgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return b.trash.PurgeDeleted(ctx, deletedBefore)
+}
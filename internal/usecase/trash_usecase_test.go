@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/memory"
+)
+
+// trashFixture holds the memory repositories behind a TrashUseCase with a
+// student and course and one enrollment between them.
+type trashFixture struct {
+	students    repository.StudentRepository
+	courses     repository.CourseRepository
+	enrollments repository.EnrollmentRepository
+	student     *entity.Student
+	course      *entity.Course
+	enrollment  *entity.Enrollment
+}
+
+func newTrashFixture(t *testing.T) *trashFixture {
+	t.Helper()
+	ctx := context.Background()
+	f := &trashFixture{
+		students:    memory.NewStudentRepository(),
+		courses:     memory.NewCourseRepository(),
+		enrollments: memory.NewEnrollmentRepository(memory.NewKRSRepository(), memory.NewWaitlistRepository()),
+		student:     &entity.Student{NIM: "2021001", Name: "Ani", Email: "ani@example.com", Major: "Informatics", EnrollmentYear: 2021},
+		course:      &entity.Course{Code: "IF101", Name: "Algorithms", Credits: 3, Semester: 1, Department: "Informatics", MaxStudents: 40},
+	}
+	if err := f.students.Create(ctx, f.student); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.courses.Create(ctx, f.course); err != nil {
+		t.Fatal(err)
+	}
+	f.enrollment = &entity.Enrollment{StudentID: f.student.ID, CourseID: f.course.ID, AcademicYear: "2024/2025", Semester: 1}
+	if err := f.enrollments.Create(ctx, f.enrollment); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f *trashFixture) useCase(retention time.Duration) TrashUseCase {
+	auditor := NewAuditor(memory.NewAuditRepository(), true)
+	return NewTrashUseCase(f.students, memory.NewLecturerRepository(), f.courses, f.enrollments, memory.NewTxManager(), auditor, retention)
+}
+
+func TestTrashRestoreEnrollment(t *testing.T) {
+	tests := []struct {
+		name      string
+		delete    func(ctx context.Context, f *trashFixture) error
+		wantErr   string
+		wantField string
+	}{
+		{
+			name: "student deleted",
+			delete: func(ctx context.Context, f *trashFixture) error {
+				return f.students.Delete(ctx, f.student.ID, f.student.Version)
+			},
+			wantErr:   "the student is deleted; restore it first",
+			wantField: "student_id",
+		},
+		{
+			name: "course deleted",
+			delete: func(ctx context.Context, f *trashFixture) error {
+				return f.courses.Delete(ctx, f.course.ID, f.course.Version)
+			},
+			wantErr:   "the course is deleted; restore it first",
+			wantField: "course_id",
+		},
+		{
+			name:   "student and course live",
+			delete: func(context.Context, *trashFixture) error { return nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newTrashFixture(t)
+			if err := f.enrollments.Delete(ctx, f.enrollment.ID, f.enrollment.Version); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.delete(ctx, f); err != nil {
+				t.Fatal(err)
+			}
+
+			restored, err := f.useCase(time.Hour).Restore(ctx, TrashEnrollments, f.enrollment.ID)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr || apperror.KindOf(err) != apperror.KindConflict || apperror.FieldOf(err) != tt.wantField {
+					t.Fatalf("Restore() = %v, want a conflict on %s: %q", err, tt.wantField, tt.wantErr)
+				}
+				if _, err := f.enrollments.FindDeletedByID(ctx, f.enrollment.ID); err != nil {
+					t.Errorf("enrollment left the trash: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Restore() = %v", err)
+			}
+			if e, ok := restored.(*entity.Enrollment); !ok || e.ID != f.enrollment.ID {
+				t.Errorf("Restore() = %#v, want the enrollment", restored)
+			}
+		})
+	}
+}
+
+func TestTrashPurge(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		wantPurge bool
+	}{
+		{name: "within the retention period", retention: time.Hour},
+		{name: "after the retention period", retention: 0, wantPurge: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newTrashFixture(t)
+			if err := f.enrollments.Delete(ctx, f.enrollment.ID, f.enrollment.Version); err != nil {
+				t.Fatal(err)
+			}
+			uc := f.useCase(tt.retention)
+
+			err := uc.Purge(ctx, TrashEnrollments, f.enrollment.ID)
+			_, findErr := f.enrollments.FindDeletedByID(ctx, f.enrollment.ID)
+			if !tt.wantPurge {
+				if apperror.KindOf(err) != apperror.KindConflict {
+					t.Fatalf("Purge() = %v, want %v", err, apperror.KindConflict)
+				}
+				if findErr != nil {
+					t.Errorf("enrollment left the trash: %v", findErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Purge() = %v", err)
+			}
+			if findErr == nil {
+				t.Error("enrollment is still in the trash")
+			}
+			if err := uc.Purge(ctx, TrashEnrollments, f.enrollment.ID); apperror.KindOf(err) != apperror.KindNotFound {
+				t.Errorf("second Purge() = %v, want %v", err, apperror.KindNotFound)
+			}
+		})
+	}
+}
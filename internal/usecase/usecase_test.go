@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/seatfeed"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/memory"
+)
+
+const (
+	testYear     = "2024/2025"
+	testSemester = 1
+)
+
+// academic wires the enrollment, waitlist and KRS use cases to memory
+// repositories, counting seats from the KRS state seatsFrom.
+type academic struct {
+	t *testing.T
+
+	students    repository.StudentRepository
+	lecturers   repository.LecturerRepository
+	courses     repository.CourseRepository
+	enrollments repository.EnrollmentRepository
+	waitlists   repository.WaitlistRepository
+	krs         repository.KRSRepository
+	advisors    repository.AdvisorRepository
+
+	enrollment EnrollmentUseCase
+	waitlist   WaitlistUseCase
+	krsUseCase KRSUseCase
+}
+
+func newAcademic(t *testing.T, seatsFrom string) *academic {
+	t.Helper()
+	a := &academic{
+		t:         t,
+		students:  memory.NewStudentRepository(),
+		lecturers: memory.NewLecturerRepository(),
+		courses:   memory.NewCourseRepository(),
+		waitlists: memory.NewWaitlistRepository(),
+		krs:       memory.NewKRSRepository(),
+		advisors:  memory.NewAdvisorRepository(),
+	}
+	a.enrollments = memory.NewEnrollmentRepository(a.krs, a.waitlists)
+	audit, outbox := memory.NewAuditRepository(), memory.NewOutboxRepository()
+	tx := memory.NewTxManager(a.students, a.lecturers, a.courses, a.enrollments, a.waitlists, a.krs, a.advisors, audit, outbox)
+
+	seatKRS := entity.KRSSeatStatuses(seatsFrom)
+	auditor := NewAuditor(audit, false)
+	events := NewOutbox(outbox)
+	seats := NewSeatFeed(memory.NewSeatChangeBus(), a.courses, a.enrollments, seatfeed.NewHub(1, 1, 0), seatKRS)
+	a.enrollment = NewEnrollmentUseCase(a.enrollments, a.students, a.courses, a.waitlists, a.krs, tx, auditor, events, seats, 0, seatKRS)
+	a.waitlist = NewWaitlistUseCase(a.waitlists, a.students, a.courses, a.enrollments, tx, auditor, seatKRS)
+	a.krsUseCase = NewKRSUseCase(a.krs, a.students, a.enrollments, a.courses, a.lecturers, a.advisors, a.enrollment, tx, auditor, events, seats, seatKRS)
+	return a
+}
+
+// student creates an active student advised by a new lecturer.
+func (a *academic) student(nim string) *entity.Student {
+	a.t.Helper()
+	ctx := context.Background()
+	student := &entity.Student{NIM: nim, Name: "Student " + nim, Email: nim + "@students.example.com", Major: "Informatics", EnrollmentYear: 2024, Status: "active"}
+	a.must(a.students.Create(ctx, student))
+	lecturer := &entity.Lecturer{NIP: "L" + nim, Name: "Advisor of " + nim, Email: nim + "@staff.example.com", Department: "Informatics", Status: "active"}
+	a.must(a.lecturers.Create(ctx, lecturer))
+	a.must(a.advisors.Create(ctx, &entity.AdvisorAssignment{StudentID: student.ID, LecturerID: lecturer.ID, StartedAt: time.Now().UTC()}))
+	return student
+}
+
+// linkUser links the user account userID to student.
+func (a *academic) linkUser(student *entity.Student, userID uuid.UUID) {
+	a.t.Helper()
+	student, err := a.students.FindByID(context.Background(), student.ID)
+	a.must(err)
+	a.must(a.students.Update(context.Background(), student.ID, student.Version, map[string]interface{}{"user_id": userID}))
+}
+
+// linkAdvisor links the user account userID to the current advisor of
+// student.
+func (a *academic) linkAdvisor(student *entity.Student, userID uuid.UUID) {
+	a.t.Helper()
+	ctx := context.Background()
+	advisor, err := a.advisors.FindCurrent(ctx, student.ID)
+	a.must(err)
+	lecturer, err := a.lecturers.FindByID(ctx, advisor.LecturerID)
+	a.must(err)
+	a.must(a.lecturers.Update(ctx, lecturer.ID, lecturer.Version, map[string]interface{}{"user_id": userID}))
+}
+
+func (a *academic) course(code string, maxStudents int) *entity.Course {
+	a.t.Helper()
+	course := &entity.Course{Code: code, Name: "Course " + code, Credits: 3, Semester: 1, Department: "Informatics", CourseType: "mandatory", MaxStudents: maxStudents, Status: "active"}
+	a.must(a.courses.Create(context.Background(), course))
+	return course
+}
+
+func (a *academic) enroll(ctx context.Context, studentID, courseID uuid.UUID) (*entity.Enrollment, error) {
+	enrollment := &entity.Enrollment{StudentID: studentID, CourseID: courseID, AcademicYear: testYear, Semester: testSemester}
+	return enrollment, a.enrollment.Enroll(ctx, enrollment)
+}
+
+// dropped enrolls student in course and drops the enrollment.
+func (a *academic) dropped(ctx context.Context, student *entity.Student, course *entity.Course) *entity.Enrollment {
+	a.t.Helper()
+	enrollment, err := a.enroll(ctx, student.ID, course.ID)
+	a.must(err)
+	enrollment, err = a.enrollment.Update(ctx, enrollment.ID, enrollment.Version, map[string]interface{}{"status": "dropped"})
+	a.must(err)
+	return enrollment
+}
+
+// submitted enrolls student in course and submits their KRS, which holds
+// the seat when seats count from submission.
+func (a *academic) submitted(ctx context.Context, student *entity.Student, course *entity.Course) {
+	a.t.Helper()
+	_, err := a.enroll(ctx, student.ID, course.ID)
+	a.must(err)
+	a.must(a.decide(ctx, student.ID, ""))
+}
+
+// termKRS returns the KRS of a student in the test term.
+func (a *academic) termKRS(studentID uuid.UUID) *entity.KRSSubmission {
+	a.t.Helper()
+	krs, err := a.krs.FindByStudentTerm(context.Background(), studentID, testYear, testSemester)
+	a.must(err)
+	return krs
+}
+
+// decide submits the KRS of a student and, unless decision is empty,
+// reviews it.
+func (a *academic) decide(ctx context.Context, studentID uuid.UUID, decision string) error {
+	krs := a.termKRS(studentID)
+	krs, err := a.krsUseCase.Submit(ctx, krs.ID, krs.Version)
+	if err != nil || decision == "" {
+		return err
+	}
+	comment := ""
+	if decision == entity.KRSRevisionRequested {
+		comment = "please revise"
+	}
+	_, err = a.krsUseCase.Review(ctx, krs.ID, krs.Version, decision, comment)
+	return err
+}
+
+func (a *academic) taken(courseID uuid.UUID, seatsFrom string) int64 {
+	a.t.Helper()
+	taken, err := a.enrollments.CountActiveByCourse(context.Background(), courseID, testYear, testSemester, entity.KRSSeatStatuses(seatsFrom))
+	a.must(err)
+	return taken
+}
+
+func (a *academic) must(err error) {
+	a.t.Helper()
+	if err != nil {
+		a.t.Fatal(err)
+	}
+}
@@ -0,0 +1,18 @@
+// File: internal/usecase/version.go
+package usecase
+
+import "github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+
+// checkVersion compares the version a client read (from If-Match) with the
+// stored one. A zero expected version stands for "If-Match: *" and accepts
+// whatever is current. The returned version is what the repository write
+// must be conditioned on.
+func checkVersion(current, expected int) (int, error) {
+	if expected == 0 {
+		return current, nil
+	}
+	if expected != current {
+		return 0, repository.ErrVersionConflict
+	}
+	return expected, nil
+}
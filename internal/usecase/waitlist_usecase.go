@@ -0,0 +1,232 @@
+// File: internal/usecase/waitlist_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/actor"
+)
+
+// WaitlistUseCase manages the waitlists of full courses. Promotion is not
+// part of it: the enrollment use case promotes the next students in the
+// same unit of work that drops or deletes an enrollment, or in which a
+// KRS decision releases seats.
+type WaitlistUseCase interface {
+	// Join puts a student at the end of the waitlist of a full course.
+	// A caller with the student role can only put themselves on it.
+	Join(ctx context.Context, entry *entity.WaitlistEntry) error
+	Get(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error)
+	// List returns the waiting entries of a course term in promotion order.
+	List(ctx context.Context, courseID uuid.UUID, academicYear string, semester int) ([]*entity.WaitlistEntry, error)
+	// Move puts a waiting entry at position, counted from 1, shifting the
+	// entries in between. Positions past the end move it to the end.
+	Move(ctx context.Context, id uuid.UUID, version int, position int) (*entity.WaitlistEntry, error)
+	// Remove takes a waiting entry off its waitlist.
+	Remove(ctx context.Context, id uuid.UUID, version int) error
+}
+
+type waitlistUseCaseImpl struct {
+	repo           repository.WaitlistRepository
+	studentRepo    repository.StudentRepository
+	courseRepo     repository.CourseRepository
+	enrollmentRepo repository.EnrollmentRepository
+	txManager      repository.TxManager
+	auditor        *Auditor
+	seatKRS        []string
+}
+
+// NewWaitlistUseCase counts the enrollments whose KRS is in one of the
+// states seatKRS as taking a seat, as the enrollment use case does.
+func NewWaitlistUseCase(
+	repo repository.WaitlistRepository,
+	studentRepo repository.StudentRepository,
+	courseRepo repository.CourseRepository,
+	enrollmentRepo repository.EnrollmentRepository,
+	txManager repository.TxManager,
+	auditor *Auditor,
+	seatKRS []string,
+) WaitlistUseCase {
+	return &waitlistUseCaseImpl{
+		repo:           repo,
+		studentRepo:    studentRepo,
+		courseRepo:     courseRepo,
+		enrollmentRepo: enrollmentRepo,
+		txManager:      txManager,
+		auditor:        auditor,
+		seatKRS:        seatKRS,
+	}
+}
+
+func (uc *waitlistUseCaseImpl) Join(ctx context.Context, entry *entity.WaitlistEntry) error {
+	if entry.AcademicYear == "" || entry.Semester < 1 {
+		return apperror.Validation("required fields are missing")
+	}
+
+	// Reading the seats and the end of the waitlist in the transaction
+	// keeps a concurrent drop from promoting past the new entry.
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return uc.join(ctx, entry)
+	})
+}
+
+func (uc *waitlistUseCaseImpl) join(ctx context.Context, entry *entity.WaitlistEntry) error {
+	student, err := uc.studentRepo.FindByID(ctx, entry.StudentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return apperror.NotFound("student not found")
+		}
+		return err
+	}
+	if caller := actor.From(ctx); caller.Role == "student" && (student.UserID == nil || *student.UserID != caller.UserID) {
+		return apperror.Forbidden("students can only join a waitlist for themselves")
+	}
+	if student.Status != "active" {
+		return apperror.Validation("student is not active")
+	}
+
+	course, err := uc.courseRepo.FindByID(ctx, entry.CourseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return apperror.NotFound("course not found")
+		}
+		return err
+	}
+	if course.Status != "active" {
+		return apperror.Validation("course is not active")
+	}
+
+	enrolled, err := uc.enrollmentRepo.FindByStudentCourse(ctx, entry.StudentID, entry.CourseID, entry.AcademicYear, entry.Semester)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to check existing enrollment: %w", err)
+	}
+	if enrolled != nil {
+		return apperror.Conflict("course_id", "student is already enrolled in this course")
+	}
+
+	taken, err := uc.enrollmentRepo.CountActiveByCourse(ctx, entry.CourseID, entry.AcademicYear, entry.Semester, uc.seatKRS)
+	if err != nil {
+		return fmt.Errorf("failed to count course seats: %w", err)
+	}
+	if course.MaxStudents == 0 || taken < int64(course.MaxStudents) {
+		return apperror.Conflict("course_id", "course has free seats, enroll instead")
+	}
+
+	waiting, err := uc.repo.FindWaiting(ctx, entry.CourseID, entry.AcademicYear, entry.Semester)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(waiting, func(e *entity.WaitlistEntry) bool { return e.StudentID == entry.StudentID }) {
+		return apperror.Conflict("student_id", "student is already on the waitlist")
+	}
+
+	entry.Position = 1
+	if len(waiting) > 0 {
+		entry.Position = waiting[len(waiting)-1].Position + 1
+	}
+	entry.Status = entity.WaitlistWaiting
+	if err := uc.repo.Create(ctx, entry); err != nil {
+		return err
+	}
+	return uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditWaitlist, entry.ID, nil, entry)
+}
+
+func (uc *waitlistUseCaseImpl) Get(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	entry, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("waitlist entry not found")
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (uc *waitlistUseCaseImpl) List(ctx context.Context, courseID uuid.UUID, academicYear string, semester int) ([]*entity.WaitlistEntry, error) {
+	if _, err := uc.courseRepo.FindByID(ctx, courseID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("course not found")
+		}
+		return nil, err
+	}
+	return uc.repo.FindWaiting(ctx, courseID, academicYear, semester)
+}
+
+func (uc *waitlistUseCaseImpl) Move(ctx context.Context, id uuid.UUID, version int, position int) (*entity.WaitlistEntry, error) {
+	if position < 1 {
+		return nil, apperror.Validation("position must be at least 1")
+	}
+
+	var moved *entity.WaitlistEntry
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.waitingEntry(ctx, id, version)
+		if err != nil {
+			return err
+		}
+		waiting, err := uc.repo.FindWaiting(ctx, existing.CourseID, existing.AcademicYear, existing.Semester)
+		if err != nil {
+			return err
+		}
+
+		// Renumber the waitlist 1..n in its new order, writing only the
+		// entries whose position changes.
+		waiting = slices.DeleteFunc(waiting, func(e *entity.WaitlistEntry) bool { return e.ID == id })
+		waiting = slices.Insert(waiting, min(position, len(waiting)+1)-1, existing)
+		for i, entry := range waiting {
+			if entry.Position == i+1 {
+				continue
+			}
+			if err := uc.repo.Update(ctx, entry.ID, entry.Version, map[string]interface{}{"position": i + 1}); err != nil {
+				return err
+			}
+		}
+
+		if moved, err = uc.repo.FindByID(ctx, id); err != nil {
+			return err
+		}
+		if moved.Position == existing.Position {
+			return nil
+		}
+		return uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditWaitlist, id, existing, moved)
+	})
+	return moved, err
+}
+
+func (uc *waitlistUseCaseImpl) Remove(ctx context.Context, id uuid.UUID, version int) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.waitingEntry(ctx, id, version)
+		if err != nil {
+			return err
+		}
+		if err := uc.repo.Update(ctx, id, existing.Version, map[string]interface{}{"status": entity.WaitlistRemoved}); err != nil {
+			return err
+		}
+		removed, err := uc.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditWaitlist, id, existing, removed)
+	})
+}
+
+// waitingEntry loads an entry that is still on its waitlist and checks its
+// version.
+func (uc *waitlistUseCaseImpl) waitingEntry(ctx context.Context, id uuid.UUID, version int) (*entity.WaitlistEntry, error) {
+	existing, err := uc.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkVersion(existing.Version, version); err != nil {
+		return nil, err
+	}
+	if existing.Status != entity.WaitlistWaiting {
+		return nil, apperror.Conflict("status", fmt.Sprintf("entry is %s, not waiting", existing.Status))
+	}
+	return existing, nil
+}
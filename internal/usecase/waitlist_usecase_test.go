@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/actor"
+)
+
+func TestWaitlistJoinRoles(t *testing.T) {
+	self, other := uuid.New(), uuid.New()
+	tests := []struct {
+		name      string
+		caller    actor.Actor
+		forbidden bool
+	}{
+		{name: "admin", caller: actor.Actor{UserID: other, Role: "admin"}},
+		{name: "staff", caller: actor.Actor{UserID: other, Role: "staff"}},
+		{name: "the student themselves", caller: actor.Actor{UserID: self, Role: "student"}},
+		{name: "another student", caller: actor.Actor{UserID: other, Role: "student"}, forbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := actor.With(context.Background(), actor.Actor{Role: "admin"})
+			a := newAcademic(t, entity.KRSSubmitted)
+			course := a.course("IF101", 1)
+			a.submitted(ctx, a.student("2024001"), course)
+			student := a.student("2024002")
+			a.must(a.students.Update(ctx, student.ID, student.Version, map[string]interface{}{"user_id": self}))
+
+			entry := &entity.WaitlistEntry{StudentID: student.ID, CourseID: course.ID, AcademicYear: testYear, Semester: testSemester}
+			err := a.waitlist.Join(actor.With(ctx, tt.caller), entry)
+			waiting, findErr := a.waitlists.FindWaiting(ctx, course.ID, testYear, testSemester)
+			a.must(findErr)
+			if tt.forbidden {
+				if apperror.KindOf(err) != apperror.KindForbidden || len(waiting) != 0 {
+					t.Fatalf("Join() = %v with %d waiting, want %v and nobody waiting", err, len(waiting), apperror.KindForbidden)
+				}
+				return
+			}
+			if err != nil || len(waiting) != 1 || waiting[0].StudentID != student.ID {
+				t.Fatalf("Join() = %v with %d waiting, want the student on the waitlist", err, len(waiting))
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+// File: internal/usecase/webhook_usecase.go
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/eventsink"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/logger"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/metrics"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/webhook"
+)
+
+const (
+	// webhookRetryBackoff is the wait after the first failed delivery
+	// attempt; it doubles with every further one, up to webhookMaxBackoff.
+	webhookRetryBackoff = 30 * time.Second
+	webhookMaxBackoff   = time.Hour
+
+	// webhookCleanupInterval is how often the dispatcher deletes finished
+	// deliveries older than the retention.
+	webhookCleanupInterval = time.Hour
+)
+
+type WebhookUseCase interface {
+	// CreateSubscription stores subscription with a new signing secret,
+	// which is only ever returned here.
+	CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, page, pageSize int) ([]*entity.WebhookSubscription, int64, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID, version int) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter repository.DeliveryFilter, page, pageSize int) ([]*entity.WebhookDelivery, int64, error)
+	// Redeliver sends a delivery that succeeded or was dead-lettered again,
+	// with a fresh set of attempts.
+	Redeliver(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+}
+
+type webhookUseCaseImpl struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+	txManager     repository.TxManager
+	auditor       *Auditor
+	policy        *webhook.Policy
+}
+
+func NewWebhookUseCase(
+	subscriptions repository.WebhookSubscriptionRepository,
+	deliveries repository.WebhookDeliveryRepository,
+	txManager repository.TxManager,
+	auditor *Auditor,
+	policy *webhook.Policy,
+) WebhookUseCase {
+	return &webhookUseCaseImpl{subscriptions: subscriptions, deliveries: deliveries, txManager: txManager, auditor: auditor, policy: policy}
+}
+
+func (uc *webhookUseCaseImpl) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	if err := uc.validateURL(ctx, subscription.URL); err != nil {
+		return err
+	}
+	if err := validateEventTypes(subscription.EventTypes); err != nil {
+		return err
+	}
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	subscription.Secret = secret
+
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.subscriptions.Create(ctx, subscription); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, entity.AuditCreate, entity.AuditWebhook, subscription.ID, nil, subscription)
+	})
+}
+
+func (uc *webhookUseCaseImpl) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	subscription, err := uc.subscriptions.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("webhook subscription not found")
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (uc *webhookUseCaseImpl) ListSubscriptions(ctx context.Context, page, pageSize int) ([]*entity.WebhookSubscription, int64, error) {
+	return uc.subscriptions.FindAll(ctx, page, pageSize)
+}
+
+func (uc *webhookUseCaseImpl) UpdateSubscription(ctx context.Context, id uuid.UUID, version int, changes map[string]interface{}) (*entity.WebhookSubscription, error) {
+	if u, ok := changes["url"].(string); ok {
+		if err := uc.validateURL(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+	if types, ok := changes["event_types"].(entity.EventTypeList); ok {
+		if err := validateEventTypes(types); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated *entity.WebhookSubscription
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.GetSubscription(ctx, id)
+		if err != nil {
+			return err
+		}
+		version, err := checkVersion(existing.Version, version)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			updated = existing
+			return nil
+		}
+		if err := uc.subscriptions.Update(ctx, id, version, changes); err != nil {
+			return err
+		}
+		if updated, err = uc.subscriptions.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, entity.AuditUpdate, entity.AuditWebhook, id, existing, updated)
+	})
+	return updated, err
+}
+
+func (uc *webhookUseCaseImpl) DeleteSubscription(ctx context.Context, id uuid.UUID, version int) error {
+	return uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := uc.GetSubscription(ctx, id)
+		if err != nil {
+			return err
+		}
+		version, err := checkVersion(existing.Version, version)
+		if err != nil {
+			return err
+		}
+		if err := uc.subscriptions.Delete(ctx, id, version); err != nil {
+			return err
+		}
+		return uc.auditor.Record(ctx, entity.AuditDelete, entity.AuditWebhook, id, existing, nil)
+	})
+}
+
+func (uc *webhookUseCaseImpl) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	delivery, err := uc.deliveries.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.NotFound("webhook delivery not found")
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (uc *webhookUseCaseImpl) ListDeliveries(ctx context.Context, filter repository.DeliveryFilter, page, pageSize int) ([]*entity.WebhookDelivery, int64, error) {
+	if filter.Status != "" && !slices.Contains(entity.DeliveryStatuses, filter.Status) {
+		return nil, 0, apperror.Validation(fmt.Sprintf("status must be one of %v", entity.DeliveryStatuses))
+	}
+	if filter.EventType != "" && !slices.Contains(entity.EventTypes, filter.EventType) {
+		return nil, 0, apperror.Validation(fmt.Sprintf("event_type must be one of %v", entity.EventTypes))
+	}
+	return uc.deliveries.FindAll(ctx, filter, page, pageSize)
+}
+
+func (uc *webhookUseCaseImpl) Redeliver(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	delivery, err := uc.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == entity.DeliveryPending {
+		return nil, apperror.Conflict("status", "delivery is still pending")
+	}
+	if _, err := uc.GetSubscription(ctx, delivery.SubscriptionID); err != nil {
+		if apperror.KindOf(err) == apperror.KindNotFound {
+			return nil, apperror.Conflict("subscription_id", "webhook subscription was deleted")
+		}
+		return nil, err
+	}
+
+	err = uc.deliveries.Update(ctx, id, map[string]interface{}{
+		"status":          entity.DeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.deliveries.FindByID(ctx, id)
+}
+
+// validateURL accepts absolute http and https URLs whose host the policy
+// permits. The sender checks the address again on every delivery, since
+// the host may resolve elsewhere by then.
+func (uc *webhookUseCaseImpl) validateURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apperror.Validation("url must be an absolute http or https URL")
+	}
+	if err := uc.policy.CheckHost(ctx, u.Hostname()); err != nil {
+		return apperror.Validation(fmt.Sprintf("url must not point at an internal address: %v", err))
+	}
+	return nil
+}
+
+func validateEventTypes(types entity.EventTypeList) error {
+	if len(types) == 0 {
+		return apperror.Validation("event_types must name at least one event type")
+	}
+	for _, t := range types {
+		if !slices.Contains(entity.EventTypes, t) {
+			return apperror.Validation(fmt.Sprintf("unknown event type %q, must be one of %v", t, entity.EventTypes))
+		}
+	}
+	return nil
+}
+
+// WebhookFanout is the sink that turns each published domain event into
+// one pending delivery per active subscription wanting it. It runs behind
+// the outbox relay, so a subscription only receives events published
+// after it was created.
+type WebhookFanout struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+}
+
+func NewWebhookFanout(subscriptions repository.WebhookSubscriptionRepository, deliveries repository.WebhookDeliveryRepository) *WebhookFanout {
+	return &WebhookFanout{subscriptions: subscriptions, deliveries: deliveries}
+}
+
+// Publish queues msg for the subscriptions. The relay publishes an event
+// again when this fails; subscriptions that already have it are skipped.
+func (f *WebhookFanout) Publish(ctx context.Context, msg eventsink.Message) error {
+	subscriptions, err := f.subscriptions.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	var deliveries []*entity.WebhookDelivery
+	now := time.Now().UTC()
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(msg.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(msg); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, &entity.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        msg.ID,
+			EventType:      msg.Type,
+			Payload:        string(payload),
+			Status:         entity.DeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	return f.deliveries.CreateMany(ctx, deliveries)
+}
+
+func (f *WebhookFanout) Close() error {
+	return nil
+}
+
+// WebhookDispatcher sends the pending deliveries. A failed delivery is
+// retried with exponential backoff, from 30s up to 1h, and dead-lettered
+// once it has used up its attempts.
+type WebhookDispatcher struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+	sender        *webhook.Sender
+	batchSize     int
+	maxAttempts   int
+	retention     time.Duration
+}
+
+// NewWebhookDispatcher returns a dispatcher sending up to batchSize
+// deliveries per query, each at most maxAttempts times. Finished
+// deliveries are deleted once retention has passed, unless it is 0.
+func NewWebhookDispatcher(
+	subscriptions repository.WebhookSubscriptionRepository,
+	deliveries repository.WebhookDeliveryRepository,
+	sender *webhook.Sender,
+	batchSize, maxAttempts int,
+	retention time.Duration,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		batchSize:     batchSize,
+		maxAttempts:   maxAttempts,
+		retention:     retention,
+	}
+}
+
+// Run dispatches every interval until ctx is done. Instances sharing a
+// database take turns through TryLock, like the outbox relay.
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log := logger.FromContext(ctx)
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		release, ok, err := d.deliveries.TryLock(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.ErrorContext(ctx, "webhook dispatcher failed to take its lock", "error", err)
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := d.drain(ctx); err != nil && ctx.Err() == nil {
+			log.ErrorContext(ctx, "webhook dispatch failed", "error", err)
+		}
+		if d.retention > 0 && time.Since(lastCleanup) >= webhookCleanupInterval {
+			deleted, err := d.deliveries.DeleteFinished(ctx, time.Now().Add(-d.retention))
+			if err != nil && ctx.Err() == nil {
+				log.ErrorContext(ctx, "webhook delivery cleanup failed", "error", err)
+			} else {
+				lastCleanup = time.Now()
+				if deleted > 0 {
+					log.InfoContext(ctx, "webhook deliveries cleaned up", "deliveries", deleted)
+				}
+			}
+		}
+		release()
+	}
+}
+
+// drain dispatches batches until one is not full.
+func (d *WebhookDispatcher) drain(ctx context.Context) error {
+	for {
+		attempted, err := d.DispatchOnce(ctx)
+		if err != nil || attempted < d.batchSize {
+			return err
+		}
+	}
+}
+
+// DispatchOnce attempts the due deliveries, the longest waiting first, and
+// returns how many it attempted.
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	due, err := d.deliveries.FindDue(ctx, time.Now().UTC(), d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := make(map[uuid.UUID]*entity.WebhookSubscription)
+	for i, delivery := range due {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = d.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return i, err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if err := d.attempt(ctx, delivery, subscription); err != nil {
+			return i, err
+		}
+	}
+	return len(due), nil
+}
+
+// attempt sends delivery to subscription, which is nil when it was
+// deleted, and records the outcome.
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *entity.WebhookDelivery, subscription *entity.WebhookSubscription) error {
+	now := time.Now().UTC()
+	attempts := delivery.Attempts + 1
+	changes := map[string]interface{}{
+		"attempts":        attempts,
+		"last_attempt_at": now,
+	}
+
+	var sendErr error
+	switch {
+	case subscription == nil:
+		sendErr = errors.New("webhook subscription was deleted")
+		attempts = d.maxAttempts
+	case !subscription.Active:
+		sendErr = errors.New("webhook subscription is inactive")
+		attempts = d.maxAttempts
+	default:
+		var status int
+		status, sendErr = d.sender.Send(ctx, webhook.Request{
+			URL:        subscription.URL,
+			Secret:     subscription.Secret,
+			DeliveryID: delivery.ID.String(),
+			EventID:    delivery.EventID.String(),
+			EventType:  delivery.EventType,
+			Body:       []byte(delivery.Payload),
+		})
+		if sendErr != nil && ctx.Err() != nil {
+			// Shutting down: leave the delivery for the next dispatcher.
+			return ctx.Err()
+		}
+		changes["last_status_code"] = status
+	}
+
+	result := entity.DeliverySucceeded
+	switch {
+	case sendErr == nil:
+		changes["status"] = entity.DeliverySucceeded
+		changes["last_error"] = ""
+		changes["next_attempt_at"] = nil
+		changes["delivered_at"] = now
+	case attempts >= d.maxAttempts:
+		result = entity.DeliveryDead
+		changes["status"] = entity.DeliveryDead
+		changes["last_error"] = sendErr.Error()
+		changes["next_attempt_at"] = nil
+	default:
+		result = "failed"
+		changes["last_error"] = sendErr.Error()
+		changes["next_attempt_at"] = now.Add(exponentialBackoff(webhookRetryBackoff, webhookMaxBackoff, attempts))
+	}
+	metrics.RecordWebhookDelivery(delivery.EventType, result)
+	if sendErr != nil {
+		logger.FromContext(ctx).WarnContext(ctx, "webhook delivery failed",
+			"delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID, "event_type", delivery.EventType,
+			"attempts", delivery.Attempts+1, "dead", result == entity.DeliveryDead, "error", sendErr)
+	}
+	return d.deliveries.Update(ctx, delivery.ID, changes)
+}
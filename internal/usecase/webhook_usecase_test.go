@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/apperror"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/entity"
+	"github.com/haninhammoud01/go-academic-service/internal/domain/repository"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/eventsink"
+	"github.com/haninhammoud01/go-academic-service/internal/pkg/webhook"
+	"github.com/haninhammoud01/go-academic-service/internal/repository/memory"
+)
+
+func TestWebhookDispatcherAttempt(t *testing.T) {
+	const maxAttempts = 3
+	tests := []struct {
+		name string
+		// status is what the endpoint answers.
+		status int
+		// subscription is the state of the subscription: active, inactive
+		// or deleted.
+		subscription string
+		attempts     int
+		wantStatus   string
+		wantAttempts int
+		wantRequests int32
+		wantRetryIn  time.Duration
+	}{
+		{name: "delivered", status: http.StatusOK, subscription: "active", wantStatus: entity.DeliverySucceeded, wantAttempts: 1, wantRequests: 1},
+		{name: "first failure is retried", status: http.StatusInternalServerError, subscription: "active", wantStatus: entity.DeliveryPending, wantAttempts: 1, wantRequests: 1, wantRetryIn: 30 * time.Second},
+		{name: "later failure backs off", status: http.StatusInternalServerError, subscription: "active", attempts: 1, wantStatus: entity.DeliveryPending, wantAttempts: 2, wantRequests: 1, wantRetryIn: time.Minute},
+		{name: "last failure is dead-lettered", status: http.StatusInternalServerError, subscription: "active", attempts: maxAttempts - 1, wantStatus: entity.DeliveryDead, wantAttempts: maxAttempts, wantRequests: 1},
+		{name: "inactive subscription", status: http.StatusOK, subscription: "inactive", wantStatus: entity.DeliveryDead, wantAttempts: 1},
+		{name: "deleted subscription", status: http.StatusOK, subscription: "deleted", wantStatus: entity.DeliveryDead, wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if r.Header.Get(webhook.SignatureHeader) == "" {
+					t.Error("delivery is not signed")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			subscriptions, deliveries := memory.NewWebhookSubscriptionRepository(), memory.NewWebhookDeliveryRepository()
+			subscription := &entity.WebhookSubscription{
+				URL:        server.URL,
+				EventTypes: entity.EventTypeList{entity.EventStudentStatusChanged},
+				Secret:     "whsec_test",
+				Active:     tt.subscription != "inactive",
+			}
+			if err := subscriptions.Create(ctx, subscription); err != nil {
+				t.Fatal(err)
+			}
+			if tt.subscription == "deleted" {
+				if err := subscriptions.Delete(ctx, subscription.ID, subscription.Version); err != nil {
+					t.Fatal(err)
+				}
+			}
+			due := time.Now().UTC().Add(-time.Minute)
+			delivery := &entity.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventID:        uuid.New(),
+				EventType:      entity.EventStudentStatusChanged,
+				Payload:        `{}`,
+				Status:         entity.DeliveryPending,
+				Attempts:       tt.attempts,
+				NextAttemptAt:  &due,
+			}
+			if err := deliveries.CreateMany(ctx, []*entity.WebhookDelivery{delivery}); err != nil {
+				t.Fatal(err)
+			}
+
+			sender := webhook.NewSender(time.Second, webhook.NewPolicy([]string{"127.0.0.1"}))
+			defer sender.Close()
+			dispatcher := NewWebhookDispatcher(subscriptions, deliveries, sender, 10, maxAttempts, 0)
+			start := time.Now().UTC()
+			if attempted, err := dispatcher.DispatchOnce(ctx); err != nil || attempted != 1 {
+				t.Fatalf("DispatchOnce() = %d, %v, want 1 attempt", attempted, err)
+			}
+
+			got, err := deliveries.FindByID(ctx, delivery.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus || got.Attempts != tt.wantAttempts {
+				t.Errorf("delivery is %s after %d attempts, want %s after %d", got.Status, got.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if n := requests.Load(); n != tt.wantRequests {
+				t.Errorf("endpoint got %d requests, want %d", n, tt.wantRequests)
+			}
+			switch {
+			case tt.wantRetryIn > 0:
+				if got.NextAttemptAt == nil || got.NextAttemptAt.Sub(start) < tt.wantRetryIn || got.NextAttemptAt.Sub(start) > tt.wantRetryIn+5*time.Second {
+					t.Errorf("next attempt at %v, want about %v after %v", got.NextAttemptAt, tt.wantRetryIn, start)
+				}
+			case got.NextAttemptAt != nil:
+				t.Errorf("finished delivery is due again at %v", got.NextAttemptAt)
+			}
+			if tt.wantStatus == entity.DeliverySucceeded && (got.DeliveredAt == nil || got.LastError != "") {
+				t.Errorf("delivered at %v with error %q, want a time and no error", got.DeliveredAt, got.LastError)
+			}
+			if tt.wantStatus != entity.DeliverySucceeded && got.LastError == "" {
+				t.Error("failed delivery has no error")
+			}
+
+			// Finished deliveries and those waiting for a retry are not due.
+			if attempted, err := dispatcher.DispatchOnce(ctx); err != nil || attempted != 0 {
+				t.Errorf("second DispatchOnce() = %d, %v, want nothing due", attempted, err)
+			}
+		})
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: time.Hour},
+		{attempts: 50, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := exponentialBackoff(webhookRetryBackoff, webhookMaxBackoff, tt.attempts); got != tt.want {
+			t.Errorf("backoff after %d attempts = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookFanout(t *testing.T) {
+	ctx := context.Background()
+	subscriptions, deliveries := memory.NewWebhookSubscriptionRepository(), memory.NewWebhookDeliveryRepository()
+	for _, subscription := range []*entity.WebhookSubscription{
+		{URL: "https://a.example.com", EventTypes: entity.EventTypeList{entity.EventStudentStatusChanged}, Secret: "a", Active: true},
+		{URL: "https://b.example.com", EventTypes: entity.EventTypeList{entity.EventKRSSubmitted}, Secret: "b", Active: true},
+		{URL: "https://c.example.com", EventTypes: entity.EventTypeList{entity.EventStudentStatusChanged}, Secret: "c", Active: false},
+	} {
+		if err := subscriptions.Create(ctx, subscription); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fanout := NewWebhookFanout(subscriptions, deliveries)
+	msg := eventsink.Message{ID: uuid.New(), Type: entity.EventStudentStatusChanged, Payload: []byte(`{}`)}
+	// The relay publishes again after a failure; nothing is queued twice.
+	for range 2 {
+		if err := fanout.Publish(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queued, total, err := deliveries.FindAll(ctx, repository.DeliveryFilter{}, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("%d deliveries queued, want 1 for the active subscription wanting the event", total)
+	}
+	if d := queued[0]; d.EventID != msg.ID || d.Status != entity.DeliveryPending || d.NextAttemptAt == nil {
+		t.Errorf("delivery = %+v, want the event pending and due", d)
+	}
+}
+
+func TestWebhookSubscriptionURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{url: "https://93.184.216.34/hooks"},
+		{url: "http://10.0.0.5:8080/hooks"},
+		{url: "ftp://93.184.216.34/hooks", wantErr: "url must be an absolute http or https URL"},
+		{url: "http://127.0.0.1:9000/hooks", wantErr: "url must not point at an internal address: 127.0.0.1, an internal address"},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: "url must not point at an internal address: 169.254.169.254, an internal address"},
+		{url: "http://[::1]/hooks", wantErr: "url must not point at an internal address: ::1, an internal address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			uc := NewWebhookUseCase(memory.NewWebhookSubscriptionRepository(), memory.NewWebhookDeliveryRepository(),
+				memory.NewTxManager(), NewAuditor(memory.NewAuditRepository(), true), webhook.NewPolicy([]string{"10.0.0.5"}))
+			subscription := &entity.WebhookSubscription{URL: tt.url, EventTypes: entity.EventTypeList{entity.EventStudentStatusChanged}, Active: true}
+
+			err := uc.CreateSubscription(context.Background(), subscription)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CreateSubscription() = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr || apperror.KindOf(err) != apperror.KindValidation {
+				t.Errorf("CreateSubscription() = %v, want a validation error %q", err, tt.wantErr)
+			}
+		})
+	}
+}